@@ -0,0 +1,14 @@
+// The prototag command checks protobuf struct tags of message types.
+//
+// It can be run directly on packages, or through go vet:
+//
+//	go vet -vettool=$(which prototag) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/RomiChan/protobuf/analysis/prototag"
+)
+
+func main() { singlechecker.Main(prototag.Analyzer) }
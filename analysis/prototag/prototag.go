@@ -0,0 +1,313 @@
+// Package prototag defines an Analyzer that checks protobuf struct tags of
+// hand-written message types.
+//
+// The checks mirror what the proto runtime does when it first walks a
+// message type, so that mistakes which would otherwise panic (or silently
+// produce wrong bytes) at runtime are reported at build time instead.
+package prototag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const Doc = `check protobuf struct tags of message types
+
+The prototag checker reports struct fields whose protobuf, protobuf_key
+or protobuf_val tags cannot be handled by github.com/RomiChan/protobuf/proto:
+malformed tags, wire types that do not match the Go type of the field,
+map fields without key/value tags and duplicated field numbers.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "prototag",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const protoPackage = "github.com/RomiChan/protobuf/proto"
+
+// maxFieldNumber is the largest field number allowed by the protobuf spec.
+const maxFieldNumber = 1<<29 - 1
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+	ins.Preorder(nodeFilter, func(n ast.Node) {
+		st, ok := pass.TypesInfo.Types[n.(*ast.StructType)].Type.(*types.Struct)
+		if !ok {
+			return
+		}
+		checkStruct(pass, n.(*ast.StructType), st)
+	})
+	return nil, nil
+}
+
+func checkStruct(pass *analysis.Pass, node *ast.StructType, st *types.Struct) {
+	seen := make(map[int]string)
+	i := 0
+	for _, field := range node.Fields.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1 // embedded field
+		}
+		for j := 0; j < n; j, i = j+1, i+1 {
+			if field.Tag == nil {
+				continue
+			}
+			v := st.Field(i)
+			tag := reflect.StructTag(st.Tag(i))
+			value, ok := tag.Lookup("protobuf")
+			if !ok {
+				continue
+			}
+			if !v.Exported() {
+				pass.Reportf(field.Tag.Pos(), "protobuf tag on unexported field %s is ignored", v.Name())
+				continue
+			}
+			t, err := parseStructTag(value)
+			if err != nil {
+				pass.Reportf(field.Tag.Pos(), "%s: %v", v.Name(), err)
+				continue
+			}
+			if prev, ok := seen[t.fieldNumber]; ok {
+				pass.Reportf(field.Tag.Pos(), "%s: field number %d already used by %s", v.Name(), t.fieldNumber, prev)
+			} else {
+				seen[t.fieldNumber] = v.Name()
+			}
+			if err := checkField(v.Type(), t, tag); err != nil {
+				pass.Reportf(field.Tag.Pos(), "%s: %v", v.Name(), err)
+			}
+		}
+	}
+}
+
+// checkField reports whether a field of type typ can be encoded with tag t,
+// following the same decisions as walker.structInfo.
+func checkField(typ types.Type, t structTag, tag reflect.StructTag) error {
+	if elem, ok := optionElem(typ); ok {
+		return checkOption(elem, t)
+	}
+	if elem, ok := genericElem(typ, "Lazy"); ok {
+		if _, ok := elem.Underlying().(*types.Struct); !ok {
+			return fmt.Errorf("unsupported type proto.Lazy[%s]", typeString(elem))
+		}
+		return checkWireType(typ, t, "bytes")
+	}
+
+	switch u := baseType(typ).(type) {
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return checkWireType(typ, t, "bytes")
+		}
+		return checkRequired(u.Elem(), t)
+
+	case *types.Map:
+		if t.wireType != "bytes" {
+			return fmt.Errorf("map field must use wire type bytes, got %s", t.wireType)
+		}
+		if err := checkMapEntry(u.Key(), tag, "protobuf_key"); err != nil {
+			return err
+		}
+		return checkMapEntry(u.Elem(), tag, "protobuf_val")
+
+	case *types.Struct:
+		if _, ok := typ.Underlying().(*types.Pointer); !ok {
+			return fmt.Errorf("nested message %s must be a pointer", typeString(typ))
+		}
+		return checkWireType(typ, t, "bytes", "group")
+	}
+	return checkScalar(typ, t)
+}
+
+func checkMapEntry(typ types.Type, tag reflect.StructTag, key string) error {
+	value, ok := tag.Lookup(key)
+	if !ok {
+		return fmt.Errorf("map field is missing %s tag", key)
+	}
+	t, err := parseStructTag(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if err := checkRequired(typ, t); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// checkRequired checks the element type of repeated fields and map entries,
+// which are always encoded even when they hold the zero value.
+func checkRequired(typ types.Type, t structTag) error {
+	if _, ok := optionElem(typ); ok {
+		return fmt.Errorf("unsupported type %s", typeString(typ))
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Struct:
+		return fmt.Errorf("nested message %s must be a pointer", typeString(typ))
+	case *types.Slice:
+		if !isByte(u.Elem()) {
+			return fmt.Errorf("unsupported type %s", typeString(typ))
+		}
+		return checkWireType(typ, t, "bytes")
+	case *types.Pointer:
+		if _, ok := baseType(typ).(*types.Struct); ok {
+			return checkWireType(typ, t, "bytes", "group")
+		}
+	}
+	return checkScalar(typ, t)
+}
+
+func checkOption(elem types.Type, t structTag) error {
+	var wires []string
+	if b, ok := elem.Underlying().(*types.Basic); ok {
+		wires = scalarWireTypes(b.Kind())
+	}
+	if wires == nil {
+		return fmt.Errorf("unsupported type proto.Option[%s]", typeString(elem))
+	}
+	return checkWireType(elem, t, wires...)
+}
+
+func checkScalar(typ types.Type, t structTag) error {
+	b, ok := baseType(typ).(*types.Basic)
+	if !ok {
+		return fmt.Errorf("unsupported type %s", typeString(typ))
+	}
+	wires := scalarWireTypes(b.Kind())
+	if wires == nil {
+		return fmt.Errorf("unsupported type %s", typeString(typ))
+	}
+	return checkWireType(typ, t, wires...)
+}
+
+// scalarWireTypes returns the struct tag wire types a scalar kind can be
+// encoded with.
+func scalarWireTypes(kind types.BasicKind) []string {
+	switch kind {
+	case types.Bool:
+		return []string{"varint"}
+	case types.Int32:
+		return []string{"varint", "zigzag32", "fixed32"}
+	case types.Int64:
+		return []string{"varint", "zigzag64", "fixed64"}
+	case types.Uint32:
+		return []string{"varint", "fixed32"}
+	case types.Uint64:
+		return []string{"varint", "fixed64"}
+	case types.Float32:
+		return []string{"fixed32"}
+	case types.Float64:
+		return []string{"fixed64"}
+	case types.String:
+		return []string{"bytes"}
+	}
+	return nil
+}
+
+func checkWireType(typ types.Type, t structTag, allowed ...string) error {
+	for _, w := range allowed {
+		if t.wireType == w {
+			return nil
+		}
+	}
+	return fmt.Errorf("wire type %s does not match Go type %s (want %s)",
+		t.wireType, typeString(typ), strings.Join(allowed, " or "))
+}
+
+// optionElem returns T if typ is proto.Option[T].
+func optionElem(typ types.Type) (types.Type, bool) {
+	return genericElem(typ, "Option")
+}
+
+// genericElem returns T if typ is the generic type proto.name[T].
+func genericElem(typ types.Type, name string) (types.Type, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != protoPackage || obj.Name() != name {
+		return nil, false
+	}
+	args := named.TypeArgs()
+	if args == nil || args.Len() != 1 {
+		return nil, false
+	}
+	return args.At(0), true
+}
+
+// baseType returns the underlying type of typ with all pointers removed.
+func baseType(typ types.Type) types.Type {
+	u := typ.Underlying()
+	for {
+		p, ok := u.(*types.Pointer)
+		if !ok {
+			return u
+		}
+		u = p.Elem().Underlying()
+	}
+}
+
+func isByte(typ types.Type) bool {
+	b, ok := typ.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+func typeString(typ types.Type) string {
+	return types.TypeString(typ, func(p *types.Package) string { return p.Name() })
+}
+
+type structTag struct {
+	wireType    string
+	fieldNumber int
+}
+
+// parseStructTag accepts the same tags as the parseStructTag of the proto
+// package.
+func parseStructTag(tag string) (structTag, error) {
+	var t structTag
+	fields := strings.Split(tag, ",")
+	if len(fields) < 2 {
+		return t, fmt.Errorf("missing field number in struct tag %q", tag)
+	}
+	for i, f := range fields {
+		switch i {
+		case 0:
+			switch f {
+			case "varint", "bytes", "fixed32", "fixed64", "zigzag32", "zigzag64", "group":
+				t.wireType = f
+			default:
+				return t, fmt.Errorf("unsupported wire type in struct tag %q: %s", tag, f)
+			}
+
+		case 1:
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return t, fmt.Errorf("unsupported field number in struct tag %q: %w", tag, err)
+			}
+			if n < 1 || n > maxFieldNumber {
+				return t, fmt.Errorf("field number %d in struct tag %q is out of range", n, tag)
+			}
+			t.fieldNumber = n
+
+		case 2:
+			switch f {
+			case "opt", "rep", "req":
+			default:
+				return t, fmt.Errorf("unsupported field option in struct tag %q: %s", tag, f)
+			}
+		}
+	}
+	return t, nil
+}
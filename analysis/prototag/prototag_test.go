@@ -0,0 +1,14 @@
+package prototag_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/RomiChan/protobuf/analysis/prototag"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, prototag.Analyzer, "a")
+}
@@ -0,0 +1,57 @@
+package a
+
+import "github.com/RomiChan/protobuf/proto"
+
+type Enum = int32
+
+type Sub struct {
+	X string `protobuf:"bytes,1,opt"`
+}
+
+type Good struct {
+	A  int32                 `protobuf:"varint,1,opt"`
+	B  int64                 `protobuf:"zigzag64,2,opt"`
+	C  uint32                `protobuf:"fixed32,3,opt"`
+	D  float64               `protobuf:"fixed64,4,opt"`
+	E  string                `protobuf:"bytes,5,opt"`
+	F  []byte                `protobuf:"bytes,6,opt"`
+	G  *Sub                  `protobuf:"bytes,7,opt"`
+	H  []*Sub                `protobuf:"bytes,8,rep"`
+	I  []uint64              `protobuf:"varint,9,rep"`
+	J  map[string]*Sub       `protobuf:"bytes,10,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	K  proto.Option[float32] `protobuf:"fixed32,11,opt"`
+	L  proto.Option[int32]   `protobuf:"zigzag32,12,opt"`
+	M  Enum                  `protobuf:"varint,13,opt"`
+	N  *int64                `protobuf:"varint,14,opt"`
+	P  []int32               `protobuf:"fixed32,15,rep"`
+	Q  *Sub                  `protobuf:"group,16,opt"`
+	R  []*Sub                `protobuf:"group,17,rep"`
+	S  int32                 `protobuf:"varint,18,req"`
+	T  proto.Lazy[Sub]       `protobuf:"bytes,19,opt"`
+	O  isOneof               `protobuf_oneof:"o"`
+	no int
+}
+
+type isOneof interface{ isOneof() }
+
+type Bad struct {
+	A int64                 `protobuf:"fixed32,1,opt"`                                                        // want `A: wire type fixed32 does not match Go type int64 \(want varint or zigzag64 or fixed64\)`
+	B int32                 `protobuf:"varnit,2,opt"`                                                         // want `B: unsupported wire type in struct tag "varnit,2,opt": varnit`
+	C int32                 `protobuf:"varint,x,opt"`                                                         // want `C: unsupported field number in struct tag "varint,x,opt"`
+	D int32                 `protobuf:"varint,3,optional"`                                                    // want `D: unsupported field option in struct tag "varint,3,optional": optional`
+	E int32                 `protobuf:"varint,1,opt"`                                                         // want `E: field number 1 already used by A`
+	F map[int32]string      `protobuf:"bytes,4,rep"`                                                          // want `F: map field is missing protobuf_key tag`
+	G Sub                   `protobuf:"bytes,5,opt"`                                                          // want `G: nested message a.Sub must be a pointer`
+	H []Sub                 `protobuf:"bytes,6,rep"`                                                          // want `H: nested message a.Sub must be a pointer`
+	I int                   `protobuf:"varint,7,opt"`                                                         // want `I: unsupported type int`
+	J proto.Option[float32] `protobuf:"varint,8,opt"`                                                         // want `J: wire type varint does not match Go type float32 \(want fixed32\)`
+	K proto.Option[[]byte]  `protobuf:"bytes,9,opt"`                                                          // want `K: unsupported type proto.Option\[\[\]byte\]`
+	L float32               `protobuf:"fixed32,0,opt"`                                                        // want `L: field number 0 in struct tag "fixed32,0,opt" is out of range`
+	M string                `protobuf:"bytes"`                                                                // want `M: missing field number in struct tag "bytes"`
+	N []string              `protobuf:"varint,10,rep"`                                                        // want `N: wire type varint does not match Go type string \(want bytes\)`
+	m int32                 `protobuf:"varint,11,opt"`                                                        // want `protobuf tag on unexported field m is ignored`
+	O map[int32]string      `protobuf:"bytes,12,rep" protobuf_key:"fixed64,1,opt" protobuf_val:"bytes,2,opt"` // want `O: protobuf_key: wire type fixed64 does not match Go type int32 \(want varint or zigzag32 or fixed32\)`
+	P string                `protobuf:"group,13,opt"`                                                         // want `P: wire type group does not match Go type string \(want bytes\)`
+	Q proto.Lazy[Sub]       `protobuf:"group,14,opt"`                                                         // want `Q: wire type group does not match Go type proto.Lazy\[a.Sub\] \(want bytes\)`
+	R proto.Lazy[int32]     `protobuf:"bytes,15,opt"`                                                         // want `R: unsupported type proto.Lazy\[int32\]`
+}
@@ -0,0 +1,11 @@
+package proto
+
+type Option[T any] struct {
+	some  bool
+	value T
+}
+
+type Lazy[T any] struct {
+	raw   []byte
+	value *T
+}
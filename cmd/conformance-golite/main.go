@@ -0,0 +1,142 @@
+// The conformance-golite binary is a testee for the protobuf conformance test
+// suite. It reads ConformanceRequest messages from stdin and writes
+// ConformanceResponse messages to stdout, each prefixed by its length as a
+// little-endian uint32.
+//
+// Build the conformance_test_runner from the protobuf repository and run:
+//
+//	go build -o conformance-golite ./cmd/conformance-golite
+//	conformance_test_runner \
+//		--failure_list cmd/conformance-golite/failure_list_golite.txt \
+//		./conformance-golite
+//
+// Only binary payloads are supported for now, JSON, JSPB and text format
+// tests are reported as skipped. Tests that are known to fail are listed in
+// failure_list_golite.txt, the runner reports both new failures and tests
+// from the list that started to pass.
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/RomiChan/protobuf/internal/conformance"
+	"github.com/RomiChan/protobuf/proto"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("conformance-golite: ")
+	for {
+		ok, err := serve(os.Stdin, os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// serve handles a single request from r and writes the response to w. It
+// returns false when r is exhausted.
+func serve(r io.Reader, w io.Writer) (bool, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, fmt.Errorf("read request length: %w", err)
+	}
+	b := make([]byte, binary.LittleEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return false, fmt.Errorf("read request: %w", err)
+	}
+
+	req := &conformance.ConformanceRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		return false, fmt.Errorf("parse request: %w", err)
+	}
+	res := handle(req)
+
+	b, err := proto.Marshal(res)
+	if err != nil {
+		return false, fmt.Errorf("marshal response: %w", err)
+	}
+	binary.LittleEndian.PutUint32(size[:], uint32(len(b)))
+	if _, err := w.Write(size[:]); err != nil {
+		return false, fmt.Errorf("write response length: %w", err)
+	}
+	if _, err := w.Write(b); err != nil {
+		return false, fmt.Errorf("write response: %w", err)
+	}
+	return true, nil
+}
+
+func handle(req *conformance.ConformanceRequest) (res *conformance.ConformanceResponse) {
+	defer func() {
+		if err := recover(); err != nil {
+			res = &conformance.ConformanceResponse{
+				Result: &conformance.ConformanceResponse_RuntimeError{
+					RuntimeError: fmt.Sprint("panic: ", err),
+				},
+			}
+		}
+	}()
+
+	var msg interface{}
+	switch req.MessageType {
+	case "protobuf_test_messages.proto2.TestAllTypesProto2":
+		msg = &conformance.TestAllTypesProto2{}
+	case "protobuf_test_messages.proto3.TestAllTypesProto3":
+		msg = &conformance.TestAllTypesProto3{}
+	case "conformance.FailureSet":
+		// The runner asks for the failures the testee expects, golite keeps
+		// them in failure_list_golite.txt instead.
+		b, _ := proto.Marshal(&conformance.FailureSet{})
+		return &conformance.ConformanceResponse{
+			Result: &conformance.ConformanceResponse_ProtobufPayload{ProtobufPayload: b},
+		}
+	default:
+		return skipped("unknown message type " + req.MessageType)
+	}
+
+	switch p := req.Payload.(type) {
+	case *conformance.ConformanceRequest_ProtobufPayload:
+		if err := proto.Unmarshal(p.ProtobufPayload, msg); err != nil {
+			return &conformance.ConformanceResponse{
+				Result: &conformance.ConformanceResponse_ParseError{ParseError: err.Error()},
+			}
+		}
+	case nil:
+		return &conformance.ConformanceResponse{
+			Result: &conformance.ConformanceResponse_RuntimeError{RuntimeError: "request without payload"},
+		}
+	default:
+		return skipped(fmt.Sprintf("unsupported payload %T", p))
+	}
+
+	switch req.RequestedOutputFormat {
+	case conformance.WireFormat_PROTOBUF:
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			return &conformance.ConformanceResponse{
+				Result: &conformance.ConformanceResponse_SerializeError{SerializeError: err.Error()},
+			}
+		}
+		return &conformance.ConformanceResponse{
+			Result: &conformance.ConformanceResponse_ProtobufPayload{ProtobufPayload: b},
+		}
+	default:
+		return skipped(fmt.Sprintf("unsupported output format %d", req.RequestedOutputFormat))
+	}
+}
+
+func skipped(reason string) *conformance.ConformanceResponse {
+	return &conformance.ConformanceResponse{
+		Result: &conformance.ConformanceResponse_Skipped{Skipped: reason},
+	}
+}
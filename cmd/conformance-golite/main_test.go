@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/RomiChan/protobuf/internal/conformance"
+	"github.com/RomiChan/protobuf/proto"
+)
+
+func roundTrip(t *testing.T, reqs ...*conformance.ConformanceRequest) []*conformance.ConformanceResponse {
+	t.Helper()
+	var in bytes.Buffer
+	for _, req := range reqs {
+		b, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var size [4]byte
+		binary.LittleEndian.PutUint32(size[:], uint32(len(b)))
+		in.Write(size[:])
+		in.Write(b)
+	}
+
+	var out bytes.Buffer
+	for {
+		ok, err := serve(&in, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+	}
+
+	var ress []*conformance.ConformanceResponse
+	for out.Len() > 0 {
+		n := binary.LittleEndian.Uint32(out.Next(4))
+		res := &conformance.ConformanceResponse{}
+		if err := proto.Unmarshal(out.Next(int(n)), res); err != nil {
+			t.Fatal(err)
+		}
+		ress = append(ress, res)
+	}
+	if len(ress) != len(reqs) {
+		t.Fatalf("got %d responses, want %d", len(ress), len(reqs))
+	}
+	return ress
+}
+
+func binaryRequest(messageType string, payload []byte) *conformance.ConformanceRequest {
+	return &conformance.ConformanceRequest{
+		Payload:               &conformance.ConformanceRequest_ProtobufPayload{ProtobufPayload: payload},
+		RequestedOutputFormat: conformance.WireFormat_PROTOBUF,
+		MessageType:           messageType,
+		TestCategory:          conformance.TestCategory_BINARY_TEST,
+	}
+}
+
+func TestServe(t *testing.T) {
+	var payload []byte
+	// optional_sint32 = -1
+	payload = protowire.AppendTag(payload, 5, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(-1))
+	// packed_sfixed32 = [-1, 2]
+	payload = protowire.AppendTag(payload, 83, protowire.BytesType)
+	payload = protowire.AppendVarint(payload, 8)
+	payload = protowire.AppendFixed32(payload, 0xffffffff)
+	payload = protowire.AppendFixed32(payload, 2)
+	// map_sint32_sint32 = {-2: 3}
+	payload = protowire.AppendTag(payload, 60, protowire.BytesType)
+	payload = protowire.AppendVarint(payload, 4)
+	payload = protowire.AppendTag(payload, 1, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(-2))
+	payload = protowire.AppendTag(payload, 2, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(3))
+	// oneof_string = "golite"
+	payload = protowire.AppendTag(payload, 113, protowire.BytesType)
+	payload = protowire.AppendString(payload, "golite")
+	// Data { group_int32 = 7 }
+	payload = protowire.AppendTag(payload, 201, protowire.StartGroupType)
+	payload = protowire.AppendTag(payload, 202, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, 7)
+	payload = protowire.AppendTag(payload, 201, protowire.EndGroupType)
+
+	ress := roundTrip(t,
+		binaryRequest("protobuf_test_messages.proto2.TestAllTypesProto2", payload),
+		binaryRequest("protobuf_test_messages.proto2.TestAllTypesProto2", payload[:len(payload)-1]),
+		&conformance.ConformanceRequest{
+			Payload:               &conformance.ConformanceRequest_JsonPayload{JsonPayload: "{}"},
+			RequestedOutputFormat: conformance.WireFormat_JSON,
+			MessageType:           "protobuf_test_messages.proto3.TestAllTypesProto3",
+		},
+		binaryRequest("conformance.FailureSet", nil),
+		binaryRequest("protobuf_test_messages.proto3.TestAllTypesProto3", payload[:2]),
+	)
+
+	got, ok := ress[0].Result.(*conformance.ConformanceResponse_ProtobufPayload)
+	if !ok {
+		t.Fatalf("want protobuf payload, got %#v", ress[0].Result)
+	}
+	m := &conformance.TestAllTypesProto2{}
+	if err := proto.Unmarshal(got.ProtobufPayload, m); err != nil {
+		t.Fatal(err)
+	}
+	if v := m.OptionalSint32.Unwrap(); v != -1 {
+		t.Errorf("optional_sint32 = %d, want -1", v)
+	}
+	if v := m.PackedSfixed32; len(v) != 2 || v[0] != -1 || v[1] != 2 {
+		t.Errorf("packed_sfixed32 = %v, want [-1 2]", v)
+	}
+	if v := m.MapSint32Sint32; len(v) != 1 || v[-2] != 3 {
+		t.Errorf("map_sint32_sint32 = %v, want map[-2:3]", v)
+	}
+	if v := m.GetOneofString(); v != "golite" {
+		t.Errorf("oneof_string = %q, want golite", v)
+	}
+	if m.Data == nil || m.Data.GroupInt32.Unwrap() != 7 {
+		t.Errorf("data = %+v, want group_int32 = 7", m.Data)
+	}
+
+	if _, ok := ress[1].Result.(*conformance.ConformanceResponse_ParseError); !ok {
+		t.Errorf("truncated payload: want parse error, got %#v", ress[1].Result)
+	}
+	if _, ok := ress[2].Result.(*conformance.ConformanceResponse_Skipped); !ok {
+		t.Errorf("json payload: want skipped, got %#v", ress[2].Result)
+	}
+	if _, ok := ress[3].Result.(*conformance.ConformanceResponse_ProtobufPayload); !ok {
+		t.Errorf("failure set: want protobuf payload, got %#v", ress[3].Result)
+	}
+	if got, ok := ress[4].Result.(*conformance.ConformanceResponse_ProtobufPayload); !ok || !bytes.Equal(got.ProtobufPayload, payload[:2]) {
+		t.Errorf("proto3: want payload %x, got %#v", payload[:2], ress[4].Result)
+	}
+}
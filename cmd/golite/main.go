@@ -0,0 +1,155 @@
+// The golite command generates golite code from .proto files without
+// protoc.
+//
+// Usage:
+//
+//	golite generate [-I dir]... [-out dir] [-opt params] files.proto...
+//	golite schema [-o file] importpath.Type...
+//
+// generate parses the files in pure Go and passes them to the generator of
+// protoc-gen-golite, -opt takes the parameters of protoc-gen-golite, like
+// "paths=source_relative,register=true".
+//
+// schema writes a .proto file declaring the Go message structs and the
+// messages they reference, see proto.SchemaOf. It runs a program importing
+// the types in the module of the current directory.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	gengo "github.com/RomiChan/protobuf/internal/generator"
+	"github.com/RomiChan/protobuf/internal/protoparse"
+)
+
+const usage = `usage: golite <command> [arguments]
+
+commands:
+	generate	generate Go code from .proto files
+	schema		write a .proto file from Go message structs
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "generate":
+		err = generate(os.Args[2:])
+	case "schema":
+		err = schema(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "golite: unknown command %q\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "golite:", err)
+		os.Exit(1)
+	}
+}
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string     { return strings.Join(*s, string(filepath.ListSeparator)) }
+func (s *stringsFlag) Set(v string) error { *s = append(*s, v); return nil }
+
+func generate(args []string) error {
+	var (
+		importPaths stringsFlag
+		out         string
+		opt         string
+	)
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Var(&importPaths, "I", "directory searched for the imports, can be repeated")
+	flags.StringVar(&out, "out", ".", "output directory")
+	flags.StringVar(&opt, "opt", "", "comma separated parameters of protoc-gen-golite")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("no input files")
+	}
+	if len(importPaths) == 0 {
+		importPaths = stringsFlag{"."}
+	}
+
+	var names []string
+	for _, file := range flags.Args() {
+		name, err := importName(importPaths, file)
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+	parser := protoparse.Parser{ImportPaths: importPaths}
+	files, err := parser.ParseFiles(names...)
+	if err != nil {
+		return err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: names,
+		ProtoFile:      files,
+	}
+	if opt != "" {
+		req.Parameter = proto.String(opt)
+	}
+	var params flag.FlagSet
+	gengo.RegisterFlags(&params)
+	gen, err := protogen.Options{ParamFunc: params.Set}.New(req)
+	if err != nil {
+		return err
+	}
+	if err := gengo.Generate(gen); err != nil {
+		return err
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return errors.New(resp.GetError())
+	}
+	for _, f := range resp.File {
+		path := filepath.Join(out, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(f.GetContent()), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importName returns the name of file relative to the import path
+// containing it, like protoc. A name already relative to an import path is
+// accepted too.
+func importName(importPaths []string, file string) (string, error) {
+	for _, dir := range importPaths {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, rel)); err == nil {
+			return filepath.ToSlash(rel), nil
+		}
+	}
+	for _, dir := range importPaths {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return filepath.ToSlash(file), nil
+		}
+	}
+	return "", fmt.Errorf("%s: file not found in the import paths %v", file, importPaths)
+}
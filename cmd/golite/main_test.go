@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerate checks that the files generated without protoc are the
+// checked in files generated with protoc.
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		dir         string
+		importPaths []string
+		files       []string
+	}{
+		{
+			dir:         "../../proto/internal/testproto",
+			importPaths: []string{"../..", "../../proto/internal/testproto"},
+			files:       []string{"defaults.proto", "lazy.proto", "optional.proto", "proto2.proto", "redact.proto", "repeated.proto", "validate.proto"},
+		},
+		{
+			dir:   "../../internal/conformance",
+			files: []string{"conformance.proto", "test_messages_proto3.proto"},
+		},
+		{
+			dir:   "../../rpc/internal/echo",
+			files: []string{"echo.proto"},
+		},
+	}
+	for _, tt := range tests {
+		for _, file := range tt.files {
+			t.Run(file, func(t *testing.T) {
+				out := t.TempDir()
+				args := []string{"-out", out, "-I", tt.dir}
+				for _, dir := range tt.importPaths {
+					args = append(args, "-I", dir)
+				}
+				args = append(args, filepath.Join(tt.dir, file))
+				assert.NoError(t, generate(args))
+
+				name := file[:len(file)-len(".proto")] + ".pb.go"
+				want, err := os.ReadFile(filepath.Join(tt.dir, name))
+				assert.NoError(t, err)
+				got, err := os.ReadFile(filepath.Join(out, name))
+				assert.NoError(t, err)
+				assert.Equal(t, string(want), string(got))
+			})
+		}
+	}
+}
+
+func TestGenerateMessageSet(t *testing.T) {
+	err := generate([]string{"-out", t.TempDir(), "-I", "../../internal/conformance", "../../internal/conformance/test_messages_proto2.proto"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "message protobuf_test_messages.proto2.TestAllTypesProto2.MessageSetCorrect: MessageSet is not supported")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go")
+	}
+	out := filepath.Join(t.TempDir(), "schema.proto")
+	err := schema([]string{"-o", out, "github.com/RomiChan/protobuf/internal/conformance.ForeignMessage"})
+	assert.NoError(t, err)
+	got, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `syntax = "proto3";
+
+package conformance;
+
+option go_package = "github.com/RomiChan/protobuf/internal/conformance";
+
+message ForeignMessage {
+  int32 c = 1;
+}
+`, string(got))
+
+	assert.Error(t, schema([]string{"ForeignMessage"}))
+}
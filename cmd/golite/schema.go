@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var schemaProgram = template.Must(template.New("main").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	proto "github.com/RomiChan/protobuf/proto"
+{{range $i, $p := .Packages}}	p{{$i}} {{printf "%q" $p}}
+{{end}})
+
+func main() {
+	s, err := proto.SchemaOf(
+{{range .Types}}		(*p{{.Package}}.{{.Name}})(nil),
+{{end}}	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.WriteString(s)
+}
+`))
+
+type schemaType struct {
+	Package int
+	Name    string
+}
+
+func schema(args []string) error {
+	var out string
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.StringVar(&out, "o", "", "output file, the standard output if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("no types")
+	}
+
+	var data struct {
+		Packages []string
+		Types    []schemaType
+	}
+	index := make(map[string]int)
+	for _, arg := range flags.Args() {
+		pkg, name, err := splitType(arg)
+		if err != nil {
+			return err
+		}
+		i, ok := index[pkg]
+		if !ok {
+			i = len(data.Packages)
+			index[pkg] = i
+			data.Packages = append(data.Packages, pkg)
+		}
+		data.Types = append(data.Types, schemaType{Package: i, Name: name})
+	}
+
+	// The program is built in the current directory, so that it resolves the
+	// packages of the types, internal ones included, like the user's code.
+	dir, err := os.MkdirTemp(".", "golite-schema")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	var src bytes.Buffer
+	if err := schemaProgram.Execute(&src, data); err != nil {
+		return err
+	}
+	main := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(main, src.Bytes(), 0o644); err != nil {
+		return err
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", "run", main)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go run: %w", err)
+	}
+
+	if out == "" {
+		_, err := os.Stdout.Write(stdout.Bytes())
+		return err
+	}
+	return os.WriteFile(out, stdout.Bytes(), 0o644)
+}
+
+// splitType splits a type named like example.com/pkg.Type into the import
+// path and the type name.
+func splitType(arg string) (pkg, name string, err error) {
+	i := strings.LastIndexByte(arg, '.')
+	if i <= strings.LastIndexByte(arg, '/') || i == len(arg)-1 {
+		return "", "", fmt.Errorf("%s: want importpath.Type", arg)
+	}
+	return arg[:i], arg[i+1:], nil
+}
@@ -43,7 +43,6 @@ import (
 	"os"
 
 	"google.golang.org/protobuf/compiler/protogen"
-	"google.golang.org/protobuf/types/pluginpb"
 
 	gengo "github.com/RomiChan/protobuf/internal/generator"
 )
@@ -59,15 +58,8 @@ func main() {
 	var (
 		flags flag.FlagSet
 	)
+	gengo.RegisterFlags(&flags)
 	protogen.Options{
 		ParamFunc: flags.Set,
-	}.Run(func(gen *protogen.Plugin) error {
-		for _, f := range gen.Files {
-			if f.Generate {
-				gengo.GenerateFile(gen, f)
-			}
-		}
-		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		return nil
-	})
+	}.Run(gengo.Generate)
 }
@@ -0,0 +1,304 @@
+package dynamic
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// Marshal returns the encoding of m. The fields are encoded in declaration
+// order, followed by the unknown fields, like the static codecs encode a
+// generated struct; the map entries are encoded in key order.
+func Marshal(m *Message) ([]byte, error) {
+	return appendMessage(nil, m), nil
+}
+
+func appendMessage(b []byte, m *Message) []byte {
+	if m == nil {
+		return b
+	}
+	for _, f := range m.schema.Fields {
+		v, ok := m.values[f.Number]
+		if !ok {
+			continue
+		}
+		switch x := v.v.(type) {
+		case *Map:
+			for _, k := range x.keys() {
+				var e []byte
+				e = appendField(e, 1, x.key, Value{k})
+				e = appendField(e, 2, x.val, x.entries[k])
+				b = protowire.AppendTag(b, protowire.Number(f.Number), protowire.BytesType)
+				b = protowire.AppendBytes(b, e)
+			}
+		case *List:
+			for _, e := range x.elems {
+				b = appendField(b, f.Number, f.Kind, e)
+			}
+		default:
+			if !f.HasPresence && isZero(v) {
+				continue
+			}
+			b = appendField(b, f.Number, f.Kind, v)
+		}
+	}
+	return append(b, m.unknown...)
+}
+
+var wireTypes = map[proto.Kind]protowire.Type{
+	proto.BoolKind:     protowire.VarintType,
+	proto.Int32Kind:    protowire.VarintType,
+	proto.Sint32Kind:   protowire.VarintType,
+	proto.Uint32Kind:   protowire.VarintType,
+	proto.Int64Kind:    protowire.VarintType,
+	proto.Sint64Kind:   protowire.VarintType,
+	proto.Uint64Kind:   protowire.VarintType,
+	proto.Sfixed32Kind: protowire.Fixed32Type,
+	proto.Fixed32Kind:  protowire.Fixed32Type,
+	proto.FloatKind:    protowire.Fixed32Type,
+	proto.Sfixed64Kind: protowire.Fixed64Type,
+	proto.Fixed64Kind:  protowire.Fixed64Type,
+	proto.DoubleKind:   protowire.Fixed64Type,
+	proto.StringKind:   protowire.BytesType,
+	proto.BytesKind:    protowire.BytesType,
+	proto.MessageKind:  protowire.BytesType,
+	proto.GroupKind:    protowire.StartGroupType,
+}
+
+func appendField(b []byte, n int, k proto.Kind, v Value) []byte {
+	num := protowire.Number(n)
+	b = protowire.AppendTag(b, num, wireTypes[k])
+	switch k {
+	case proto.BoolKind:
+		return protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+	case proto.Int32Kind:
+		return protowire.AppendVarint(b, uint64(v.Int32()))
+	case proto.Sint32Kind:
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(int64(v.Int32())))
+	case proto.Uint32Kind:
+		return protowire.AppendVarint(b, uint64(v.Uint32()))
+	case proto.Int64Kind:
+		return protowire.AppendVarint(b, uint64(v.Int64()))
+	case proto.Sint64Kind:
+		return protowire.AppendVarint(b, protowire.EncodeZigZag(v.Int64()))
+	case proto.Uint64Kind:
+		return protowire.AppendVarint(b, v.Uint64())
+	case proto.Sfixed32Kind:
+		return protowire.AppendFixed32(b, uint32(v.Int32()))
+	case proto.Fixed32Kind:
+		return protowire.AppendFixed32(b, v.Uint32())
+	case proto.FloatKind:
+		return protowire.AppendFixed32(b, math.Float32bits(v.Float32()))
+	case proto.Sfixed64Kind:
+		return protowire.AppendFixed64(b, uint64(v.Int64()))
+	case proto.Fixed64Kind:
+		return protowire.AppendFixed64(b, v.Uint64())
+	case proto.DoubleKind:
+		return protowire.AppendFixed64(b, math.Float64bits(v.Float64()))
+	case proto.StringKind:
+		return protowire.AppendString(b, v.String())
+	case proto.BytesKind:
+		return protowire.AppendBytes(b, v.Bytes())
+	case proto.MessageKind:
+		return protowire.AppendBytes(b, appendMessage(nil, v.Message()))
+	case proto.GroupKind:
+		b = appendMessage(b, v.Message())
+		return protowire.AppendTag(b, num, protowire.EndGroupType)
+	}
+	panic(fmt.Sprintf("dynamic: cannot encode %s", k))
+}
+
+// Unmarshal decodes b into m, merging it with the fields already set. The
+// repeated scalars are accepted packed and unpacked; the fields which are
+// not in the schema of m, or whose wire type doesn't match their kind, are
+// kept as unknown fields.
+func Unmarshal(b []byte, m *Message) error {
+	if err := unmarshal(b, m, false); err != nil {
+		return fmt.Errorf("dynamic.Unmarshal(%s): %w", m.schema.Name, err)
+	}
+	return nil
+}
+
+// unmarshal decodes the fields of m, b ends with an end group tag if group
+// is true.
+func unmarshal(b []byte, m *Message, group bool) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		if typ == protowire.EndGroupType && group {
+			return nil
+		}
+		size := protowire.ConsumeFieldValue(num, typ, b[n:])
+		if size < 0 {
+			return protowire.ParseError(size)
+		}
+		field, raw := b[:n+size], b[n:n+size]
+		b = b[n+size:]
+		if f := m.schema.FieldByNumber(int(num)); f != nil {
+			ok, err := m.decodeField(f, typ, raw)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			if ok {
+				continue
+			}
+		}
+		m.unknown = append(m.unknown, field...)
+	}
+	return nil
+}
+
+// decodeField decodes the value raw of f, it returns false if the wire type
+// typ doesn't match the kind of f.
+func (m *Message) decodeField(f *Field, typ protowire.Type, raw []byte) (bool, error) {
+	switch {
+	case f.Kind == proto.MapKind:
+		if typ != protowire.BytesType {
+			return false, nil
+		}
+		raw, _ = protowire.ConsumeBytes(raw)
+		return true, m.Mutable(f.Number).Map().decodeEntry(raw)
+	case f.Cardinality == proto.Repeated:
+		l := m.Mutable(f.Number).List()
+		wt := wireTypes[f.Kind]
+		if typ == protowire.BytesType && wt != protowire.BytesType && wt != protowire.StartGroupType {
+			packed, _ := protowire.ConsumeBytes(raw)
+			for len(packed) > 0 {
+				size := protowire.ConsumeFieldValue(0, wt, packed)
+				if size < 0 {
+					return false, protowire.ParseError(size)
+				}
+				v, err := decodeValue(f.Kind, nil, wt, packed[:size], nil)
+				if err != nil {
+					return false, err
+				}
+				l.elems = append(l.elems, v)
+				packed = packed[size:]
+			}
+			return true, nil
+		}
+		if typ != wt {
+			return false, nil
+		}
+		v, err := decodeValue(f.Kind, f.Message, typ, raw, nil)
+		if err != nil {
+			return false, err
+		}
+		l.elems = append(l.elems, v)
+		return true, nil
+	}
+	if typ != wireTypes[f.Kind] {
+		return false, nil
+	}
+	var prev *Message
+	if f.Message != nil {
+		prev, _ = m.values[f.Number].v.(*Message)
+	}
+	v, err := decodeValue(f.Kind, f.Message, typ, raw, prev)
+	if err != nil {
+		return false, err
+	}
+	m.set(f, v)
+	return true, nil
+}
+
+// decodeEntry decodes a map entry into m, missing keys and values are zero.
+func (m *Map) decodeEntry(b []byte) error {
+	key, val := zeroValue(m.key), zeroValue(m.val)
+	if m.schema != nil {
+		val = Value{NewMessage(m.schema)}
+	}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		size := protowire.ConsumeFieldValue(num, typ, b[n:])
+		if size < 0 {
+			return protowire.ParseError(size)
+		}
+		raw := b[n : n+size]
+		b = b[n+size:]
+		var err error
+		switch {
+		case num == 1 && typ == wireTypes[m.key]:
+			key, err = decodeValue(m.key, nil, typ, raw, nil)
+		case num == 2 && typ == wireTypes[m.val]:
+			prev, _ := val.v.(*Message)
+			val, err = decodeValue(m.val, m.schema, typ, raw, prev)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	m.entries[key.v] = val
+	return nil
+}
+
+// decodeValue decodes the value raw of wire type typ, merging messages
+// into prev if it isn't nil.
+func decodeValue(k proto.Kind, s *Schema, typ protowire.Type, raw []byte, prev *Message) (Value, error) {
+	var (
+		u uint64
+		n int
+	)
+	switch typ {
+	case protowire.VarintType:
+		u, n = protowire.ConsumeVarint(raw)
+	case protowire.Fixed32Type:
+		var x uint32
+		x, n = protowire.ConsumeFixed32(raw)
+		u = uint64(x)
+	case protowire.Fixed64Type:
+		u, n = protowire.ConsumeFixed64(raw)
+	}
+	if n < 0 {
+		return Value{}, protowire.ParseError(n)
+	}
+	switch k {
+	case proto.BoolKind:
+		return Value{protowire.DecodeBool(u)}, nil
+	case proto.Int32Kind, proto.Sfixed32Kind:
+		return Value{int32(u)}, nil
+	case proto.Sint32Kind:
+		return Value{int32(protowire.DecodeZigZag(u & math.MaxUint32))}, nil
+	case proto.Uint32Kind, proto.Fixed32Kind:
+		return Value{uint32(u)}, nil
+	case proto.Int64Kind, proto.Sfixed64Kind:
+		return Value{int64(u)}, nil
+	case proto.Sint64Kind:
+		return Value{protowire.DecodeZigZag(u)}, nil
+	case proto.Uint64Kind, proto.Fixed64Kind:
+		return Value{u}, nil
+	case proto.FloatKind:
+		return Value{math.Float32frombits(uint32(u))}, nil
+	case proto.DoubleKind:
+		return Value{math.Float64frombits(u)}, nil
+	}
+	m := prev
+	if m == nil {
+		m = NewMessage(s)
+	}
+	switch k {
+	case proto.StringKind, proto.BytesKind, proto.MessageKind:
+		v, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return Value{}, protowire.ParseError(n)
+		}
+		switch k {
+		case proto.StringKind:
+			return Value{string(v)}, nil
+		case proto.BytesKind:
+			return Value{append([]byte{}, v...)}, nil
+		}
+		return Value{m}, unmarshal(v, m, false)
+	case proto.GroupKind:
+		return Value{m}, unmarshal(raw, m, true)
+	}
+	return Value{}, fmt.Errorf("cannot decode %s", k)
+}
@@ -0,0 +1,127 @@
+package dynamic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	. "github.com/RomiChan/protobuf/dynamic"
+	"github.com/RomiChan/protobuf/internal/conformance"
+	"github.com/RomiChan/protobuf/proto"
+)
+
+func allTypes() *conformance.TestAllTypesProto3 {
+	return &conformance.TestAllTypesProto3{
+		OptionalInt32:         -1,
+		OptionalInt64:         -2,
+		OptionalUint32:        3,
+		OptionalUint64:        4,
+		OptionalSint32:        -5,
+		OptionalSint64:        -6,
+		OptionalFixed32:       7,
+		OptionalFixed64:       8,
+		OptionalSfixed32:      -9,
+		OptionalSfixed64:      -10,
+		OptionalFloat:         1.5,
+		OptionalDouble:        -2.5,
+		OptionalBool:          true,
+		OptionalString:        "golite",
+		OptionalBytes:         []byte{0, 1},
+		OptionalNestedMessage: &conformance.TestAllTypesProto3_NestedMessage{A: 42},
+		OptionalNestedEnum:    conformance.TestAllTypesProto3_BAZ,
+		RecursiveMessage:      &conformance.TestAllTypesProto3{OptionalString: "inner"},
+		RepeatedInt32:         []int32{1, -1, 0},
+		RepeatedString:        []string{"a", ""},
+		RepeatedNestedMessage: []*conformance.TestAllTypesProto3_NestedMessage{{A: 1}, {}},
+		PackedDouble:          []float64{1, 2},
+		UnpackedSint64:        []int64{-3},
+		MapInt32Int32:         map[int32]int32{1: 2},
+		MapStringNestedMessage: map[string]*conformance.TestAllTypesProto3_NestedMessage{
+			"x": {A: 3},
+		},
+		MapBoolBool: map[bool]bool{false: false},
+		OneofField:  &conformance.TestAllTypesProto3_OneofString{OneofString: ""},
+		Fieldname1:  401,
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	r, err := ParseFiles([]string{"../internal/conformance"}, "test_messages_proto3.proto")
+	require.NoError(t, err)
+	s := r.Schema("protobuf_test_messages.proto3.TestAllTypesProto3")
+	require.NotNil(t, s)
+
+	b, err := proto.Marshal(allTypes())
+	require.NoError(t, err)
+	m := NewMessage(s)
+	require.NoError(t, Unmarshal(b, m))
+
+	assert.Equal(t, int32(-1), m.Get(1).Int32())
+	assert.Equal(t, int64(-6), m.Get(6).Int64())
+	assert.Equal(t, float32(1.5), m.Get(11).Float32())
+	assert.Equal(t, "golite", m.Get(14).String())
+	assert.Equal(t, []byte{0, 1}, m.Get(15).Bytes())
+	assert.Equal(t, int32(42), m.Get(18).Message().Get(1).Int32())
+	assert.Equal(t, int32(2), m.Get(21).Int32())
+	assert.Equal(t, "inner", m.Get(27).Message().Get(14).String())
+	assert.Equal(t, 3, m.Get(31).List().Len())
+	assert.Equal(t, int32(-1), m.Get(31).List().Get(1).Int32())
+	v, ok := m.Get(71).Map().Get("x")
+	assert.True(t, ok)
+	assert.Equal(t, int32(3), v.Message().Get(1).Int32())
+	assert.True(t, m.Has(113))
+	assert.Equal(t, "", m.Get(113).String())
+	assert.False(t, m.Has(2000))
+	assert.Equal(t, 0, m.Get(32).List().Len())
+	assert.Nil(t, m.Get(19).Message())
+
+	out, err := Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, b, out)
+
+	// setting a oneof member clears the others
+	require.NoError(t, m.Set(111, ValueOf(uint32(7))))
+	assert.False(t, m.Has(113))
+	assert.Error(t, m.Set(111, ValueOf("7")))
+	assert.Error(t, m.Set(18, ValueOf(NewMessage(s))))
+
+	var static conformance.TestAllTypesProto3
+	out, err = Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(out, &static))
+	assert.Equal(t, &conformance.TestAllTypesProto3_OneofUint32{OneofUint32: 7}, static.OneofField)
+}
+
+func TestUnmarshal(t *testing.T) {
+	s := NewSchema((&durationpb.Duration{}).ProtoReflect().Descriptor())
+	m := NewMessage(s)
+
+	// unknown fields and mismatched wire types are kept
+	b := []byte{0x08, 0x01, 0x15, 1, 2, 3, 4, 0x18, 0x05}
+	require.NoError(t, Unmarshal(b, m))
+	assert.Equal(t, int64(1), m.Get(1).Int64())
+	assert.Equal(t, int32(0), m.Get(2).Int32())
+	assert.Equal(t, []byte{0x15, 1, 2, 3, 4, 0x18, 0x05}, m.Unknown())
+	out, err := Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, b, out)
+
+	assert.Error(t, Unmarshal([]byte{0x08}, NewMessage(s)))
+}
+
+func TestPacked(t *testing.T) {
+	r, err := ParseFiles([]string{"../internal/conformance"}, "test_messages_proto3.proto")
+	require.NoError(t, err)
+	m := NewMessage(r.Schema("protobuf_test_messages.proto3.TestAllTypesProto3"))
+
+	// packed and unpacked encodings of repeated_int32
+	require.NoError(t, Unmarshal([]byte{0xfa, 0x01, 2, 1, 2, 0xf8, 0x01, 3}, m))
+	l := m.Get(31).List()
+	require.Equal(t, 3, l.Len())
+	assert.Equal(t, int32(3), l.Get(2).Int32())
+	out, err := Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xf8, 0x01, 1, 0xf8, 0x01, 2, 0xf8, 0x01, 3}, out)
+}
@@ -0,0 +1,168 @@
+package dynamic
+
+import (
+	"fmt"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// Message is a message of a runtime schema, its fields are keyed by number.
+// A nil *Message is an empty message for the read accessors.
+type Message struct {
+	schema  *Schema
+	values  map[int]Value
+	unknown []byte
+}
+
+// NewMessage returns an empty message of the schema s.
+func NewMessage(s *Schema) *Message {
+	return &Message{schema: s, values: make(map[int]Value)}
+}
+
+// Schema returns the schema of m.
+func (m *Message) Schema() *Schema {
+	return m.schema
+}
+
+// Has reports whether the field with number n is set: repeated fields and
+// maps must be non-empty.
+func (m *Message) Has(n int) bool {
+	if m == nil {
+		return false
+	}
+	v, ok := m.values[n]
+	if !ok {
+		return false
+	}
+	switch x := v.v.(type) {
+	case *List:
+		return x.Len() > 0
+	case *Map:
+		return x.Len() > 0
+	}
+	return true
+}
+
+// Get returns the value of the field with number n. Unset scalars return
+// their zero value, unset messages, repeated fields and maps a nil
+// *Message, *List or *Map. Get returns an invalid value if the schema has
+// no field n.
+func (m *Message) Get(n int) Value {
+	if m == nil {
+		return Value{}
+	}
+	if v, ok := m.values[n]; ok {
+		return v
+	}
+	f := m.schema.FieldByNumber(n)
+	switch {
+	case f == nil:
+		return Value{}
+	case f.Kind == proto.MapKind:
+		return Value{(*Map)(nil)}
+	case f.Cardinality == proto.Repeated:
+		return Value{(*List)(nil)}
+	}
+	return zeroValue(f.Kind)
+}
+
+// Set sets the field with number n to v, which must be of the type of the
+// field. Setting a oneof member clears the other members of the oneof.
+func (m *Message) Set(n int, v Value) error {
+	f := m.schema.FieldByNumber(n)
+	if f == nil {
+		return fmt.Errorf("dynamic.Set(%s): %w %d", m.schema.Name, proto.ErrUnknownField, n)
+	}
+	var err error
+	switch {
+	case f.Kind == proto.MapKind:
+		x, ok := v.v.(*Map)
+		if !ok || x == nil || x.key != f.MapKey || x.val != f.MapValue || x.schema != f.Message {
+			err = fmt.Errorf("cannot use %T as map field", v.v)
+		}
+	case f.Cardinality == proto.Repeated:
+		x, ok := v.v.(*List)
+		if !ok || x == nil || x.kind != f.Kind || x.schema != f.Message {
+			err = fmt.Errorf("cannot use %T as repeated field", v.v)
+		}
+	default:
+		err = checkScalar(f.Kind, f.Message, v)
+	}
+	if err != nil {
+		return fmt.Errorf("dynamic.Set(%s): field %s: %w", m.schema.Name, f.Name, err)
+	}
+	m.set(f, v)
+	return nil
+}
+
+func (m *Message) set(f *Field, v Value) {
+	if f.Oneof != "" {
+		for _, other := range m.schema.Fields {
+			if other.Oneof == f.Oneof {
+				delete(m.values, other.Number)
+			}
+		}
+	}
+	m.values[f.Number] = v
+}
+
+// Clear unsets the field with number n.
+func (m *Message) Clear(n int) {
+	delete(m.values, n)
+}
+
+// Mutable returns the value of the message, repeated or map field with
+// number n, setting it to an empty value if it is unset.
+func (m *Message) Mutable(n int) Value {
+	f := m.schema.FieldByNumber(n)
+	if f == nil {
+		panic(fmt.Sprintf("dynamic.Mutable(%s): unknown field %d", m.schema.Name, n))
+	}
+	if v, ok := m.values[n]; ok {
+		return v
+	}
+	var v Value
+	switch {
+	case f.Kind == proto.MapKind:
+		v = Value{NewMap(f)}
+	case f.Cardinality == proto.Repeated:
+		v = Value{NewList(f)}
+	case f.Message != nil:
+		v = Value{NewMessage(f.Message)}
+	default:
+		panic(fmt.Sprintf("dynamic.Mutable(%s): field %s is a scalar", m.schema.Name, f.Name))
+	}
+	m.set(f, v)
+	return v
+}
+
+// NewList returns an empty list for the repeated field f.
+func NewList(f *Field) *List {
+	return &List{kind: f.Kind, schema: f.Message}
+}
+
+// NewMap returns an empty map for the map field f.
+func NewMap(f *Field) *Map {
+	return &Map{key: f.MapKey, val: f.MapValue, schema: f.Message, entries: make(map[interface{}]Value)}
+}
+
+// Range calls fn for the set fields of m in declaration order, until fn
+// returns false.
+func (m *Message) Range(fn func(f *Field, v Value) bool) {
+	if m == nil {
+		return
+	}
+	for _, f := range m.schema.Fields {
+		if m.Has(f.Number) && !fn(f, m.values[f.Number]) {
+			return
+		}
+	}
+}
+
+// Unknown returns the encoded fields of m which are not in its schema.
+func (m *Message) Unknown() []byte {
+	if m == nil {
+		return nil
+	}
+	return m.unknown
+}
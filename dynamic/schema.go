@@ -0,0 +1,181 @@
+// Package dynamic decodes and encodes messages whose types are only known at
+// runtime.
+//
+// A Schema, built from a descriptor or from parsed .proto files, drives the
+// decoding of a message into a Message, a tree of values keyed by field
+// number. Marshal encodes a Message like the static codecs of the proto
+// package encode the equivalent generated struct.
+package dynamic
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/RomiChan/protobuf/internal/protoparse"
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// Schema describes a message type.
+type Schema struct {
+	Name   string   // full name of the message
+	Fields []*Field // in declaration order
+
+	byNumber map[int]*Field
+	byName   map[string]*Field
+}
+
+// Field describes a field of a message.
+type Field struct {
+	Name        string // proto name of the field
+	Number      int
+	Kind        proto.Kind // enums are Int32Kind, like in generated code
+	Cardinality proto.Cardinality
+	Oneof       string // name of the oneof the field belongs to, if any
+
+	// HasPresence reports whether the field is encoded when it is set to
+	// its zero value: messages, oneof members, proto2 fields and proto3
+	// optional fields.
+	HasPresence bool
+
+	// Message is the schema of message and group fields, and of the values
+	// of maps of messages.
+	Message *Schema
+
+	// MapKey and MapValue are the kinds of the keys and values of map fields.
+	MapKey   proto.Kind
+	MapValue proto.Kind
+}
+
+// FieldByNumber returns the field with number n, nil if there is none.
+func (s *Schema) FieldByNumber(n int) *Field {
+	return s.byNumber[n]
+}
+
+// FieldByName returns the field with the proto name name, nil if there is
+// none.
+func (s *Schema) FieldByName(name string) *Field {
+	return s.byName[name]
+}
+
+var kinds = map[protoreflect.Kind]proto.Kind{
+	protoreflect.BoolKind:     proto.BoolKind,
+	protoreflect.EnumKind:     proto.Int32Kind,
+	protoreflect.Int32Kind:    proto.Int32Kind,
+	protoreflect.Sint32Kind:   proto.Sint32Kind,
+	protoreflect.Uint32Kind:   proto.Uint32Kind,
+	protoreflect.Int64Kind:    proto.Int64Kind,
+	protoreflect.Sint64Kind:   proto.Sint64Kind,
+	protoreflect.Uint64Kind:   proto.Uint64Kind,
+	protoreflect.Sfixed32Kind: proto.Sfixed32Kind,
+	protoreflect.Fixed32Kind:  proto.Fixed32Kind,
+	protoreflect.FloatKind:    proto.FloatKind,
+	protoreflect.Sfixed64Kind: proto.Sfixed64Kind,
+	protoreflect.Fixed64Kind:  proto.Fixed64Kind,
+	protoreflect.DoubleKind:   proto.DoubleKind,
+	protoreflect.StringKind:   proto.StringKind,
+	protoreflect.BytesKind:    proto.BytesKind,
+	protoreflect.MessageKind:  proto.MessageKind,
+	protoreflect.GroupKind:    proto.GroupKind,
+}
+
+// NewSchema returns the schema of the message descriptor md.
+func NewSchema(md protoreflect.MessageDescriptor) *Schema {
+	return newSchema(md, make(map[protoreflect.FullName]*Schema))
+}
+
+// newSchema converts md, seen holds the schemas being built for the
+// recursive messages.
+func newSchema(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]*Schema) *Schema {
+	if s, ok := seen[md.FullName()]; ok {
+		return s
+	}
+	s := &Schema{
+		Name:     string(md.FullName()),
+		byNumber: make(map[int]*Field),
+		byName:   make(map[string]*Field),
+	}
+	seen[md.FullName()] = s
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		f := &Field{
+			Name:        string(fd.Name()),
+			Number:      int(fd.Number()),
+			Kind:        kinds[fd.Kind()],
+			HasPresence: fd.HasPresence(),
+		}
+		switch fd.Cardinality() {
+		case protoreflect.Required:
+			f.Cardinality = proto.Required
+		case protoreflect.Repeated:
+			f.Cardinality = proto.Repeated
+		}
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			f.Oneof = string(od.Name())
+		}
+		switch {
+		case fd.IsMap():
+			f.Kind = proto.MapKind
+			f.MapKey = kinds[fd.MapKey().Kind()]
+			f.MapValue = kinds[fd.MapValue().Kind()]
+			if vd := fd.MapValue().Message(); vd != nil {
+				f.Message = newSchema(vd, seen)
+			}
+		case fd.Message() != nil:
+			f.Message = newSchema(fd.Message(), seen)
+		}
+		s.Fields = append(s.Fields, f)
+		s.byNumber[f.Number] = f
+		s.byName[f.Name] = f
+	}
+	return s
+}
+
+// Registry holds the schemas of the messages of a set of files.
+type Registry struct {
+	schemas map[string]*Schema
+}
+
+// NewRegistry returns the schemas of the messages of files, like the files
+// of a FileDescriptorSet. The files must be given after their imports.
+func NewRegistry(files []*descriptorpb.FileDescriptorProto) (*Registry, error) {
+	fds, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: files})
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{schemas: make(map[string]*Schema)}
+	seen := make(map[protoreflect.FullName]*Schema)
+	var add func(messages protoreflect.MessageDescriptors)
+	add = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			r.schemas[string(md.FullName())] = newSchema(md, seen)
+			add(md.Messages())
+		}
+	}
+	fds.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		add(fd.Messages())
+		return true
+	})
+	return r, nil
+}
+
+// ParseFiles parses the .proto files named relative to the import paths and
+// returns the schemas of their messages and of the messages they import.
+func ParseFiles(importPaths []string, names ...string) (*Registry, error) {
+	p := protoparse.Parser{ImportPaths: importPaths}
+	files, err := p.ParseFiles(names...)
+	if err != nil {
+		return nil, fmt.Errorf("dynamic.ParseFiles: %w", err)
+	}
+	return NewRegistry(files)
+}
+
+// Schema returns the schema of the message with the full name name, nil if
+// there is none.
+func (r *Registry) Schema(name string) *Schema {
+	return r.schemas[name]
+}
@@ -0,0 +1,240 @@
+package dynamic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// Value is the value of a field: a bool, int32, int64, uint32, uint64,
+// float32, float64, string, []byte, *Message, *List or *Map. The accessors
+// panic if the value holds another type.
+type Value struct {
+	v interface{}
+}
+
+// ValueOf returns the value holding v, it panics if v has none of the types
+// of the values.
+func ValueOf(v interface{}) Value {
+	switch v.(type) {
+	case bool, int32, int64, uint32, uint64, float32, float64, string, []byte, *Message, *List, *Map:
+		return Value{v}
+	}
+	panic(fmt.Sprintf("dynamic.ValueOf: invalid type %T", v))
+}
+
+// IsValid reports whether v holds a value.
+func (v Value) IsValid() bool { return v.v != nil }
+
+// Interface returns the value held by v.
+func (v Value) Interface() interface{} { return v.v }
+
+func (v Value) Bool() bool        { return v.v.(bool) }
+func (v Value) Int32() int32      { return v.v.(int32) }
+func (v Value) Int64() int64      { return v.v.(int64) }
+func (v Value) Uint32() uint32    { return v.v.(uint32) }
+func (v Value) Uint64() uint64    { return v.v.(uint64) }
+func (v Value) Float32() float32  { return v.v.(float32) }
+func (v Value) Float64() float64  { return v.v.(float64) }
+func (v Value) String() string    { return v.v.(string) }
+func (v Value) Bytes() []byte     { return v.v.([]byte) }
+func (v Value) Message() *Message { return v.v.(*Message) }
+func (v Value) List() *List       { return v.v.(*List) }
+func (v Value) Map() *Map         { return v.v.(*Map) }
+
+// zeroValue returns the value of an unset scalar field of kind k, or of an
+// unset message field.
+func zeroValue(k proto.Kind) Value {
+	switch k {
+	case proto.BoolKind:
+		return Value{false}
+	case proto.Int32Kind, proto.Sint32Kind, proto.Sfixed32Kind:
+		return Value{int32(0)}
+	case proto.Int64Kind, proto.Sint64Kind, proto.Sfixed64Kind:
+		return Value{int64(0)}
+	case proto.Uint32Kind, proto.Fixed32Kind:
+		return Value{uint32(0)}
+	case proto.Uint64Kind, proto.Fixed64Kind:
+		return Value{uint64(0)}
+	case proto.FloatKind:
+		return Value{float32(0)}
+	case proto.DoubleKind:
+		return Value{float64(0)}
+	case proto.StringKind:
+		return Value{""}
+	case proto.BytesKind:
+		return Value{[]byte(nil)}
+	}
+	return Value{(*Message)(nil)}
+}
+
+// checkScalar checks that v holds a value of kind k, with the schema s for
+// messages.
+func checkScalar(k proto.Kind, s *Schema, v Value) error {
+	if !v.IsValid() {
+		return fmt.Errorf("invalid value for %s field", k)
+	}
+	if k == proto.MessageKind || k == proto.GroupKind {
+		m, ok := v.v.(*Message)
+		if !ok || m == nil {
+			return fmt.Errorf("cannot use %T as %s", v.v, s.Name)
+		}
+		if m.schema != s {
+			return fmt.Errorf("cannot use %s as %s", m.schema.Name, s.Name)
+		}
+		return nil
+	}
+	want := zeroValue(k).v
+	if fmt.Sprintf("%T", v.v) != fmt.Sprintf("%T", want) {
+		return fmt.Errorf("cannot use %T as %s", v.v, k)
+	}
+	return nil
+}
+
+// isZero reports whether v is the zero value of a scalar, zero scalars of
+// fields without presence are not encoded.
+func isZero(v Value) bool {
+	switch x := v.v.(type) {
+	case bool:
+		return !x
+	case int32:
+		return x == 0
+	case int64:
+		return x == 0
+	case uint32:
+		return x == 0
+	case uint64:
+		return x == 0
+	case float32:
+		return x == 0
+	case float64:
+		return x == 0
+	case string:
+		return x == ""
+	case []byte:
+		return len(x) == 0
+	}
+	return false
+}
+
+// List is the value of a repeated field. A nil *List is an empty list.
+type List struct {
+	kind   proto.Kind
+	schema *Schema
+	elems  []Value
+}
+
+// Len returns the number of elements of l.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.elems)
+}
+
+// Get returns the element i of l.
+func (l *List) Get(i int) Value {
+	return l.elems[i]
+}
+
+// Set sets the element i of l to v, v must be of the kind of the elements.
+func (l *List) Set(i int, v Value) error {
+	if err := checkScalar(l.kind, l.schema, v); err != nil {
+		return err
+	}
+	l.elems[i] = v
+	return nil
+}
+
+// Append appends v to l, v must be of the kind of the elements.
+func (l *List) Append(v Value) error {
+	if err := checkScalar(l.kind, l.schema, v); err != nil {
+		return err
+	}
+	l.elems = append(l.elems, v)
+	return nil
+}
+
+// Map is the value of a map field, its keys are bool, int32, int64, uint32,
+// uint64 or string values. A nil *Map is an empty map.
+type Map struct {
+	key, val proto.Kind
+	schema   *Schema
+	entries  map[interface{}]Value
+}
+
+// Len returns the number of entries of m.
+func (m *Map) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.entries)
+}
+
+// Get returns the value of key, ok is false if m has no such key.
+func (m *Map) Get(key interface{}) (v Value, ok bool) {
+	if m == nil {
+		return Value{}, false
+	}
+	v, ok = m.entries[key]
+	return v, ok
+}
+
+// Set sets the value of key, they must be of the kinds of the map.
+func (m *Map) Set(key interface{}, v Value) error {
+	if err := checkScalar(m.key, nil, Value{key}); err != nil {
+		return fmt.Errorf("map key: %w", err)
+	}
+	if err := checkScalar(m.val, m.schema, v); err != nil {
+		return err
+	}
+	m.entries[key] = v
+	return nil
+}
+
+// Delete removes key from m.
+func (m *Map) Delete(key interface{}) {
+	if m != nil {
+		delete(m.entries, key)
+	}
+}
+
+// Range calls fn for the entries of m in key order, until fn returns false.
+func (m *Map) Range(fn func(key interface{}, v Value) bool) {
+	for _, k := range m.keys() {
+		if !fn(k, m.entries[k]) {
+			return
+		}
+	}
+}
+
+func (m *Map) keys() []interface{} {
+	if m == nil {
+		return nil
+	}
+	keys := make([]interface{}, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+	return keys
+}
+
+func lessKey(x, y interface{}) bool {
+	switch x := x.(type) {
+	case bool:
+		return !x && y.(bool)
+	case int32:
+		return x < y.(int32)
+	case int64:
+		return x < y.(int64)
+	case uint32:
+		return x < y.(uint32)
+	case uint64:
+		return x < y.(uint64)
+	case string:
+		return x < y.(string)
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+// Package grpccodec provides a gRPC codec for golite messages.
+//
+// Codec has the method set of google.golang.org/grpc/encoding.Codec without
+// importing grpc, register it to use golite messages in gRPC services:
+//
+//	encoding.RegisterCodec(grpccodec.Codec{})
+//
+// It replaces the default "proto" codec, messages of google.golang.org/protobuf
+// are still handled with the upstream runtime, so services may mix both.
+package grpccodec
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	upstream "google.golang.org/protobuf/proto"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// Name is the content-subtype of the codec, the same as the default codec
+// of grpc.
+const Name = "proto"
+
+// Codec encodes golite messages, which must be pointers to structs, and
+// messages of google.golang.org/protobuf.
+type Codec struct{}
+
+func (Codec) Name() string {
+	return Name
+}
+
+// Marshal returns the encoding of v in a new buffer, which is owned by the
+// caller, as encoding.Codec requires. See MarshalBuffer for pooled buffers.
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	if m, ok := v.(upstream.Message); ok {
+		return upstream.Marshal(m)
+	}
+	if err := checkMessage(v); err != nil {
+		return nil, err
+	}
+	return proto.MarshalAppend(make([]byte, 0, proto.Size(v)), v)
+}
+
+// Unmarshal replaces the contents of v with the message in data.
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	if m, ok := v.(upstream.Message); ok {
+		return upstream.Unmarshal(data, m)
+	}
+	if err := checkMessage(v); err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	return proto.Unmarshal(data, v)
+}
+
+// maxPooledSize is the capacity above which buffers are not kept in the pool,
+// so that an occasional large message doesn't pin its memory.
+const maxPooledSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(Buffer) },
+}
+
+// Buffer holds an encoded message taken from a pool.
+type Buffer struct {
+	B []byte
+}
+
+// Free returns the buffer to the pool, b.B must not be used afterwards.
+func (b *Buffer) Free() {
+	if cap(b.B) > maxPooledSize {
+		return
+	}
+	b.B = b.B[:0]
+	bufferPool.Put(b)
+}
+
+// MarshalBuffer is like Marshal, but encodes v into a pooled buffer. Transports
+// that are done with the bytes once written can use it to avoid allocating
+// a buffer per message.
+func (Codec) MarshalBuffer(v interface{}) (*Buffer, error) {
+	b := bufferPool.Get().(*Buffer)
+	var err error
+	if m, ok := v.(upstream.Message); ok {
+		b.B, err = upstream.MarshalOptions{}.MarshalAppend(b.B[:0], m)
+	} else if err = checkMessage(v); err == nil {
+		b.B, err = proto.MarshalAppend(b.B[:0], v)
+	}
+	if err != nil {
+		b.Free()
+		return nil, err
+	}
+	return b, nil
+}
+
+func checkMessage(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("grpccodec: %T is not a non-nil pointer to a message", v)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package grpccodec
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// codec is the interface of google.golang.org/grpc/encoding.Codec.
+type codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	Name() string
+}
+
+var _ codec = Codec{}
+
+type echoRequest struct {
+	Message string              `protobuf:"bytes,1,opt"`
+	Repeat  proto.Option[int32] `protobuf:"varint,2,opt"`
+}
+
+type echoResponse struct {
+	Messages []string `protobuf:"bytes,1,rep"`
+}
+
+// writeFrame writes a message with the length-prefixed framing of gRPC.
+func writeFrame(w io.Writer, b []byte) error {
+	var hdr [5]byte // compressed flag and length
+	binary.BigEndian.PutUint32(hdr[1:], uint32(len(b)))
+	_, err := w.Write(append(hdr[:], b...))
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var hdr [5]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+// serve starts a stand-in for a gRPC server on an in-memory connection, it
+// decodes requests of type Req, calls handler and encodes the responses with
+// pooled buffers. wait closes the client and waits for the server to exit.
+func serve[Req any](t *testing.T, handler func(*Req) interface{}) (client net.Conn, wait func()) {
+	client, conn := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer conn.Close()
+		serveConn(t, conn, handler)
+	}()
+	return client, func() {
+		client.Close()
+		<-done
+	}
+}
+
+func serveConn[Req any](t *testing.T, conn net.Conn, handler func(*Req) interface{}) {
+	c := Codec{}
+	for {
+		b, err := readFrame(conn)
+		if err == io.EOF {
+			return
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		req := new(Req)
+		if !assert.NoError(t, c.Unmarshal(b, req)) {
+			return
+		}
+		buf, err := c.MarshalBuffer(handler(req))
+		if !assert.NoError(t, err) {
+			return
+		}
+		err = writeFrame(conn, buf.B)
+		buf.Free()
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+}
+
+func call(t *testing.T, conn net.Conn, req, res interface{}) {
+	t.Helper()
+	c := Codec{}
+	b, err := c.Marshal(req)
+	assert.NoError(t, err)
+	assert.NoError(t, writeFrame(conn, b))
+	b, err = readFrame(conn)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Unmarshal(b, res))
+}
+
+func TestServer(t *testing.T) {
+	client, wait := serve(t, func(req *echoRequest) interface{} {
+		res := &echoResponse{}
+		for i := int32(0); i < req.Repeat.UnwrapOr(1); i++ {
+			res.Messages = append(res.Messages, req.Message)
+		}
+		return res
+	})
+	defer wait()
+
+	for _, tt := range []struct {
+		req  *echoRequest
+		want []string
+	}{
+		{&echoRequest{Message: "hello"}, []string{"hello"}},
+		{&echoRequest{Message: "hi", Repeat: proto.Some[int32](3)}, []string{"hi", "hi", "hi"}},
+		{&echoRequest{Repeat: proto.Some[int32](0)}, nil},
+	} {
+		res := &echoResponse{Messages: []string{"stale"}}
+		call(t, client, tt.req, res)
+		assert.Equal(t, tt.want, res.Messages)
+	}
+}
+
+func TestUpstreamMessages(t *testing.T) {
+	client, wait := serve(t, func(req *durationpb.Duration) interface{} {
+		return &durationpb.Duration{Seconds: req.Seconds * 2}
+	})
+	defer wait()
+
+	res := &durationpb.Duration{}
+	call(t, client, durationpb.New(3e9), res)
+	assert.Equal(t, int64(6), res.Seconds)
+}
+
+func TestCodec(t *testing.T) {
+	c := Codec{}
+	assert.Equal(t, "proto", c.Name())
+
+	_, err := c.Marshal(echoRequest{})
+	assert.Error(t, err)
+	_, err = c.MarshalBuffer((*echoRequest)(nil))
+	assert.Error(t, err)
+	assert.Error(t, c.Unmarshal(nil, echoRequest{}))
+
+	b, err := c.MarshalBuffer(&echoRequest{Message: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x01, 'a'}, b.B)
+	b.Free()
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	c := Codec{}
+	req := &echoRequest{Message: "hello world", Repeat: proto.Some[int32](3)}
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := c.Marshal(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("MarshalBuffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf, err := c.MarshalBuffer(req)
+			if err != nil {
+				b.Fatal(err)
+			}
+			buf.Free()
+		}
+	})
+}
+
+func TestMarshalBufferReuse(t *testing.T) {
+	c := Codec{}
+	long := &echoRequest{Message: "a much longer message", Repeat: proto.Some[int32](3)}
+	for i := 0; i < 3; i++ {
+		b, err := c.MarshalBuffer(long)
+		assert.NoError(t, err)
+		b.Free()
+		b, err = c.MarshalBuffer(&echoRequest{Message: "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x0a, 0x01, 'a'}, b.B)
+		b.Free()
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		b, err := c.MarshalBuffer(long)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Free()
+	})
+	// without the pool every call allocates the Buffer and its bytes, the
+	// race detector drops some of the buffers put back in the pool
+	assert.Less(t, allocs, 2.0, "pooled buffers are not reused")
+}
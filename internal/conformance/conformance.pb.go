@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: conformance.proto
+
+package conformance
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+type WireFormat = int32
+
+const (
+	WireFormat_UNSPECIFIED WireFormat = 0
+	WireFormat_PROTOBUF    WireFormat = 1
+	WireFormat_JSON        WireFormat = 2
+	WireFormat_JSPB        WireFormat = 3
+	WireFormat_TEXT_FORMAT WireFormat = 4
+)
+
+type TestCategory = int32
+
+const (
+	TestCategory_UNSPECIFIED_TEST                 TestCategory = 0
+	TestCategory_BINARY_TEST                      TestCategory = 1
+	TestCategory_JSON_TEST                        TestCategory = 2
+	TestCategory_JSON_IGNORE_UNKNOWN_PARSING_TEST TestCategory = 3
+	TestCategory_JSPB_TEST                        TestCategory = 4
+	TestCategory_TEXT_FORMAT_TEST                 TestCategory = 5
+)
+
+type FailureSet struct {
+	Failure []string `protobuf:"bytes,1,rep"`
+}
+
+func (x *FailureSet) String() string {
+	return proto.Format(x)
+}
+
+func (x *FailureSet) GetFailure() []string {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type ConformanceRequest struct {
+	// Types that are assignable to Payload:
+	//	*ConformanceRequest_ProtobufPayload
+	//	*ConformanceRequest_JsonPayload
+	//	*ConformanceRequest_JspbPayload
+	//	*ConformanceRequest_TextPayload
+	Payload               isConformanceRequest_Payload `protobuf_oneof:"payload"`
+	RequestedOutputFormat WireFormat                   `protobuf:"varint,3,opt"`
+	MessageType           string                       `protobuf:"bytes,4,opt"`
+	TestCategory          TestCategory                 `protobuf:"varint,5,opt"`
+	JspbEncodingOptions   *JspbEncodingConfig          `protobuf:"bytes,6,opt"`
+	PrintUnknownFields    bool                         `protobuf:"varint,9,opt"`
+	_                     [0]func()
+}
+
+func (x *ConformanceRequest) String() string {
+	return proto.Format(x)
+}
+
+func (m *ConformanceRequest) GetPayload() isConformanceRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *ConformanceRequest) GetProtobufPayload() []byte {
+	if x, ok := x.GetPayload().(*ConformanceRequest_ProtobufPayload); ok {
+		return x.ProtobufPayload
+	}
+	return nil
+}
+
+func (x *ConformanceRequest) GetJsonPayload() string {
+	if x, ok := x.GetPayload().(*ConformanceRequest_JsonPayload); ok {
+		return x.JsonPayload
+	}
+	return ""
+}
+
+func (x *ConformanceRequest) GetJspbPayload() string {
+	if x, ok := x.GetPayload().(*ConformanceRequest_JspbPayload); ok {
+		return x.JspbPayload
+	}
+	return ""
+}
+
+func (x *ConformanceRequest) GetTextPayload() string {
+	if x, ok := x.GetPayload().(*ConformanceRequest_TextPayload); ok {
+		return x.TextPayload
+	}
+	return ""
+}
+
+func (x *ConformanceRequest) GetRequestedOutputFormat() WireFormat {
+	if x != nil {
+		return x.RequestedOutputFormat
+	}
+	return WireFormat_UNSPECIFIED
+}
+
+func (x *ConformanceRequest) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *ConformanceRequest) GetTestCategory() TestCategory {
+	if x != nil {
+		return x.TestCategory
+	}
+	return TestCategory_UNSPECIFIED_TEST
+}
+
+func (x *ConformanceRequest) GetJspbEncodingOptions() *JspbEncodingConfig {
+	if x != nil {
+		return x.JspbEncodingOptions
+	}
+	return nil
+}
+
+func (x *ConformanceRequest) GetPrintUnknownFields() bool {
+	if x != nil {
+		return x.PrintUnknownFields
+	}
+	return false
+}
+
+type isConformanceRequest_Payload interface {
+	isConformanceRequest_Payload()
+}
+
+type ConformanceRequest_ProtobufPayload struct {
+	ProtobufPayload []byte `protobuf:"bytes,1,opt"`
+}
+
+type ConformanceRequest_JsonPayload struct {
+	JsonPayload string `protobuf:"bytes,2,opt"`
+}
+
+type ConformanceRequest_JspbPayload struct {
+	JspbPayload string `protobuf:"bytes,7,opt"`
+}
+
+type ConformanceRequest_TextPayload struct {
+	TextPayload string `protobuf:"bytes,8,opt"`
+}
+
+func (*ConformanceRequest_ProtobufPayload) isConformanceRequest_Payload() {}
+
+func (*ConformanceRequest_JsonPayload) isConformanceRequest_Payload() {}
+
+func (*ConformanceRequest_JspbPayload) isConformanceRequest_Payload() {}
+
+func (*ConformanceRequest_TextPayload) isConformanceRequest_Payload() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConformanceRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConformanceRequest_ProtobufPayload)(nil),
+		(*ConformanceRequest_JsonPayload)(nil),
+		(*ConformanceRequest_JspbPayload)(nil),
+		(*ConformanceRequest_TextPayload)(nil),
+	}
+}
+
+type ConformanceResponse struct {
+	// Types that are assignable to Result:
+	//	*ConformanceResponse_ParseError
+	//	*ConformanceResponse_SerializeError
+	//	*ConformanceResponse_RuntimeError
+	//	*ConformanceResponse_ProtobufPayload
+	//	*ConformanceResponse_JsonPayload
+	//	*ConformanceResponse_Skipped
+	//	*ConformanceResponse_JspbPayload
+	//	*ConformanceResponse_TextPayload
+	Result isConformanceResponse_Result `protobuf_oneof:"result"`
+	_      [0]func()
+}
+
+func (x *ConformanceResponse) String() string {
+	return proto.Format(x)
+}
+
+func (m *ConformanceResponse) GetResult() isConformanceResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *ConformanceResponse) GetParseError() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_ParseError); ok {
+		return x.ParseError
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetSerializeError() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_SerializeError); ok {
+		return x.SerializeError
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetRuntimeError() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_RuntimeError); ok {
+		return x.RuntimeError
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetProtobufPayload() []byte {
+	if x, ok := x.GetResult().(*ConformanceResponse_ProtobufPayload); ok {
+		return x.ProtobufPayload
+	}
+	return nil
+}
+
+func (x *ConformanceResponse) GetJsonPayload() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_JsonPayload); ok {
+		return x.JsonPayload
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetSkipped() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_Skipped); ok {
+		return x.Skipped
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetJspbPayload() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_JspbPayload); ok {
+		return x.JspbPayload
+	}
+	return ""
+}
+
+func (x *ConformanceResponse) GetTextPayload() string {
+	if x, ok := x.GetResult().(*ConformanceResponse_TextPayload); ok {
+		return x.TextPayload
+	}
+	return ""
+}
+
+type isConformanceResponse_Result interface {
+	isConformanceResponse_Result()
+}
+
+type ConformanceResponse_ParseError struct {
+	ParseError string `protobuf:"bytes,1,opt"`
+}
+
+type ConformanceResponse_SerializeError struct {
+	SerializeError string `protobuf:"bytes,6,opt"`
+}
+
+type ConformanceResponse_RuntimeError struct {
+	RuntimeError string `protobuf:"bytes,2,opt"`
+}
+
+type ConformanceResponse_ProtobufPayload struct {
+	ProtobufPayload []byte `protobuf:"bytes,3,opt"`
+}
+
+type ConformanceResponse_JsonPayload struct {
+	JsonPayload string `protobuf:"bytes,4,opt"`
+}
+
+type ConformanceResponse_Skipped struct {
+	Skipped string `protobuf:"bytes,5,opt"`
+}
+
+type ConformanceResponse_JspbPayload struct {
+	JspbPayload string `protobuf:"bytes,7,opt"`
+}
+
+type ConformanceResponse_TextPayload struct {
+	TextPayload string `protobuf:"bytes,8,opt"`
+}
+
+func (*ConformanceResponse_ParseError) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_SerializeError) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_RuntimeError) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_ProtobufPayload) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_JsonPayload) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_Skipped) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_JspbPayload) isConformanceResponse_Result() {}
+
+func (*ConformanceResponse_TextPayload) isConformanceResponse_Result() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ConformanceResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ConformanceResponse_ParseError)(nil),
+		(*ConformanceResponse_SerializeError)(nil),
+		(*ConformanceResponse_RuntimeError)(nil),
+		(*ConformanceResponse_ProtobufPayload)(nil),
+		(*ConformanceResponse_JsonPayload)(nil),
+		(*ConformanceResponse_Skipped)(nil),
+		(*ConformanceResponse_JspbPayload)(nil),
+		(*ConformanceResponse_TextPayload)(nil),
+	}
+}
+
+type JspbEncodingConfig struct {
+	UseJspbArrayAnyFormat bool `protobuf:"varint,1,opt"`
+	_                     [0]func()
+}
+
+func (x *JspbEncodingConfig) String() string {
+	return proto.Format(x)
+}
+
+func (x *JspbEncodingConfig) GetUseJspbArrayAnyFormat() bool {
+	if x != nil {
+		return x.UseJspbArrayAnyFormat
+	}
+	return false
+}
@@ -0,0 +1,1249 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: test_messages_proto2.proto
+
+package conformance
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+type ForeignEnumProto2 = int32
+
+const (
+	ForeignEnumProto2_FOREIGN_FOO ForeignEnumProto2 = 0
+	ForeignEnumProto2_FOREIGN_BAR ForeignEnumProto2 = 1
+	ForeignEnumProto2_FOREIGN_BAZ ForeignEnumProto2 = 2
+)
+
+type TestAllTypesProto2_NestedEnum = int32
+
+const (
+	TestAllTypesProto2_FOO TestAllTypesProto2_NestedEnum = 0
+	TestAllTypesProto2_BAR TestAllTypesProto2_NestedEnum = 1
+	TestAllTypesProto2_BAZ TestAllTypesProto2_NestedEnum = 2
+	TestAllTypesProto2_NEG TestAllTypesProto2_NestedEnum = -1
+)
+
+type TestAllTypesProto2 struct {
+	OptionalInt32           proto.Option[int32]                          `protobuf:"varint,1,opt"`
+	OptionalInt64           proto.Option[int64]                          `protobuf:"varint,2,opt"`
+	OptionalUint32          proto.Option[uint32]                         `protobuf:"varint,3,opt"`
+	OptionalUint64          proto.Option[uint64]                         `protobuf:"varint,4,opt"`
+	OptionalSint32          proto.Option[int32]                          `protobuf:"zigzag32,5,opt"`
+	OptionalSint64          proto.Option[int64]                          `protobuf:"zigzag64,6,opt"`
+	OptionalFixed32         proto.Option[uint32]                         `protobuf:"fixed32,7,opt"`
+	OptionalFixed64         proto.Option[uint64]                         `protobuf:"fixed64,8,opt"`
+	OptionalSfixed32        proto.Option[int32]                          `protobuf:"fixed32,9,opt"`
+	OptionalSfixed64        proto.Option[int64]                          `protobuf:"fixed64,10,opt"`
+	OptionalFloat           proto.Option[float32]                        `protobuf:"fixed32,11,opt"`
+	OptionalDouble          proto.Option[float64]                        `protobuf:"fixed64,12,opt"`
+	OptionalBool            proto.Option[bool]                           `protobuf:"varint,13,opt"`
+	OptionalString          proto.Option[string]                         `protobuf:"bytes,14,opt"`
+	OptionalBytes           []byte                                       `protobuf:"bytes,15,opt"`
+	OptionalNestedMessage   *TestAllTypesProto2_NestedMessage            `protobuf:"bytes,18,opt"`
+	OptionalForeignMessage  *ForeignMessageProto2                        `protobuf:"bytes,19,opt"`
+	OptionalNestedEnum      proto.Option[TestAllTypesProto2_NestedEnum]  `protobuf:"varint,21,opt"`
+	OptionalForeignEnum     proto.Option[ForeignEnumProto2]              `protobuf:"varint,22,opt"`
+	OptionalStringPiece     proto.Option[string]                         `protobuf:"bytes,24,opt"`
+	OptionalCord            proto.Option[string]                         `protobuf:"bytes,25,opt"`
+	RecursiveMessage        *TestAllTypesProto2                          `protobuf:"bytes,27,opt"`
+	RepeatedInt32           []int32                                      `protobuf:"varint,31,rep"`
+	RepeatedInt64           []int64                                      `protobuf:"varint,32,rep"`
+	RepeatedUint32          []uint32                                     `protobuf:"varint,33,rep"`
+	RepeatedUint64          []uint64                                     `protobuf:"varint,34,rep"`
+	RepeatedSint32          []int32                                      `protobuf:"zigzag32,35,rep"`
+	RepeatedSint64          []int64                                      `protobuf:"zigzag64,36,rep"`
+	RepeatedFixed32         []uint32                                     `protobuf:"fixed32,37,rep"`
+	RepeatedFixed64         []uint64                                     `protobuf:"fixed64,38,rep"`
+	RepeatedSfixed32        []int32                                      `protobuf:"fixed32,39,rep"`
+	RepeatedSfixed64        []int64                                      `protobuf:"fixed64,40,rep"`
+	RepeatedFloat           []float32                                    `protobuf:"fixed32,41,rep"`
+	RepeatedDouble          []float64                                    `protobuf:"fixed64,42,rep"`
+	RepeatedBool            []bool                                       `protobuf:"varint,43,rep"`
+	RepeatedString          []string                                     `protobuf:"bytes,44,rep"`
+	RepeatedBytes           [][]byte                                     `protobuf:"bytes,45,rep"`
+	RepeatedNestedMessage   []*TestAllTypesProto2_NestedMessage          `protobuf:"bytes,48,rep"`
+	RepeatedForeignMessage  []*ForeignMessageProto2                      `protobuf:"bytes,49,rep"`
+	RepeatedNestedEnum      []TestAllTypesProto2_NestedEnum              `protobuf:"varint,51,rep"`
+	RepeatedForeignEnum     []ForeignEnumProto2                          `protobuf:"varint,52,rep"`
+	RepeatedStringPiece     []string                                     `protobuf:"bytes,54,rep"`
+	RepeatedCord            []string                                     `protobuf:"bytes,55,rep"`
+	PackedInt32             []int32                                      `protobuf:"varint,75,rep"`
+	PackedInt64             []int64                                      `protobuf:"varint,76,rep"`
+	PackedUint32            []uint32                                     `protobuf:"varint,77,rep"`
+	PackedUint64            []uint64                                     `protobuf:"varint,78,rep"`
+	PackedSint32            []int32                                      `protobuf:"zigzag32,79,rep"`
+	PackedSint64            []int64                                      `protobuf:"zigzag64,80,rep"`
+	PackedFixed32           []uint32                                     `protobuf:"fixed32,81,rep"`
+	PackedFixed64           []uint64                                     `protobuf:"fixed64,82,rep"`
+	PackedSfixed32          []int32                                      `protobuf:"fixed32,83,rep"`
+	PackedSfixed64          []int64                                      `protobuf:"fixed64,84,rep"`
+	PackedFloat             []float32                                    `protobuf:"fixed32,85,rep"`
+	PackedDouble            []float64                                    `protobuf:"fixed64,86,rep"`
+	PackedBool              []bool                                       `protobuf:"varint,87,rep"`
+	PackedNestedEnum        []TestAllTypesProto2_NestedEnum              `protobuf:"varint,88,rep"`
+	UnpackedInt32           []int32                                      `protobuf:"varint,89,rep"`
+	UnpackedInt64           []int64                                      `protobuf:"varint,90,rep"`
+	UnpackedUint32          []uint32                                     `protobuf:"varint,91,rep"`
+	UnpackedUint64          []uint64                                     `protobuf:"varint,92,rep"`
+	UnpackedSint32          []int32                                      `protobuf:"zigzag32,93,rep"`
+	UnpackedSint64          []int64                                      `protobuf:"zigzag64,94,rep"`
+	UnpackedFixed32         []uint32                                     `protobuf:"fixed32,95,rep"`
+	UnpackedFixed64         []uint64                                     `protobuf:"fixed64,96,rep"`
+	UnpackedSfixed32        []int32                                      `protobuf:"fixed32,97,rep"`
+	UnpackedSfixed64        []int64                                      `protobuf:"fixed64,98,rep"`
+	UnpackedFloat           []float32                                    `protobuf:"fixed32,99,rep"`
+	UnpackedDouble          []float64                                    `protobuf:"fixed64,100,rep"`
+	UnpackedBool            []bool                                       `protobuf:"varint,101,rep"`
+	UnpackedNestedEnum      []TestAllTypesProto2_NestedEnum              `protobuf:"varint,102,rep"`
+	MapInt32Int32           map[int32]int32                              `protobuf:"bytes,56,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapInt64Int64           map[int64]int64                              `protobuf:"bytes,57,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapUint32Uint32         map[uint32]uint32                            `protobuf:"bytes,58,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapUint64Uint64         map[uint64]uint64                            `protobuf:"bytes,59,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapSint32Sint32         map[int32]int32                              `protobuf:"bytes,60,rep" protobuf_key:"zigzag32,1,opt" protobuf_val:"zigzag32,2,opt"`
+	MapSint64Sint64         map[int64]int64                              `protobuf:"bytes,61,rep" protobuf_key:"zigzag64,1,opt" protobuf_val:"zigzag64,2,opt"`
+	MapFixed32Fixed32       map[uint32]uint32                            `protobuf:"bytes,62,rep" protobuf_key:"fixed32,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapFixed64Fixed64       map[uint64]uint64                            `protobuf:"bytes,63,rep" protobuf_key:"fixed64,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapSfixed32Sfixed32     map[int32]int32                              `protobuf:"bytes,64,rep" protobuf_key:"fixed32,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapSfixed64Sfixed64     map[int64]int64                              `protobuf:"bytes,65,rep" protobuf_key:"fixed64,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapInt32Float           map[int32]float32                            `protobuf:"bytes,66,rep" protobuf_key:"varint,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapInt32Double          map[int32]float64                            `protobuf:"bytes,67,rep" protobuf_key:"varint,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapBoolBool             map[bool]bool                                `protobuf:"bytes,68,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapStringString         map[string]string                            `protobuf:"bytes,69,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringBytes          map[string][]byte                            `protobuf:"bytes,70,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringNestedMessage  map[string]*TestAllTypesProto2_NestedMessage `protobuf:"bytes,71,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringForeignMessage map[string]*ForeignMessageProto2             `protobuf:"bytes,72,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringNestedEnum     map[string]TestAllTypesProto2_NestedEnum     `protobuf:"bytes,73,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+	MapStringForeignEnum    map[string]ForeignEnumProto2                 `protobuf:"bytes,74,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+	// Types that are assignable to OneofField:
+	//	*TestAllTypesProto2_OneofUint32
+	//	*TestAllTypesProto2_OneofNestedMessage
+	//	*TestAllTypesProto2_OneofString
+	//	*TestAllTypesProto2_OneofBytes
+	//	*TestAllTypesProto2_OneofBool
+	//	*TestAllTypesProto2_OneofUint64
+	//	*TestAllTypesProto2_OneofFloat
+	//	*TestAllTypesProto2_OneofDouble
+	//	*TestAllTypesProto2_OneofEnum
+	OneofField             isTestAllTypesProto2_OneofField `protobuf_oneof:"oneof_field"`
+	Data                   *TestAllTypesProto2_Data        `protobuf:"group,201,opt"`
+	Fieldname1             proto.Option[int32]             `protobuf:"varint,401,opt"`
+	FieldName2             proto.Option[int32]             `protobuf:"varint,402,opt"`
+	XFieldName3            proto.Option[int32]             `protobuf:"varint,403,opt"`
+	Field_Name4_           proto.Option[int32]             `protobuf:"varint,404,opt"`
+	Field0Name5            proto.Option[int32]             `protobuf:"varint,405,opt"`
+	Field_0Name6           proto.Option[int32]             `protobuf:"varint,406,opt"`
+	FieldName7             proto.Option[int32]             `protobuf:"varint,407,opt"`
+	FieldName8             proto.Option[int32]             `protobuf:"varint,408,opt"`
+	Field_Name9            proto.Option[int32]             `protobuf:"varint,409,opt"`
+	Field_Name10           proto.Option[int32]             `protobuf:"varint,410,opt"`
+	FIELD_NAME11           proto.Option[int32]             `protobuf:"varint,411,opt"`
+	FIELDName12            proto.Option[int32]             `protobuf:"varint,412,opt"`
+	XFieldName13           proto.Option[int32]             `protobuf:"varint,413,opt"`
+	X_FieldName14          proto.Option[int32]             `protobuf:"varint,414,opt"`
+	Field_Name15           proto.Option[int32]             `protobuf:"varint,415,opt"`
+	Field__Name16          proto.Option[int32]             `protobuf:"varint,416,opt"`
+	FieldName17__          proto.Option[int32]             `protobuf:"varint,417,opt"`
+	FieldName18__          proto.Option[int32]             `protobuf:"varint,418,opt"`
+	XXX_InternalExtensions proto.Extensions                `protobuf_extensions:"120-200"`
+}
+
+func (x *TestAllTypesProto2) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto2) GetOptionalInt32() int32 {
+	if x != nil && x.OptionalInt32.IsSome() {
+		return x.OptionalInt32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalInt64() int64 {
+	if x != nil && x.OptionalInt64.IsSome() {
+		return x.OptionalInt64.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalUint32() uint32 {
+	if x != nil && x.OptionalUint32.IsSome() {
+		return x.OptionalUint32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalUint64() uint64 {
+	if x != nil && x.OptionalUint64.IsSome() {
+		return x.OptionalUint64.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalSint32() int32 {
+	if x != nil && x.OptionalSint32.IsSome() {
+		return x.OptionalSint32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalSint64() int64 {
+	if x != nil && x.OptionalSint64.IsSome() {
+		return x.OptionalSint64.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalFixed32() uint32 {
+	if x != nil && x.OptionalFixed32.IsSome() {
+		return x.OptionalFixed32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalFixed64() uint64 {
+	if x != nil && x.OptionalFixed64.IsSome() {
+		return x.OptionalFixed64.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalSfixed32() int32 {
+	if x != nil && x.OptionalSfixed32.IsSome() {
+		return x.OptionalSfixed32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalSfixed64() int64 {
+	if x != nil && x.OptionalSfixed64.IsSome() {
+		return x.OptionalSfixed64.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalFloat() float32 {
+	if x != nil && x.OptionalFloat.IsSome() {
+		return x.OptionalFloat.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalDouble() float64 {
+	if x != nil && x.OptionalDouble.IsSome() {
+		return x.OptionalDouble.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOptionalBool() bool {
+	if x != nil && x.OptionalBool.IsSome() {
+		return x.OptionalBool.Unwrap()
+	}
+	return false
+}
+
+func (x *TestAllTypesProto2) GetOptionalString() string {
+	if x != nil && x.OptionalString.IsSome() {
+		return x.OptionalString.Unwrap()
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto2) GetOptionalBytes() []byte {
+	if x != nil {
+		return x.OptionalBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOptionalNestedMessage() *TestAllTypesProto2_NestedMessage {
+	if x != nil {
+		return x.OptionalNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOptionalForeignMessage() *ForeignMessageProto2 {
+	if x != nil {
+		return x.OptionalForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOptionalNestedEnum() TestAllTypesProto2_NestedEnum {
+	if x != nil && x.OptionalNestedEnum.IsSome() {
+		return x.OptionalNestedEnum.Unwrap()
+	}
+	return TestAllTypesProto2_FOO
+}
+
+func (x *TestAllTypesProto2) GetOptionalForeignEnum() ForeignEnumProto2 {
+	if x != nil && x.OptionalForeignEnum.IsSome() {
+		return x.OptionalForeignEnum.Unwrap()
+	}
+	return ForeignEnumProto2_FOREIGN_FOO
+}
+
+func (x *TestAllTypesProto2) GetOptionalStringPiece() string {
+	if x != nil && x.OptionalStringPiece.IsSome() {
+		return x.OptionalStringPiece.Unwrap()
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto2) GetOptionalCord() string {
+	if x != nil && x.OptionalCord.IsSome() {
+		return x.OptionalCord.Unwrap()
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto2) GetRecursiveMessage() *TestAllTypesProto2 {
+	if x != nil {
+		return x.RecursiveMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedInt32() []int32 {
+	if x != nil {
+		return x.RepeatedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedInt64() []int64 {
+	if x != nil {
+		return x.RepeatedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedUint32() []uint32 {
+	if x != nil {
+		return x.RepeatedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedUint64() []uint64 {
+	if x != nil {
+		return x.RepeatedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedSint32() []int32 {
+	if x != nil {
+		return x.RepeatedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedSint64() []int64 {
+	if x != nil {
+		return x.RepeatedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedFixed32() []uint32 {
+	if x != nil {
+		return x.RepeatedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedFixed64() []uint64 {
+	if x != nil {
+		return x.RepeatedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedSfixed32() []int32 {
+	if x != nil {
+		return x.RepeatedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedSfixed64() []int64 {
+	if x != nil {
+		return x.RepeatedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedFloat() []float32 {
+	if x != nil {
+		return x.RepeatedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedDouble() []float64 {
+	if x != nil {
+		return x.RepeatedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedBool() []bool {
+	if x != nil {
+		return x.RepeatedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedString() []string {
+	if x != nil {
+		return x.RepeatedString
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedBytes() [][]byte {
+	if x != nil {
+		return x.RepeatedBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedNestedMessage() []*TestAllTypesProto2_NestedMessage {
+	if x != nil {
+		return x.RepeatedNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedForeignMessage() []*ForeignMessageProto2 {
+	if x != nil {
+		return x.RepeatedForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedNestedEnum() []TestAllTypesProto2_NestedEnum {
+	if x != nil {
+		return x.RepeatedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedForeignEnum() []ForeignEnumProto2 {
+	if x != nil {
+		return x.RepeatedForeignEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedStringPiece() []string {
+	if x != nil {
+		return x.RepeatedStringPiece
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetRepeatedCord() []string {
+	if x != nil {
+		return x.RepeatedCord
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedInt32() []int32 {
+	if x != nil {
+		return x.PackedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedInt64() []int64 {
+	if x != nil {
+		return x.PackedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedUint32() []uint32 {
+	if x != nil {
+		return x.PackedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedUint64() []uint64 {
+	if x != nil {
+		return x.PackedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedSint32() []int32 {
+	if x != nil {
+		return x.PackedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedSint64() []int64 {
+	if x != nil {
+		return x.PackedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedFixed32() []uint32 {
+	if x != nil {
+		return x.PackedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedFixed64() []uint64 {
+	if x != nil {
+		return x.PackedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedSfixed32() []int32 {
+	if x != nil {
+		return x.PackedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedSfixed64() []int64 {
+	if x != nil {
+		return x.PackedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedFloat() []float32 {
+	if x != nil {
+		return x.PackedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedDouble() []float64 {
+	if x != nil {
+		return x.PackedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedBool() []bool {
+	if x != nil {
+		return x.PackedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetPackedNestedEnum() []TestAllTypesProto2_NestedEnum {
+	if x != nil {
+		return x.PackedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedInt32() []int32 {
+	if x != nil {
+		return x.UnpackedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedInt64() []int64 {
+	if x != nil {
+		return x.UnpackedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedUint32() []uint32 {
+	if x != nil {
+		return x.UnpackedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedUint64() []uint64 {
+	if x != nil {
+		return x.UnpackedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedSint32() []int32 {
+	if x != nil {
+		return x.UnpackedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedSint64() []int64 {
+	if x != nil {
+		return x.UnpackedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedFixed32() []uint32 {
+	if x != nil {
+		return x.UnpackedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedFixed64() []uint64 {
+	if x != nil {
+		return x.UnpackedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedSfixed32() []int32 {
+	if x != nil {
+		return x.UnpackedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedSfixed64() []int64 {
+	if x != nil {
+		return x.UnpackedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedFloat() []float32 {
+	if x != nil {
+		return x.UnpackedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedDouble() []float64 {
+	if x != nil {
+		return x.UnpackedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedBool() []bool {
+	if x != nil {
+		return x.UnpackedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetUnpackedNestedEnum() []TestAllTypesProto2_NestedEnum {
+	if x != nil {
+		return x.UnpackedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapInt32Int32() map[int32]int32 {
+	if x != nil {
+		return x.MapInt32Int32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapInt64Int64() map[int64]int64 {
+	if x != nil {
+		return x.MapInt64Int64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapUint32Uint32() map[uint32]uint32 {
+	if x != nil {
+		return x.MapUint32Uint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapUint64Uint64() map[uint64]uint64 {
+	if x != nil {
+		return x.MapUint64Uint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapSint32Sint32() map[int32]int32 {
+	if x != nil {
+		return x.MapSint32Sint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapSint64Sint64() map[int64]int64 {
+	if x != nil {
+		return x.MapSint64Sint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapFixed32Fixed32() map[uint32]uint32 {
+	if x != nil {
+		return x.MapFixed32Fixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapFixed64Fixed64() map[uint64]uint64 {
+	if x != nil {
+		return x.MapFixed64Fixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapSfixed32Sfixed32() map[int32]int32 {
+	if x != nil {
+		return x.MapSfixed32Sfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapSfixed64Sfixed64() map[int64]int64 {
+	if x != nil {
+		return x.MapSfixed64Sfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapInt32Float() map[int32]float32 {
+	if x != nil {
+		return x.MapInt32Float
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapInt32Double() map[int32]float64 {
+	if x != nil {
+		return x.MapInt32Double
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapBoolBool() map[bool]bool {
+	if x != nil {
+		return x.MapBoolBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringString() map[string]string {
+	if x != nil {
+		return x.MapStringString
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringBytes() map[string][]byte {
+	if x != nil {
+		return x.MapStringBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringNestedMessage() map[string]*TestAllTypesProto2_NestedMessage {
+	if x != nil {
+		return x.MapStringNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringForeignMessage() map[string]*ForeignMessageProto2 {
+	if x != nil {
+		return x.MapStringForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringNestedEnum() map[string]TestAllTypesProto2_NestedEnum {
+	if x != nil {
+		return x.MapStringNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetMapStringForeignEnum() map[string]ForeignEnumProto2 {
+	if x != nil {
+		return x.MapStringForeignEnum
+	}
+	return nil
+}
+
+func (m *TestAllTypesProto2) GetOneofField() isTestAllTypesProto2_OneofField {
+	if m != nil {
+		return m.OneofField
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOneofUint32() uint32 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofUint32); ok {
+		return x.OneofUint32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOneofNestedMessage() *TestAllTypesProto2_NestedMessage {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofNestedMessage); ok {
+		return x.OneofNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOneofString() string {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofString); ok {
+		return x.OneofString
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto2) GetOneofBytes() []byte {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofBytes); ok {
+		return x.OneofBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetOneofBool() bool {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofBool); ok {
+		return x.OneofBool
+	}
+	return false
+}
+
+func (x *TestAllTypesProto2) GetOneofUint64() uint64 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofUint64); ok {
+		return x.OneofUint64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOneofFloat() float32 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofFloat); ok {
+		return x.OneofFloat
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOneofDouble() float64 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofDouble); ok {
+		return x.OneofDouble
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetOneofEnum() TestAllTypesProto2_NestedEnum {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto2_OneofEnum); ok {
+		return x.OneofEnum
+	}
+	return TestAllTypesProto2_FOO
+}
+
+func (x *TestAllTypesProto2) GetData() *TestAllTypesProto2_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto2) GetFieldname1() int32 {
+	if x != nil && x.Fieldname1.IsSome() {
+		return x.Fieldname1.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFieldName2() int32 {
+	if x != nil && x.FieldName2.IsSome() {
+		return x.FieldName2.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetXFieldName3() int32 {
+	if x != nil && x.XFieldName3.IsSome() {
+		return x.XFieldName3.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField_Name4_() int32 {
+	if x != nil && x.Field_Name4_.IsSome() {
+		return x.Field_Name4_.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField0Name5() int32 {
+	if x != nil && x.Field0Name5.IsSome() {
+		return x.Field0Name5.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField_0Name6() int32 {
+	if x != nil && x.Field_0Name6.IsSome() {
+		return x.Field_0Name6.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFieldName7() int32 {
+	if x != nil && x.FieldName7.IsSome() {
+		return x.FieldName7.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFieldName8() int32 {
+	if x != nil && x.FieldName8.IsSome() {
+		return x.FieldName8.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField_Name9() int32 {
+	if x != nil && x.Field_Name9.IsSome() {
+		return x.Field_Name9.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField_Name10() int32 {
+	if x != nil && x.Field_Name10.IsSome() {
+		return x.Field_Name10.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFIELD_NAME11() int32 {
+	if x != nil && x.FIELD_NAME11.IsSome() {
+		return x.FIELD_NAME11.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFIELDName12() int32 {
+	if x != nil && x.FIELDName12.IsSome() {
+		return x.FIELDName12.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetXFieldName13() int32 {
+	if x != nil && x.XFieldName13.IsSome() {
+		return x.XFieldName13.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetX_FieldName14() int32 {
+	if x != nil && x.X_FieldName14.IsSome() {
+		return x.X_FieldName14.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField_Name15() int32 {
+	if x != nil && x.Field_Name15.IsSome() {
+		return x.Field_Name15.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetField__Name16() int32 {
+	if x != nil && x.Field__Name16.IsSome() {
+		return x.Field__Name16.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFieldName17__() int32 {
+	if x != nil && x.FieldName17__.IsSome() {
+		return x.FieldName17__.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2) GetFieldName18__() int32 {
+	if x != nil && x.FieldName18__.IsSome() {
+		return x.FieldName18__.Unwrap()
+	}
+	return 0
+}
+
+type isTestAllTypesProto2_OneofField interface {
+	isTestAllTypesProto2_OneofField()
+}
+
+type TestAllTypesProto2_OneofUint32 struct {
+	OneofUint32 uint32 `protobuf:"varint,111,opt"`
+}
+
+type TestAllTypesProto2_OneofNestedMessage struct {
+	OneofNestedMessage *TestAllTypesProto2_NestedMessage `protobuf:"bytes,112,opt"`
+}
+
+type TestAllTypesProto2_OneofString struct {
+	OneofString string `protobuf:"bytes,113,opt"`
+}
+
+type TestAllTypesProto2_OneofBytes struct {
+	OneofBytes []byte `protobuf:"bytes,114,opt"`
+}
+
+type TestAllTypesProto2_OneofBool struct {
+	OneofBool bool `protobuf:"varint,115,opt"`
+}
+
+type TestAllTypesProto2_OneofUint64 struct {
+	OneofUint64 uint64 `protobuf:"varint,116,opt"`
+}
+
+type TestAllTypesProto2_OneofFloat struct {
+	OneofFloat float32 `protobuf:"fixed32,117,opt"`
+}
+
+type TestAllTypesProto2_OneofDouble struct {
+	OneofDouble float64 `protobuf:"fixed64,118,opt"`
+}
+
+type TestAllTypesProto2_OneofEnum struct {
+	OneofEnum TestAllTypesProto2_NestedEnum `protobuf:"varint,119,opt"`
+}
+
+func (*TestAllTypesProto2_OneofUint32) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofNestedMessage) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofString) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofBytes) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofBool) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofUint64) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofFloat) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofDouble) isTestAllTypesProto2_OneofField() {}
+
+func (*TestAllTypesProto2_OneofEnum) isTestAllTypesProto2_OneofField() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestAllTypesProto2) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestAllTypesProto2_OneofUint32)(nil),
+		(*TestAllTypesProto2_OneofNestedMessage)(nil),
+		(*TestAllTypesProto2_OneofString)(nil),
+		(*TestAllTypesProto2_OneofBytes)(nil),
+		(*TestAllTypesProto2_OneofBool)(nil),
+		(*TestAllTypesProto2_OneofUint64)(nil),
+		(*TestAllTypesProto2_OneofFloat)(nil),
+		(*TestAllTypesProto2_OneofDouble)(nil),
+		(*TestAllTypesProto2_OneofEnum)(nil),
+	}
+}
+
+type ForeignMessageProto2 struct {
+	C proto.Option[int32] `protobuf:"varint,1,opt"`
+	_ [0]func()
+}
+
+func (x *ForeignMessageProto2) String() string {
+	return proto.Format(x)
+}
+
+func (x *ForeignMessageProto2) GetC() int32 {
+	if x != nil && x.C.IsSome() {
+		return x.C.Unwrap()
+	}
+	return 0
+}
+
+type UnknownToTestAllTypes struct {
+	OptionalInt32  proto.Option[int32]                  `protobuf:"varint,1001,opt"`
+	OptionalString proto.Option[string]                 `protobuf:"bytes,1002,opt"`
+	NestedMessage  *ForeignMessageProto2                `protobuf:"bytes,1003,opt"`
+	Optionalgroup  *UnknownToTestAllTypes_OptionalGroup `protobuf:"group,1004,opt"`
+	OptionalBool   proto.Option[bool]                   `protobuf:"varint,1006,opt"`
+	RepeatedInt32  []int32                              `protobuf:"varint,1011,rep"`
+}
+
+func (x *UnknownToTestAllTypes) String() string {
+	return proto.Format(x)
+}
+
+func (x *UnknownToTestAllTypes) GetOptionalInt32() int32 {
+	if x != nil && x.OptionalInt32.IsSome() {
+		return x.OptionalInt32.Unwrap()
+	}
+	return 0
+}
+
+func (x *UnknownToTestAllTypes) GetOptionalString() string {
+	if x != nil && x.OptionalString.IsSome() {
+		return x.OptionalString.Unwrap()
+	}
+	return ""
+}
+
+func (x *UnknownToTestAllTypes) GetNestedMessage() *ForeignMessageProto2 {
+	if x != nil {
+		return x.NestedMessage
+	}
+	return nil
+}
+
+func (x *UnknownToTestAllTypes) GetOptionalgroup() *UnknownToTestAllTypes_OptionalGroup {
+	if x != nil {
+		return x.Optionalgroup
+	}
+	return nil
+}
+
+func (x *UnknownToTestAllTypes) GetOptionalBool() bool {
+	if x != nil && x.OptionalBool.IsSome() {
+		return x.OptionalBool.Unwrap()
+	}
+	return false
+}
+
+func (x *UnknownToTestAllTypes) GetRepeatedInt32() []int32 {
+	if x != nil {
+		return x.RepeatedInt32
+	}
+	return nil
+}
+
+type TestAllTypesProto2_Data struct {
+	GroupInt32  proto.Option[int32]  `protobuf:"varint,202,opt"`
+	GroupUint32 proto.Option[uint32] `protobuf:"varint,203,opt"`
+	_           [0]func()
+}
+
+func (x *TestAllTypesProto2_Data) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto2_Data) GetGroupInt32() int32 {
+	if x != nil && x.GroupInt32.IsSome() {
+		return x.GroupInt32.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2_Data) GetGroupUint32() uint32 {
+	if x != nil && x.GroupUint32.IsSome() {
+		return x.GroupUint32.Unwrap()
+	}
+	return 0
+}
+
+type TestAllTypesProto2_NestedMessage struct {
+	A           proto.Option[int32] `protobuf:"varint,1,opt"`
+	Corecursive *TestAllTypesProto2 `protobuf:"bytes,2,opt"`
+	_           [0]func()
+}
+
+func (x *TestAllTypesProto2_NestedMessage) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto2_NestedMessage) GetA() int32 {
+	if x != nil && x.A.IsSome() {
+		return x.A.Unwrap()
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto2_NestedMessage) GetCorecursive() *TestAllTypesProto2 {
+	if x != nil {
+		return x.Corecursive
+	}
+	return nil
+}
+
+type TestAllTypesProto2_MessageSetCorrect struct {
+	XXX_InternalExtensions proto.Extensions `protobuf_extensions:"4-2147483646"`
+}
+
+func (x *TestAllTypesProto2_MessageSetCorrect) String() string {
+	return proto.Format(x)
+}
+
+type TestAllTypesProto2_MessageSetCorrectExtension1 struct {
+	Str proto.Option[string] `protobuf:"bytes,25,opt"`
+	_   [0]func()
+}
+
+func (x *TestAllTypesProto2_MessageSetCorrectExtension1) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto2_MessageSetCorrectExtension1) GetStr() string {
+	if x != nil && x.Str.IsSome() {
+		return x.Str.Unwrap()
+	}
+	return ""
+}
+
+type TestAllTypesProto2_MessageSetCorrectExtension2 struct {
+	I proto.Option[int32] `protobuf:"varint,9,opt"`
+	_ [0]func()
+}
+
+func (x *TestAllTypesProto2_MessageSetCorrectExtension2) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto2_MessageSetCorrectExtension2) GetI() int32 {
+	if x != nil && x.I.IsSome() {
+		return x.I.Unwrap()
+	}
+	return 0
+}
+
+type UnknownToTestAllTypes_OptionalGroup struct {
+	A proto.Option[int32] `protobuf:"varint,1,opt"`
+	_ [0]func()
+}
+
+func (x *UnknownToTestAllTypes_OptionalGroup) String() string {
+	return proto.Format(x)
+}
+
+func (x *UnknownToTestAllTypes_OptionalGroup) GetA() int32 {
+	if x != nil && x.A.IsSome() {
+		return x.A.Unwrap()
+	}
+	return 0
+}
+
+var (
+	E_ExtensionInt32 = &proto.ExtensionDesc[TestAllTypesProto2, int32]{
+		Name: "protobuf_test_messages.proto2.extension_int32",
+		Tag:  "varint,120,opt",
+	}
+	E_TestAllTypesProto2_MessageSetCorrectExtension1_MessageSetExtension = &proto.ExtensionDesc[TestAllTypesProto2_MessageSetCorrect, *TestAllTypesProto2_MessageSetCorrectExtension1]{
+		Name: "protobuf_test_messages.proto2.TestAllTypesProto2.MessageSetCorrectExtension1.message_set_extension",
+		Tag:  "bytes,1547769,opt",
+	}
+	E_TestAllTypesProto2_MessageSetCorrectExtension2_MessageSetExtension = &proto.ExtensionDesc[TestAllTypesProto2_MessageSetCorrect, *TestAllTypesProto2_MessageSetCorrectExtension2]{
+		Name: "protobuf_test_messages.proto2.TestAllTypesProto2.MessageSetCorrectExtension2.message_set_extension",
+		Tag:  "bytes,4135312,opt",
+	}
+)
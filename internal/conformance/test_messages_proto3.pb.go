@@ -0,0 +1,1386 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: test_messages_proto3.proto
+
+package conformance
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+	anypb "github.com/RomiChan/protobuf/proto/types/known/anypb"
+	durationpb "github.com/RomiChan/protobuf/proto/types/known/durationpb"
+	fieldmaskpb "github.com/RomiChan/protobuf/proto/types/known/fieldmaskpb"
+	structpb "github.com/RomiChan/protobuf/proto/types/known/structpb"
+	timestamppb "github.com/RomiChan/protobuf/proto/types/known/timestamppb"
+	wrapperspb "github.com/RomiChan/protobuf/proto/types/known/wrapperspb"
+)
+
+type ForeignEnum = int32
+
+const (
+	ForeignEnum_FOREIGN_FOO ForeignEnum = 0
+	ForeignEnum_FOREIGN_BAR ForeignEnum = 1
+	ForeignEnum_FOREIGN_BAZ ForeignEnum = 2
+)
+
+type TestAllTypesProto3_NestedEnum = int32
+
+const (
+	TestAllTypesProto3_FOO TestAllTypesProto3_NestedEnum = 0
+	TestAllTypesProto3_BAR TestAllTypesProto3_NestedEnum = 1
+	TestAllTypesProto3_BAZ TestAllTypesProto3_NestedEnum = 2
+	TestAllTypesProto3_NEG TestAllTypesProto3_NestedEnum = -1
+)
+
+type TestAllTypesProto3_AliasedEnum = int32
+
+const (
+	TestAllTypesProto3_ALIAS_FOO TestAllTypesProto3_AliasedEnum = 0
+	TestAllTypesProto3_ALIAS_BAR TestAllTypesProto3_AliasedEnum = 1
+	TestAllTypesProto3_ALIAS_BAZ TestAllTypesProto3_AliasedEnum = 2
+	TestAllTypesProto3_QUX       TestAllTypesProto3_AliasedEnum = 2
+	TestAllTypesProto3_qux       TestAllTypesProto3_AliasedEnum = 2
+	TestAllTypesProto3_bAz       TestAllTypesProto3_AliasedEnum = 2
+)
+
+type TestAllTypesProto3 struct {
+	OptionalInt32           int32                                        `protobuf:"varint,1,opt"`
+	OptionalInt64           int64                                        `protobuf:"varint,2,opt"`
+	OptionalUint32          uint32                                       `protobuf:"varint,3,opt"`
+	OptionalUint64          uint64                                       `protobuf:"varint,4,opt"`
+	OptionalSint32          int32                                        `protobuf:"zigzag32,5,opt"`
+	OptionalSint64          int64                                        `protobuf:"zigzag64,6,opt"`
+	OptionalFixed32         uint32                                       `protobuf:"fixed32,7,opt"`
+	OptionalFixed64         uint64                                       `protobuf:"fixed64,8,opt"`
+	OptionalSfixed32        int32                                        `protobuf:"fixed32,9,opt"`
+	OptionalSfixed64        int64                                        `protobuf:"fixed64,10,opt"`
+	OptionalFloat           float32                                      `protobuf:"fixed32,11,opt"`
+	OptionalDouble          float64                                      `protobuf:"fixed64,12,opt"`
+	OptionalBool            bool                                         `protobuf:"varint,13,opt"`
+	OptionalString          string                                       `protobuf:"bytes,14,opt"`
+	OptionalBytes           []byte                                       `protobuf:"bytes,15,opt"`
+	OptionalNestedMessage   *TestAllTypesProto3_NestedMessage            `protobuf:"bytes,18,opt"`
+	OptionalForeignMessage  *ForeignMessage                              `protobuf:"bytes,19,opt"`
+	OptionalNestedEnum      TestAllTypesProto3_NestedEnum                `protobuf:"varint,21,opt"`
+	OptionalForeignEnum     ForeignEnum                                  `protobuf:"varint,22,opt"`
+	OptionalAliasedEnum     TestAllTypesProto3_AliasedEnum               `protobuf:"varint,23,opt"`
+	OptionalStringPiece     string                                       `protobuf:"bytes,24,opt"`
+	OptionalCord            string                                       `protobuf:"bytes,25,opt"`
+	RecursiveMessage        *TestAllTypesProto3                          `protobuf:"bytes,27,opt"`
+	RepeatedInt32           []int32                                      `protobuf:"varint,31,rep"`
+	RepeatedInt64           []int64                                      `protobuf:"varint,32,rep"`
+	RepeatedUint32          []uint32                                     `protobuf:"varint,33,rep"`
+	RepeatedUint64          []uint64                                     `protobuf:"varint,34,rep"`
+	RepeatedSint32          []int32                                      `protobuf:"zigzag32,35,rep"`
+	RepeatedSint64          []int64                                      `protobuf:"zigzag64,36,rep"`
+	RepeatedFixed32         []uint32                                     `protobuf:"fixed32,37,rep"`
+	RepeatedFixed64         []uint64                                     `protobuf:"fixed64,38,rep"`
+	RepeatedSfixed32        []int32                                      `protobuf:"fixed32,39,rep"`
+	RepeatedSfixed64        []int64                                      `protobuf:"fixed64,40,rep"`
+	RepeatedFloat           []float32                                    `protobuf:"fixed32,41,rep"`
+	RepeatedDouble          []float64                                    `protobuf:"fixed64,42,rep"`
+	RepeatedBool            []bool                                       `protobuf:"varint,43,rep"`
+	RepeatedString          []string                                     `protobuf:"bytes,44,rep"`
+	RepeatedBytes           [][]byte                                     `protobuf:"bytes,45,rep"`
+	RepeatedNestedMessage   []*TestAllTypesProto3_NestedMessage          `protobuf:"bytes,48,rep"`
+	RepeatedForeignMessage  []*ForeignMessage                            `protobuf:"bytes,49,rep"`
+	RepeatedNestedEnum      []TestAllTypesProto3_NestedEnum              `protobuf:"varint,51,rep"`
+	RepeatedForeignEnum     []ForeignEnum                                `protobuf:"varint,52,rep"`
+	RepeatedStringPiece     []string                                     `protobuf:"bytes,54,rep"`
+	RepeatedCord            []string                                     `protobuf:"bytes,55,rep"`
+	PackedInt32             []int32                                      `protobuf:"varint,75,rep"`
+	PackedInt64             []int64                                      `protobuf:"varint,76,rep"`
+	PackedUint32            []uint32                                     `protobuf:"varint,77,rep"`
+	PackedUint64            []uint64                                     `protobuf:"varint,78,rep"`
+	PackedSint32            []int32                                      `protobuf:"zigzag32,79,rep"`
+	PackedSint64            []int64                                      `protobuf:"zigzag64,80,rep"`
+	PackedFixed32           []uint32                                     `protobuf:"fixed32,81,rep"`
+	PackedFixed64           []uint64                                     `protobuf:"fixed64,82,rep"`
+	PackedSfixed32          []int32                                      `protobuf:"fixed32,83,rep"`
+	PackedSfixed64          []int64                                      `protobuf:"fixed64,84,rep"`
+	PackedFloat             []float32                                    `protobuf:"fixed32,85,rep"`
+	PackedDouble            []float64                                    `protobuf:"fixed64,86,rep"`
+	PackedBool              []bool                                       `protobuf:"varint,87,rep"`
+	PackedNestedEnum        []TestAllTypesProto3_NestedEnum              `protobuf:"varint,88,rep"`
+	UnpackedInt32           []int32                                      `protobuf:"varint,89,rep"`
+	UnpackedInt64           []int64                                      `protobuf:"varint,90,rep"`
+	UnpackedUint32          []uint32                                     `protobuf:"varint,91,rep"`
+	UnpackedUint64          []uint64                                     `protobuf:"varint,92,rep"`
+	UnpackedSint32          []int32                                      `protobuf:"zigzag32,93,rep"`
+	UnpackedSint64          []int64                                      `protobuf:"zigzag64,94,rep"`
+	UnpackedFixed32         []uint32                                     `protobuf:"fixed32,95,rep"`
+	UnpackedFixed64         []uint64                                     `protobuf:"fixed64,96,rep"`
+	UnpackedSfixed32        []int32                                      `protobuf:"fixed32,97,rep"`
+	UnpackedSfixed64        []int64                                      `protobuf:"fixed64,98,rep"`
+	UnpackedFloat           []float32                                    `protobuf:"fixed32,99,rep"`
+	UnpackedDouble          []float64                                    `protobuf:"fixed64,100,rep"`
+	UnpackedBool            []bool                                       `protobuf:"varint,101,rep"`
+	UnpackedNestedEnum      []TestAllTypesProto3_NestedEnum              `protobuf:"varint,102,rep"`
+	MapInt32Int32           map[int32]int32                              `protobuf:"bytes,56,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapInt64Int64           map[int64]int64                              `protobuf:"bytes,57,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapUint32Uint32         map[uint32]uint32                            `protobuf:"bytes,58,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapUint64Uint64         map[uint64]uint64                            `protobuf:"bytes,59,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapSint32Sint32         map[int32]int32                              `protobuf:"bytes,60,rep" protobuf_key:"zigzag32,1,opt" protobuf_val:"zigzag32,2,opt"`
+	MapSint64Sint64         map[int64]int64                              `protobuf:"bytes,61,rep" protobuf_key:"zigzag64,1,opt" protobuf_val:"zigzag64,2,opt"`
+	MapFixed32Fixed32       map[uint32]uint32                            `protobuf:"bytes,62,rep" protobuf_key:"fixed32,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapFixed64Fixed64       map[uint64]uint64                            `protobuf:"bytes,63,rep" protobuf_key:"fixed64,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapSfixed32Sfixed32     map[int32]int32                              `protobuf:"bytes,64,rep" protobuf_key:"fixed32,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapSfixed64Sfixed64     map[int64]int64                              `protobuf:"bytes,65,rep" protobuf_key:"fixed64,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapInt32Float           map[int32]float32                            `protobuf:"bytes,66,rep" protobuf_key:"varint,1,opt" protobuf_val:"fixed32,2,opt"`
+	MapInt32Double          map[int32]float64                            `protobuf:"bytes,67,rep" protobuf_key:"varint,1,opt" protobuf_val:"fixed64,2,opt"`
+	MapBoolBool             map[bool]bool                                `protobuf:"bytes,68,rep" protobuf_key:"varint,1,opt" protobuf_val:"varint,2,opt"`
+	MapStringString         map[string]string                            `protobuf:"bytes,69,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringBytes          map[string][]byte                            `protobuf:"bytes,70,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringNestedMessage  map[string]*TestAllTypesProto3_NestedMessage `protobuf:"bytes,71,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringForeignMessage map[string]*ForeignMessage                   `protobuf:"bytes,72,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	MapStringNestedEnum     map[string]TestAllTypesProto3_NestedEnum     `protobuf:"bytes,73,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+	MapStringForeignEnum    map[string]ForeignEnum                       `protobuf:"bytes,74,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+	// Types that are assignable to OneofField:
+	//	*TestAllTypesProto3_OneofUint32
+	//	*TestAllTypesProto3_OneofNestedMessage
+	//	*TestAllTypesProto3_OneofString
+	//	*TestAllTypesProto3_OneofBytes
+	//	*TestAllTypesProto3_OneofBool
+	//	*TestAllTypesProto3_OneofUint64
+	//	*TestAllTypesProto3_OneofFloat
+	//	*TestAllTypesProto3_OneofDouble
+	//	*TestAllTypesProto3_OneofEnum
+	//	*TestAllTypesProto3_OneofNullValue
+	OneofField            isTestAllTypesProto3_OneofField `protobuf_oneof:"oneof_field"`
+	OptionalBoolWrapper   *wrapperspb.BoolValue           `protobuf:"bytes,201,opt"`
+	OptionalInt32Wrapper  *wrapperspb.Int32Value          `protobuf:"bytes,202,opt"`
+	OptionalInt64Wrapper  *wrapperspb.Int64Value          `protobuf:"bytes,203,opt"`
+	OptionalUint32Wrapper *wrapperspb.UInt32Value         `protobuf:"bytes,204,opt"`
+	OptionalUint64Wrapper *wrapperspb.UInt64Value         `protobuf:"bytes,205,opt"`
+	OptionalFloatWrapper  *wrapperspb.FloatValue          `protobuf:"bytes,206,opt"`
+	OptionalDoubleWrapper *wrapperspb.DoubleValue         `protobuf:"bytes,207,opt"`
+	OptionalStringWrapper *wrapperspb.StringValue         `protobuf:"bytes,208,opt"`
+	OptionalBytesWrapper  *wrapperspb.BytesValue          `protobuf:"bytes,209,opt"`
+	RepeatedBoolWrapper   []*wrapperspb.BoolValue         `protobuf:"bytes,211,rep"`
+	RepeatedInt32Wrapper  []*wrapperspb.Int32Value        `protobuf:"bytes,212,rep"`
+	RepeatedInt64Wrapper  []*wrapperspb.Int64Value        `protobuf:"bytes,213,rep"`
+	RepeatedUint32Wrapper []*wrapperspb.UInt32Value       `protobuf:"bytes,214,rep"`
+	RepeatedUint64Wrapper []*wrapperspb.UInt64Value       `protobuf:"bytes,215,rep"`
+	RepeatedFloatWrapper  []*wrapperspb.FloatValue        `protobuf:"bytes,216,rep"`
+	RepeatedDoubleWrapper []*wrapperspb.DoubleValue       `protobuf:"bytes,217,rep"`
+	RepeatedStringWrapper []*wrapperspb.StringValue       `protobuf:"bytes,218,rep"`
+	RepeatedBytesWrapper  []*wrapperspb.BytesValue        `protobuf:"bytes,219,rep"`
+	OptionalDuration      *durationpb.Duration            `protobuf:"bytes,301,opt"`
+	OptionalTimestamp     *timestamppb.Timestamp          `protobuf:"bytes,302,opt"`
+	OptionalFieldMask     *fieldmaskpb.FieldMask          `protobuf:"bytes,303,opt"`
+	OptionalStruct        *structpb.Struct                `protobuf:"bytes,304,opt"`
+	OptionalAny           *anypb.Any                      `protobuf:"bytes,305,opt"`
+	OptionalValue         *structpb.Value                 `protobuf:"bytes,306,opt"`
+	OptionalNullValue     structpb.NullValue              `protobuf:"varint,307,opt"`
+	RepeatedDuration      []*durationpb.Duration          `protobuf:"bytes,311,rep"`
+	RepeatedTimestamp     []*timestamppb.Timestamp        `protobuf:"bytes,312,rep"`
+	RepeatedFieldmask     []*fieldmaskpb.FieldMask        `protobuf:"bytes,313,rep"`
+	RepeatedStruct        []*structpb.Struct              `protobuf:"bytes,324,rep"`
+	RepeatedAny           []*anypb.Any                    `protobuf:"bytes,315,rep"`
+	RepeatedValue         []*structpb.Value               `protobuf:"bytes,316,rep"`
+	RepeatedListValue     []*structpb.ListValue           `protobuf:"bytes,317,rep"`
+	Fieldname1            int32                           `protobuf:"varint,401,opt"`
+	FieldName2            int32                           `protobuf:"varint,402,opt"`
+	XFieldName3           int32                           `protobuf:"varint,403,opt"`
+	Field_Name4_          int32                           `protobuf:"varint,404,opt"`
+	Field0Name5           int32                           `protobuf:"varint,405,opt"`
+	Field_0Name6          int32                           `protobuf:"varint,406,opt"`
+	FieldName7            int32                           `protobuf:"varint,407,opt"`
+	FieldName8            int32                           `protobuf:"varint,408,opt"`
+	Field_Name9           int32                           `protobuf:"varint,409,opt"`
+	Field_Name10          int32                           `protobuf:"varint,410,opt"`
+	FIELD_NAME11          int32                           `protobuf:"varint,411,opt"`
+	FIELDName12           int32                           `protobuf:"varint,412,opt"`
+	XFieldName13          int32                           `protobuf:"varint,413,opt"`
+	X_FieldName14         int32                           `protobuf:"varint,414,opt"`
+	Field_Name15          int32                           `protobuf:"varint,415,opt"`
+	Field__Name16         int32                           `protobuf:"varint,416,opt"`
+	FieldName17__         int32                           `protobuf:"varint,417,opt"`
+	FieldName18__         int32                           `protobuf:"varint,418,opt"`
+}
+
+func (x *TestAllTypesProto3) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto3) GetOptionalInt32() int32 {
+	if x != nil {
+		return x.OptionalInt32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalInt64() int64 {
+	if x != nil {
+		return x.OptionalInt64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalUint32() uint32 {
+	if x != nil {
+		return x.OptionalUint32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalUint64() uint64 {
+	if x != nil {
+		return x.OptionalUint64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalSint32() int32 {
+	if x != nil {
+		return x.OptionalSint32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalSint64() int64 {
+	if x != nil {
+		return x.OptionalSint64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalFixed32() uint32 {
+	if x != nil {
+		return x.OptionalFixed32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalFixed64() uint64 {
+	if x != nil {
+		return x.OptionalFixed64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalSfixed32() int32 {
+	if x != nil {
+		return x.OptionalSfixed32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalSfixed64() int64 {
+	if x != nil {
+		return x.OptionalSfixed64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalFloat() float32 {
+	if x != nil {
+		return x.OptionalFloat
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalDouble() float64 {
+	if x != nil {
+		return x.OptionalDouble
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOptionalBool() bool {
+	if x != nil {
+		return x.OptionalBool
+	}
+	return false
+}
+
+func (x *TestAllTypesProto3) GetOptionalString() string {
+	if x != nil {
+		return x.OptionalString
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto3) GetOptionalBytes() []byte {
+	if x != nil {
+		return x.OptionalBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalNestedMessage() *TestAllTypesProto3_NestedMessage {
+	if x != nil {
+		return x.OptionalNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalForeignMessage() *ForeignMessage {
+	if x != nil {
+		return x.OptionalForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalNestedEnum() TestAllTypesProto3_NestedEnum {
+	if x != nil {
+		return x.OptionalNestedEnum
+	}
+	return TestAllTypesProto3_FOO
+}
+
+func (x *TestAllTypesProto3) GetOptionalForeignEnum() ForeignEnum {
+	if x != nil {
+		return x.OptionalForeignEnum
+	}
+	return ForeignEnum_FOREIGN_FOO
+}
+
+func (x *TestAllTypesProto3) GetOptionalAliasedEnum() TestAllTypesProto3_AliasedEnum {
+	if x != nil {
+		return x.OptionalAliasedEnum
+	}
+	return TestAllTypesProto3_ALIAS_FOO
+}
+
+func (x *TestAllTypesProto3) GetOptionalStringPiece() string {
+	if x != nil {
+		return x.OptionalStringPiece
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto3) GetOptionalCord() string {
+	if x != nil {
+		return x.OptionalCord
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto3) GetRecursiveMessage() *TestAllTypesProto3 {
+	if x != nil {
+		return x.RecursiveMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedInt32() []int32 {
+	if x != nil {
+		return x.RepeatedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedInt64() []int64 {
+	if x != nil {
+		return x.RepeatedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedUint32() []uint32 {
+	if x != nil {
+		return x.RepeatedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedUint64() []uint64 {
+	if x != nil {
+		return x.RepeatedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedSint32() []int32 {
+	if x != nil {
+		return x.RepeatedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedSint64() []int64 {
+	if x != nil {
+		return x.RepeatedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedFixed32() []uint32 {
+	if x != nil {
+		return x.RepeatedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedFixed64() []uint64 {
+	if x != nil {
+		return x.RepeatedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedSfixed32() []int32 {
+	if x != nil {
+		return x.RepeatedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedSfixed64() []int64 {
+	if x != nil {
+		return x.RepeatedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedFloat() []float32 {
+	if x != nil {
+		return x.RepeatedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedDouble() []float64 {
+	if x != nil {
+		return x.RepeatedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedBool() []bool {
+	if x != nil {
+		return x.RepeatedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedString() []string {
+	if x != nil {
+		return x.RepeatedString
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedBytes() [][]byte {
+	if x != nil {
+		return x.RepeatedBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedNestedMessage() []*TestAllTypesProto3_NestedMessage {
+	if x != nil {
+		return x.RepeatedNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedForeignMessage() []*ForeignMessage {
+	if x != nil {
+		return x.RepeatedForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedNestedEnum() []TestAllTypesProto3_NestedEnum {
+	if x != nil {
+		return x.RepeatedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedForeignEnum() []ForeignEnum {
+	if x != nil {
+		return x.RepeatedForeignEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedStringPiece() []string {
+	if x != nil {
+		return x.RepeatedStringPiece
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedCord() []string {
+	if x != nil {
+		return x.RepeatedCord
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedInt32() []int32 {
+	if x != nil {
+		return x.PackedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedInt64() []int64 {
+	if x != nil {
+		return x.PackedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedUint32() []uint32 {
+	if x != nil {
+		return x.PackedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedUint64() []uint64 {
+	if x != nil {
+		return x.PackedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedSint32() []int32 {
+	if x != nil {
+		return x.PackedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedSint64() []int64 {
+	if x != nil {
+		return x.PackedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedFixed32() []uint32 {
+	if x != nil {
+		return x.PackedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedFixed64() []uint64 {
+	if x != nil {
+		return x.PackedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedSfixed32() []int32 {
+	if x != nil {
+		return x.PackedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedSfixed64() []int64 {
+	if x != nil {
+		return x.PackedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedFloat() []float32 {
+	if x != nil {
+		return x.PackedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedDouble() []float64 {
+	if x != nil {
+		return x.PackedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedBool() []bool {
+	if x != nil {
+		return x.PackedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetPackedNestedEnum() []TestAllTypesProto3_NestedEnum {
+	if x != nil {
+		return x.PackedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedInt32() []int32 {
+	if x != nil {
+		return x.UnpackedInt32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedInt64() []int64 {
+	if x != nil {
+		return x.UnpackedInt64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedUint32() []uint32 {
+	if x != nil {
+		return x.UnpackedUint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedUint64() []uint64 {
+	if x != nil {
+		return x.UnpackedUint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedSint32() []int32 {
+	if x != nil {
+		return x.UnpackedSint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedSint64() []int64 {
+	if x != nil {
+		return x.UnpackedSint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedFixed32() []uint32 {
+	if x != nil {
+		return x.UnpackedFixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedFixed64() []uint64 {
+	if x != nil {
+		return x.UnpackedFixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedSfixed32() []int32 {
+	if x != nil {
+		return x.UnpackedSfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedSfixed64() []int64 {
+	if x != nil {
+		return x.UnpackedSfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedFloat() []float32 {
+	if x != nil {
+		return x.UnpackedFloat
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedDouble() []float64 {
+	if x != nil {
+		return x.UnpackedDouble
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedBool() []bool {
+	if x != nil {
+		return x.UnpackedBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetUnpackedNestedEnum() []TestAllTypesProto3_NestedEnum {
+	if x != nil {
+		return x.UnpackedNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapInt32Int32() map[int32]int32 {
+	if x != nil {
+		return x.MapInt32Int32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapInt64Int64() map[int64]int64 {
+	if x != nil {
+		return x.MapInt64Int64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapUint32Uint32() map[uint32]uint32 {
+	if x != nil {
+		return x.MapUint32Uint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapUint64Uint64() map[uint64]uint64 {
+	if x != nil {
+		return x.MapUint64Uint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapSint32Sint32() map[int32]int32 {
+	if x != nil {
+		return x.MapSint32Sint32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapSint64Sint64() map[int64]int64 {
+	if x != nil {
+		return x.MapSint64Sint64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapFixed32Fixed32() map[uint32]uint32 {
+	if x != nil {
+		return x.MapFixed32Fixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapFixed64Fixed64() map[uint64]uint64 {
+	if x != nil {
+		return x.MapFixed64Fixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapSfixed32Sfixed32() map[int32]int32 {
+	if x != nil {
+		return x.MapSfixed32Sfixed32
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapSfixed64Sfixed64() map[int64]int64 {
+	if x != nil {
+		return x.MapSfixed64Sfixed64
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapInt32Float() map[int32]float32 {
+	if x != nil {
+		return x.MapInt32Float
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapInt32Double() map[int32]float64 {
+	if x != nil {
+		return x.MapInt32Double
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapBoolBool() map[bool]bool {
+	if x != nil {
+		return x.MapBoolBool
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringString() map[string]string {
+	if x != nil {
+		return x.MapStringString
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringBytes() map[string][]byte {
+	if x != nil {
+		return x.MapStringBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringNestedMessage() map[string]*TestAllTypesProto3_NestedMessage {
+	if x != nil {
+		return x.MapStringNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringForeignMessage() map[string]*ForeignMessage {
+	if x != nil {
+		return x.MapStringForeignMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringNestedEnum() map[string]TestAllTypesProto3_NestedEnum {
+	if x != nil {
+		return x.MapStringNestedEnum
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetMapStringForeignEnum() map[string]ForeignEnum {
+	if x != nil {
+		return x.MapStringForeignEnum
+	}
+	return nil
+}
+
+func (m *TestAllTypesProto3) GetOneofField() isTestAllTypesProto3_OneofField {
+	if m != nil {
+		return m.OneofField
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOneofUint32() uint32 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofUint32); ok {
+		return x.OneofUint32
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOneofNestedMessage() *TestAllTypesProto3_NestedMessage {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofNestedMessage); ok {
+		return x.OneofNestedMessage
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOneofString() string {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofString); ok {
+		return x.OneofString
+	}
+	return ""
+}
+
+func (x *TestAllTypesProto3) GetOneofBytes() []byte {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofBytes); ok {
+		return x.OneofBytes
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOneofBool() bool {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofBool); ok {
+		return x.OneofBool
+	}
+	return false
+}
+
+func (x *TestAllTypesProto3) GetOneofUint64() uint64 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofUint64); ok {
+		return x.OneofUint64
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOneofFloat() float32 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofFloat); ok {
+		return x.OneofFloat
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOneofDouble() float64 {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofDouble); ok {
+		return x.OneofDouble
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetOneofEnum() TestAllTypesProto3_NestedEnum {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofEnum); ok {
+		return x.OneofEnum
+	}
+	return TestAllTypesProto3_FOO
+}
+
+func (x *TestAllTypesProto3) GetOneofNullValue() structpb.NullValue {
+	if x, ok := x.GetOneofField().(*TestAllTypesProto3_OneofNullValue); ok {
+		return x.OneofNullValue
+	}
+	return structpb.NullValue(0)
+}
+
+func (x *TestAllTypesProto3) GetOptionalBoolWrapper() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.OptionalBoolWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalInt32Wrapper() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.OptionalInt32Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalInt64Wrapper() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.OptionalInt64Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalUint32Wrapper() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.OptionalUint32Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalUint64Wrapper() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.OptionalUint64Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalFloatWrapper() *wrapperspb.FloatValue {
+	if x != nil {
+		return x.OptionalFloatWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalDoubleWrapper() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.OptionalDoubleWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalStringWrapper() *wrapperspb.StringValue {
+	if x != nil {
+		return x.OptionalStringWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalBytesWrapper() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.OptionalBytesWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedBoolWrapper() []*wrapperspb.BoolValue {
+	if x != nil {
+		return x.RepeatedBoolWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedInt32Wrapper() []*wrapperspb.Int32Value {
+	if x != nil {
+		return x.RepeatedInt32Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedInt64Wrapper() []*wrapperspb.Int64Value {
+	if x != nil {
+		return x.RepeatedInt64Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedUint32Wrapper() []*wrapperspb.UInt32Value {
+	if x != nil {
+		return x.RepeatedUint32Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedUint64Wrapper() []*wrapperspb.UInt64Value {
+	if x != nil {
+		return x.RepeatedUint64Wrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedFloatWrapper() []*wrapperspb.FloatValue {
+	if x != nil {
+		return x.RepeatedFloatWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedDoubleWrapper() []*wrapperspb.DoubleValue {
+	if x != nil {
+		return x.RepeatedDoubleWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedStringWrapper() []*wrapperspb.StringValue {
+	if x != nil {
+		return x.RepeatedStringWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedBytesWrapper() []*wrapperspb.BytesValue {
+	if x != nil {
+		return x.RepeatedBytesWrapper
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalDuration() *durationpb.Duration {
+	if x != nil {
+		return x.OptionalDuration
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OptionalTimestamp
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalFieldMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.OptionalFieldMask
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalStruct() *structpb.Struct {
+	if x != nil {
+		return x.OptionalStruct
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalAny() *anypb.Any {
+	if x != nil {
+		return x.OptionalAny
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalValue() *structpb.Value {
+	if x != nil {
+		return x.OptionalValue
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetOptionalNullValue() structpb.NullValue {
+	if x != nil {
+		return x.OptionalNullValue
+	}
+	return structpb.NullValue(0)
+}
+
+func (x *TestAllTypesProto3) GetRepeatedDuration() []*durationpb.Duration {
+	if x != nil {
+		return x.RepeatedDuration
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedTimestamp() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.RepeatedTimestamp
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedFieldmask() []*fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.RepeatedFieldmask
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedStruct() []*structpb.Struct {
+	if x != nil {
+		return x.RepeatedStruct
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedAny() []*anypb.Any {
+	if x != nil {
+		return x.RepeatedAny
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedValue() []*structpb.Value {
+	if x != nil {
+		return x.RepeatedValue
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetRepeatedListValue() []*structpb.ListValue {
+	if x != nil {
+		return x.RepeatedListValue
+	}
+	return nil
+}
+
+func (x *TestAllTypesProto3) GetFieldname1() int32 {
+	if x != nil {
+		return x.Fieldname1
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFieldName2() int32 {
+	if x != nil {
+		return x.FieldName2
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetXFieldName3() int32 {
+	if x != nil {
+		return x.XFieldName3
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField_Name4_() int32 {
+	if x != nil {
+		return x.Field_Name4_
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField0Name5() int32 {
+	if x != nil {
+		return x.Field0Name5
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField_0Name6() int32 {
+	if x != nil {
+		return x.Field_0Name6
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFieldName7() int32 {
+	if x != nil {
+		return x.FieldName7
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFieldName8() int32 {
+	if x != nil {
+		return x.FieldName8
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField_Name9() int32 {
+	if x != nil {
+		return x.Field_Name9
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField_Name10() int32 {
+	if x != nil {
+		return x.Field_Name10
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFIELD_NAME11() int32 {
+	if x != nil {
+		return x.FIELD_NAME11
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFIELDName12() int32 {
+	if x != nil {
+		return x.FIELDName12
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetXFieldName13() int32 {
+	if x != nil {
+		return x.XFieldName13
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetX_FieldName14() int32 {
+	if x != nil {
+		return x.X_FieldName14
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField_Name15() int32 {
+	if x != nil {
+		return x.Field_Name15
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetField__Name16() int32 {
+	if x != nil {
+		return x.Field__Name16
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFieldName17__() int32 {
+	if x != nil {
+		return x.FieldName17__
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3) GetFieldName18__() int32 {
+	if x != nil {
+		return x.FieldName18__
+	}
+	return 0
+}
+
+type isTestAllTypesProto3_OneofField interface {
+	isTestAllTypesProto3_OneofField()
+}
+
+type TestAllTypesProto3_OneofUint32 struct {
+	OneofUint32 uint32 `protobuf:"varint,111,opt"`
+}
+
+type TestAllTypesProto3_OneofNestedMessage struct {
+	OneofNestedMessage *TestAllTypesProto3_NestedMessage `protobuf:"bytes,112,opt"`
+}
+
+type TestAllTypesProto3_OneofString struct {
+	OneofString string `protobuf:"bytes,113,opt"`
+}
+
+type TestAllTypesProto3_OneofBytes struct {
+	OneofBytes []byte `protobuf:"bytes,114,opt"`
+}
+
+type TestAllTypesProto3_OneofBool struct {
+	OneofBool bool `protobuf:"varint,115,opt"`
+}
+
+type TestAllTypesProto3_OneofUint64 struct {
+	OneofUint64 uint64 `protobuf:"varint,116,opt"`
+}
+
+type TestAllTypesProto3_OneofFloat struct {
+	OneofFloat float32 `protobuf:"fixed32,117,opt"`
+}
+
+type TestAllTypesProto3_OneofDouble struct {
+	OneofDouble float64 `protobuf:"fixed64,118,opt"`
+}
+
+type TestAllTypesProto3_OneofEnum struct {
+	OneofEnum TestAllTypesProto3_NestedEnum `protobuf:"varint,119,opt"`
+}
+
+type TestAllTypesProto3_OneofNullValue struct {
+	OneofNullValue structpb.NullValue `protobuf:"varint,120,opt"`
+}
+
+func (*TestAllTypesProto3_OneofUint32) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofNestedMessage) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofString) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofBytes) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofBool) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofUint64) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofFloat) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofDouble) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofEnum) isTestAllTypesProto3_OneofField() {}
+
+func (*TestAllTypesProto3_OneofNullValue) isTestAllTypesProto3_OneofField() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TestAllTypesProto3) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TestAllTypesProto3_OneofUint32)(nil),
+		(*TestAllTypesProto3_OneofNestedMessage)(nil),
+		(*TestAllTypesProto3_OneofString)(nil),
+		(*TestAllTypesProto3_OneofBytes)(nil),
+		(*TestAllTypesProto3_OneofBool)(nil),
+		(*TestAllTypesProto3_OneofUint64)(nil),
+		(*TestAllTypesProto3_OneofFloat)(nil),
+		(*TestAllTypesProto3_OneofDouble)(nil),
+		(*TestAllTypesProto3_OneofEnum)(nil),
+		(*TestAllTypesProto3_OneofNullValue)(nil),
+	}
+}
+
+type ForeignMessage struct {
+	C int32 `protobuf:"varint,1,opt"`
+	_ [0]func()
+}
+
+func (x *ForeignMessage) String() string {
+	return proto.Format(x)
+}
+
+func (x *ForeignMessage) GetC() int32 {
+	if x != nil {
+		return x.C
+	}
+	return 0
+}
+
+type TestAllTypesProto3_NestedMessage struct {
+	A           int32               `protobuf:"varint,1,opt"`
+	Corecursive *TestAllTypesProto3 `protobuf:"bytes,2,opt"`
+	_           [0]func()
+}
+
+func (x *TestAllTypesProto3_NestedMessage) String() string {
+	return proto.Format(x)
+}
+
+func (x *TestAllTypesProto3_NestedMessage) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+func (x *TestAllTypesProto3_NestedMessage) GetCorecursive() *TestAllTypesProto3 {
+	if x != nil {
+		return x.Corecursive
+	}
+	return nil
+}
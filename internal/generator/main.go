@@ -37,6 +37,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"math"
 	"strconv"
 	"strings"
 	"unicode"
@@ -47,7 +48,19 @@ import (
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
-var protoPackage = protogen.GoImportPath("github.com/RomiChan/protobuf/proto")
+var (
+	mathPackage  = protogen.GoImportPath("math")
+	slogPackage  = protogen.GoImportPath("log/slog")
+	protoPackage = protogen.GoImportPath("github.com/RomiChan/protobuf/proto")
+)
+
+// RegisterTypes makes the generated files register the full names of their
+// messages with proto.RegisterType.
+var RegisterTypes bool
+
+// LogValuer makes the generated messages implement slog.LogValuer, it
+// requires Go 1.21.
+var LogValuer bool
 
 // GenerateFile generates the contents of a .pb.go file.
 func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
@@ -70,10 +83,35 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.Generated
 	for _, message := range f.allMessages {
 		genMessage(g, f, message)
 	}
+	genExtensions(g, f)
+	for _, service := range f.Services {
+		genService(g, service)
+	}
+	if RegisterTypes {
+		genRegisterTypes(g, f)
+	}
 
 	return g
 }
 
+func genRegisterTypes(g *protogen.GeneratedFile, f *fileInfo) {
+	var messages []*messageInfo
+	for _, message := range f.allMessages {
+		if !message.Desc.IsMapEntry() {
+			messages = append(messages, message)
+		}
+	}
+	if len(messages) == 0 {
+		return
+	}
+	g.P("func init() {")
+	for _, message := range messages {
+		g.P(protoPackage.Ident("RegisterType"), `("`, message.Desc.FullName(), `", (*`, message.GoIdent, ")(nil))")
+	}
+	g.P("}")
+	g.P()
+}
+
 func genGeneratedHeader(_ *protogen.Plugin, g *protogen.GeneratedFile, f *fileInfo) {
 	g.P("// Code generated by protoc-gen-golite. DO NOT EDIT.")
 
@@ -85,6 +123,13 @@ func genGeneratedHeader(_ *protogen.Plugin, g *protogen.GeneratedFile, f *fileIn
 	g.P()
 }
 
+// optionFiles are the files declaring options, they have no golite version.
+var optionFiles = map[string]bool{
+	"buf/validate/validate.proto":      true,
+	"golite/options.proto":             true,
+	"google/protobuf/descriptor.proto": true,
+}
+
 func genImport(gen *protogen.Plugin, g *protogen.GeneratedFile, f *fileInfo, imp protoreflect.FileImport) {
 	impFile, ok := gen.FilesByPath[imp.Path()]
 	if !ok {
@@ -97,7 +142,8 @@ func genImport(gen *protogen.Plugin, g *protogen.GeneratedFile, f *fileInfo, imp
 	// Generate imports for all non-weak dependencies, even if they are not
 	// referenced, because other code and tools depend on having the
 	// full transitive closure of protocol buffer types in the binary.
-	if !imp.IsWeak {
+	// The files declaring options are only needed by the compiler.
+	if !imp.IsWeak && !optionFiles[imp.Path()] {
 		g.Import(impFile.GoImportPath)
 	}
 	if !imp.IsPublic {
@@ -219,6 +265,7 @@ func genMessage(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
 	g.P("}")
 	g.P()
 
+	genMessageDefaultDecls(g, f, m)
 	genMessageMethods(g, f, m)
 	genMessageOneofWrapperTypes(g, f, m)
 }
@@ -229,6 +276,18 @@ func genMessageFields(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
 	for _, field := range m.Fields {
 		genMessageField(g, f, m, field, sf)
 	}
+	if ranges := m.Desc.ExtensionRanges(); ranges.Len() > 0 {
+		var ss []string
+		for i := 0; i < ranges.Len(); i++ {
+			r := ranges.Get(i) // end is exclusive
+			ss = append(ss, fmt.Sprintf("%d-%d", r[0], r[1]-1))
+		}
+		tags := structTags{
+			{"protobuf_extensions", strings.Join(ss, ",")},
+		}
+		g.P("XXX_InternalExtensions ", protoPackage.Ident("Extensions"), tags)
+		f.comparable = false
+	}
 	if f.comparable {
 		g.P("_ [0]func()")
 	}
@@ -266,13 +325,25 @@ func genMessageField(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo, fie
 		sf.append(oneof.GoName)
 		return
 	}
-	goType, option, comp := fieldGoType(g, f, field)
+	goType, presence, comp := fieldGoType(g, f, field)
+	if fieldLazy(field) {
+		goType = g.QualifiedGoIdent(protoPackage.Ident("Lazy[" + strings.TrimPrefix(goType, "*") + "]"))
+		comp = false
+	}
 	f.comparable = f.comparable && comp
-	if option {
-		goType = g.QualifiedGoIdent(protoPackage.Ident("Option[" + goType + "]"))
+	protobufTag := fieldProtobufTagValue(field)
+	if presence {
+		switch fieldOptionalStyle(f, field) {
+		case optionalOption:
+			goType = g.QualifiedGoIdent(protoPackage.Ident("Option[" + goType + "]"))
+		case optionalPointer:
+			goType = "*" + goType
+			// a non-nil pointer to the zero value is encoded
+			protobufTag += ",presence"
+		}
 	}
 	tags := structTags{
-		{"protobuf", fieldProtobufTagValue(field)},
+		{"protobuf", protobufTag},
 	}
 	if field.Desc.IsMap() {
 		key := field.Message.Fields[0]
@@ -296,8 +367,119 @@ func genMessageField(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo, fie
 	sf.append(field.GoName)
 }
 
+// genMessageDefaultDecls generates consts and vars holding the default
+// values of fields.
+func genMessageDefaultDecls(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	var consts, vars []string
+	for _, field := range m.Fields {
+		if !field.Desc.HasDefault() {
+			continue
+		}
+		name := "Default_" + m.GoIdent.GoName + "_" + field.GoName
+		// Scalar fields.
+		defVal := field.Desc.Default()
+		switch field.Desc.Kind() {
+		case protoreflect.StringKind:
+			consts = append(consts, fmt.Sprintf("%s = %s", name, strconv.Quote(defVal.String())))
+		case protoreflect.BytesKind:
+			vars = append(vars, fmt.Sprintf("%s = []byte(%s)", name, strconv.Quote(string(defVal.Bytes()))))
+		case protoreflect.EnumKind:
+			idx := field.Desc.DefaultEnumValue().Index()
+			val := field.Enum.Values[idx]
+			if val.GoIdent.GoImportPath == f.GoImportPath {
+				consts = append(consts, fmt.Sprintf("%s = %s", name, g.QualifiedGoIdent(val.GoIdent)))
+			} else {
+				// If the enum value is declared in a different Go package,
+				// reference it by number since the name may not be correct.
+				// See https://github.com/golang/protobuf/issues/513.
+				consts = append(consts, fmt.Sprintf("%s = %s(%d)", name, g.QualifiedGoIdent(field.Enum.GoIdent), val.Desc.Number()))
+			}
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			goType, _, _ := fieldGoType(g, f, field)
+			if v := defVal.Float(); math.IsNaN(v) || math.IsInf(v, 0) {
+				var fn, arg string
+				switch {
+				case math.IsInf(v, -1):
+					fn, arg = g.QualifiedGoIdent(mathPackage.Ident("Inf")), "-1"
+				case math.IsInf(v, +1):
+					fn, arg = g.QualifiedGoIdent(mathPackage.Ident("Inf")), "+1"
+				case math.IsNaN(v):
+					fn, arg = g.QualifiedGoIdent(mathPackage.Ident("NaN")), ""
+				}
+				vars = append(vars, fmt.Sprintf("%s = %s(%s(%s))", name, goType, fn, arg))
+			} else {
+				consts = append(consts, fmt.Sprintf("%s = %s(%v)", name, goType, v))
+			}
+		default:
+			goType, _, _ := fieldGoType(g, f, field)
+			consts = append(consts, fmt.Sprintf("%s = %s(%v)", name, goType, defVal.Interface()))
+		}
+	}
+
+	// Generate consts and vars.
+	if len(consts) > 0 {
+		g.P("// Default values for ", m.GoIdent, " fields.")
+		g.P("const (")
+		for _, s := range consts {
+			g.P(s)
+		}
+		g.P(")")
+		g.P()
+	}
+	if len(vars) > 0 {
+		g.P("// Default values for ", m.GoIdent, " fields.")
+		g.P("var (")
+		for _, s := range vars {
+			g.P(s)
+		}
+		g.P(")")
+		g.P()
+	}
+}
+
+// genExtensions generates the descriptors of the extension fields.
+func genExtensions(g *protogen.GeneratedFile, f *fileInfo) {
+	if len(f.allExtensions) == 0 {
+		return
+	}
+	g.P("var (")
+	for _, x := range f.allExtensions {
+		goType, _, _ := fieldGoType(g, f, x.Extension)
+		g.Annotate("E_"+x.GoIdent.GoName, x.Location)
+		leadingComments := appendDeprecationSuffix(x.Comments.Leading,
+			x.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated())
+		g.P(leadingComments,
+			"E_", x.GoIdent.GoName, " = &", protoPackage.Ident("ExtensionDesc"), "[", x.Extendee.GoIdent, ", ", goType, "]{")
+		g.P(`Name: "`, x.Desc.FullName(), `",`)
+		g.P(`Tag: "`, fieldProtobufTagValue(x.Extension), `",`)
+		g.P("}")
+	}
+	g.P(")")
+	g.P()
+}
+
 func genMessageMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	genMessageBaseMethods(g, f, m)
 	genMessageGetterMethods(g, f, m)
+	if fileValidates(f) {
+		genMessageValidate(g, f, m)
+	}
+}
+
+func genMessageBaseMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	// String method.
+	g.P("func (x *", m.GoIdent, ") String() string {")
+	g.P("return ", protoPackage.Ident("Format"), "(x)")
+	g.P("}")
+	g.P()
+
+	// LogValue method.
+	if LogValuer {
+		g.P("func (x *", m.GoIdent, ") LogValue() ", slogPackage.Ident("Value"), " {")
+		g.P("return ", protoPackage.Ident("LogValue"), "(x)")
+		g.P("}")
+		g.P()
+	}
 }
 
 func genMessageGetterMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
@@ -317,7 +499,11 @@ func genMessageGetterMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageI
 		}
 
 		// Getter for message field.
-		goType, _, _ := fieldGoType(g, f, field)
+		goType, presence, _ := fieldGoType(g, f, field)
+		style := ""
+		if presence {
+			style = fieldOptionalStyle(f, field)
+		}
 		defaultValue := fieldDefaultValue(g, f, m, field)
 		g.Annotate(m.GoIdent.GoName+".Get"+field.GoName, field.Location)
 		leadingComments := appendDeprecationSuffix("",
@@ -330,22 +516,43 @@ func genMessageGetterMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageI
 			g.P("}")
 			g.P("return ", defaultValue)
 			g.P("}")
+		case fieldLazy(field):
+			// the decoding errors are returned by the Get method of the field
+			g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
+			g.P("if x != nil {")
+			g.P("v, _ := x.", field.GoName, ".Get()")
+			g.P("return v")
+			g.P("}")
+			g.P("return nil")
+			g.P("}")
+		case style == optionalOption:
+			g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
+			g.P("if x != nil && x.", field.GoName, ".IsSome() {")
+			g.P("return x.", field.GoName, ".Unwrap()")
+			g.P("}")
+			g.P("return ", defaultValue)
+			g.P("}")
+		case style == optionalPointer:
+			g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
+			g.P("if x != nil && x.", field.GoName, " != nil {")
+			g.P("return *x.", field.GoName)
+			g.P("}")
+			g.P("return ", defaultValue)
+			g.P("}")
+		case field.Desc.Kind() == protoreflect.BytesKind && field.Desc.HasDefault():
+			g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
+			g.P("if x != nil && x.", field.GoName, " != nil {")
+			g.P("return x.", field.GoName)
+			g.P("}")
+			g.P("return ", defaultValue)
+			g.P("}")
 		default:
-			/*
-				if !field.Desc.HasPresence() || defaultValue == "nil" {
-					continue
-				}
-				g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
-				g.P("if x != nil && x.", field.GoName, " != nil {")
-				star := ""
-				if pointer {
-					star = "*"
-				}
-				g.P("return ", star, " x.", field.GoName)
-				g.P("}")
-				g.P("return ", defaultValue)
-				g.P("}")'
-			*/
+			g.P(leadingComments, "func (x *", m.GoIdent, ") Get", field.GoName, "() ", goType, " {")
+			g.P("if x != nil {")
+			g.P("return x.", field.GoName)
+			g.P("}")
+			g.P("return ", defaultValue)
+			g.P("}")
 		}
 		g.P()
 	}
@@ -353,7 +560,8 @@ func genMessageGetterMethods(g *protogen.GeneratedFile, f *fileInfo, m *messageI
 
 // fieldGoType returns the Go type used for a field.
 //
-// If it returns pointer=true, the struct field is a pointer to the type.
+// If it returns pointer=true, the field has presence and the struct field
+// wraps the type as selected by fieldOptionalStyle.
 func fieldGoType(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field) (goType string, pointer bool, comparable bool) {
 	if field.Desc.IsWeak() {
 		return "struct{}", false, true
@@ -399,6 +607,14 @@ func fieldGoType(g *protogen.GeneratedFile, f *fileInfo, field *protogen.Field)
 	return goType, pointer, comparable
 }
 
+// fieldLazy reports whether the field is a proto.Lazy, decoded on first
+// access: singular message fields outside of oneofs with the lazy option.
+func fieldLazy(field *protogen.Field) bool {
+	fd := field.Desc
+	return fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() &&
+		fd.ContainingOneof() == nil && fd.Options().(*descriptorpb.FieldOptions).GetLazy()
+}
+
 func fieldProtobufTagValue(field *protogen.Field) string {
 	fd := field.Desc
 	var tag []string
@@ -427,13 +643,34 @@ func fieldProtobufTagValue(field *protogen.Field) string {
 	case protoreflect.Repeated:
 		tag = append(tag, "rep")
 	}
+	if fieldDebugRedact(field) {
+		tag = append(tag, "redact")
+	}
 	return strings.Join(tag, ",")
 }
 
+// fieldDebugRedact reports whether the field has the debug_redact option.
+func fieldDebugRedact(field *protogen.Field) bool {
+	const debugRedactNumber = 16 // FieldOptions.debug_redact
+	opts, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	if !ok {
+		return false
+	}
+	v, _ := unknownVarint(opts, debugRedactNumber)
+	return v != 0
+}
+
 func fieldDefaultValue(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo, field *protogen.Field) string {
-	if field.Desc.IsList() {
+	if field.Desc.IsList() || field.Desc.IsMap() {
 		return "nil"
 	}
+	if field.Desc.HasDefault() {
+		defVarName := "Default_" + m.GoIdent.GoName + "_" + field.GoName
+		if field.Desc.Kind() == protoreflect.BytesKind {
+			return "append([]byte(nil), " + defVarName + "...)"
+		}
+		return defVarName
+	}
 	switch field.Desc.Kind() {
 	case protoreflect.BoolKind:
 		return "false"
@@ -492,6 +729,30 @@ func genMessageOneofWrapperTypes(g *protogen.GeneratedFile, f *fileInfo, m *mess
 			g.P()
 		}
 	}
+	genMessageOneofWrappers(g, m)
+}
+
+// genMessageOneofWrappers generates the XXX_OneofWrappers method, which the
+// runtime uses to find the fields of the oneofs.
+func genMessageOneofWrappers(g *protogen.GeneratedFile, m *messageInfo) {
+	var fields []*protogen.Field
+	for _, oneof := range m.Oneofs {
+		if !oneof.Desc.IsSynthetic() {
+			fields = append(fields, oneof.Fields...)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	g.P("// XXX_OneofWrappers is for the internal use of the proto package.")
+	g.P("func (*", m.GoIdent, ") XXX_OneofWrappers() []interface{} {")
+	g.P("return []interface{}{")
+	for _, field := range fields {
+		g.P("(*", field.GoIdent, ")(nil),")
+	}
+	g.P("}")
+	g.P("}")
+	g.P()
 }
 
 // oneofInterfaceName returns the name of the interface type implemented by
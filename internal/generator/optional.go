@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The Go representations of the fields with presence, see
+// golite/options.proto.
+const (
+	optionalOption  = "option"  // proto.Option[T]
+	optionalPointer = "pointer" // *T
+	optionalValue   = "value"   // T, without presence
+)
+
+// The numbers of the golite.file_optional and golite.optional options.
+const (
+	fileOptionalNumber  = 50101
+	fieldOptionalNumber = 50101
+)
+
+// optionalStyles maps the values of the golite.OptionalStyle enum.
+var optionalStyles = [...]string{
+	1: optionalOption,
+	2: optionalPointer,
+	3: optionalValue,
+}
+
+// OptionalStyle is the Go representation of the fields with presence, it
+// can be overridden by the golite.file_optional and golite.optional options.
+var OptionalStyle = optionalOption
+
+// SetOptionalStyle sets OptionalStyle, it is the parameter
+// optional=option|pointer|value of protoc-gen-golite.
+func SetOptionalStyle(s string) error {
+	switch s {
+	case optionalOption, optionalPointer, optionalValue:
+		OptionalStyle = s
+		return nil
+	}
+	return fmt.Errorf("invalid optional style %q, want option, pointer or value", s)
+}
+
+// fieldOptionalStyle returns the style of a field with presence.
+func fieldOptionalStyle(f *fileInfo, field *protogen.Field) string {
+	if opts, ok := field.Desc.Options().(*descriptorpb.FieldOptions); ok {
+		if s := optionalStyleOf(opts, fieldOptionalNumber); s != "" {
+			return s
+		}
+	}
+	if opts, ok := f.Desc.Options().(*descriptorpb.FileOptions); ok {
+		if s := optionalStyleOf(opts, fileOptionalNumber); s != "" {
+			return s
+		}
+	}
+	return OptionalStyle
+}
+
+func optionalStyleOf(opts protoreflect.ProtoMessage, num protowire.Number) string {
+	v, ok := unknownVarint(opts, num)
+	if !ok || v >= uint64(len(optionalStyles)) {
+		return ""
+	}
+	return optionalStyles[v]
+}
+
+// unknownVarint returns the last value of the varint field num in the
+// unknown fields of m. The options of protoc-gen-golite and the options
+// newer than the descriptorpb package we depend on are read this way.
+func unknownVarint(m protoreflect.ProtoMessage, num protowire.Number) (v uint64, ok bool) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return 0, false
+	}
+	b := m.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return 0, false
+		}
+		b = b[l:]
+		if n == num && typ == protowire.VarintType {
+			x, l := protowire.ConsumeVarint(b)
+			if l < 0 {
+				return 0, false
+			}
+			v, ok = x, true
+		}
+		l = protowire.ConsumeFieldValue(n, typ, b)
+		if l < 0 {
+			return 0, false
+		}
+		b = b[l:]
+	}
+	return v, ok
+}
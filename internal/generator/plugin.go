@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// RegisterFlags registers the parameters of the generator on flags, they
+// are set by the ParamFunc of protogen.Options. The parameters are reset to
+// their defaults.
+func RegisterFlags(flags *flag.FlagSet) {
+	OptionalStyle = optionalOption
+	flags.BoolVar(&RegisterTypes, "register", false, "register the full names of the messages")
+	flags.BoolVar(&LogValuer, "slog", false, "implement slog.LogValuer, requires Go 1.21")
+	flags.Func("optional", "Go type of the fields with presence: option, pointer or value", SetOptionalStyle)
+}
+
+// Generate generates the files of gen marked to be generated.
+func Generate(gen *protogen.Plugin) error {
+	MapWellKnownTypes(gen)
+	for _, f := range gen.Files {
+		if f.Generate {
+			GenerateFile(gen, f)
+		}
+	}
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	return nil
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	contextPackage = protogen.GoImportPath("context")
+	rpcPackage     = protogen.GoImportPath("github.com/RomiChan/protobuf/rpc")
+)
+
+// genService generates the server interface, the client and the service
+// descriptor of a service. Streaming methods are not supported and skipped.
+func genService(g *protogen.GeneratedFile, service *protogen.Service) {
+	var methods []*protogen.Method
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			g.P("// Streaming method ", service.Desc.FullName(), ".", method.Desc.Name(), " is not supported by golite, skipped.")
+			g.P()
+			continue
+		}
+		methods = append(methods, method)
+	}
+
+	serviceName := service.GoName
+	deprecated := service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated()
+
+	g.P("const (")
+	for _, method := range methods {
+		g.P(fullMethodName(service, method), ` = "/`, service.Desc.FullName(), "/", method.Desc.Name(), `"`)
+	}
+	g.P(")")
+	g.P()
+
+	// Client.
+	clientName := serviceName + "Client"
+	g.P("// ", clientName, " is the client API for ", serviceName, " service.")
+	if deprecated {
+		g.P("//")
+		g.P("// Deprecated: Do not use.")
+	}
+	g.Annotate(clientName, service.Location)
+	g.P("type ", clientName, " interface {")
+	for _, method := range methods {
+		g.Annotate(clientName+"."+method.GoName, method.Location)
+		g.P(methodComments(method), method.GoName, "(ctx ", contextPackage.Ident("Context"), ", in *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error)")
+	}
+	g.P("}")
+	g.P()
+
+	clientImpl := unexport(clientName)
+	g.P("type ", clientImpl, " struct {")
+	g.P("inv ", rpcPackage.Ident("Invoker"))
+	g.P("}")
+	g.P()
+	g.P("// New", clientName, " returns a ", clientName, " calling the methods with inv.")
+	g.P("func New", clientName, "(inv ", rpcPackage.Ident("Invoker"), ") ", clientName, " {")
+	g.P("return &", clientImpl, "{inv}")
+	g.P("}")
+	g.P()
+	for _, method := range methods {
+		g.P("func (c *", clientImpl, ") ", method.GoName, "(ctx ", contextPackage.Ident("Context"), ", in *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error) {")
+		g.P("out := new(", method.Output.GoIdent, ")")
+		g.P("if err := c.inv.Invoke(ctx, ", fullMethodName(service, method), ", in, out); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return out, nil")
+		g.P("}")
+		g.P()
+	}
+
+	// Server.
+	serverName := serviceName + "Server"
+	g.P("// ", serverName, " is the server API for ", serviceName, " service.")
+	if deprecated {
+		g.P("//")
+		g.P("// Deprecated: Do not use.")
+	}
+	g.Annotate(serverName, service.Location)
+	g.P("type ", serverName, " interface {")
+	for _, method := range methods {
+		g.Annotate(serverName+"."+method.GoName, method.Location)
+		g.P(methodComments(method), method.GoName, "(", contextPackage.Ident("Context"), ", *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error)")
+	}
+	g.P("}")
+	g.P()
+
+	// Unimplemented server, embedded by servers to be forward compatible.
+	g.P("// Unimplemented", serverName, " can be embedded to have forward compatible implementations.")
+	g.P("type Unimplemented", serverName, " struct{}")
+	g.P()
+	for _, method := range methods {
+		g.P("func (Unimplemented", serverName, ") ", method.GoName, "(", contextPackage.Ident("Context"), ", *", method.Input.GoIdent, ") (*", method.Output.GoIdent, ", error) {")
+		g.P("return nil, ", rpcPackage.Ident("Errorf"), "(", rpcPackage.Ident("Unimplemented"), `, "method `, method.GoName, ` not implemented")`)
+		g.P("}")
+		g.P()
+	}
+
+	// Service descriptor and handlers.
+	for _, method := range methods {
+		g.P("func ", handlerName(service, method), "(srv interface{}, ctx ", contextPackage.Ident("Context"), ", dec func(interface{}) error) (interface{}, error) {")
+		g.P("in := new(", method.Input.GoIdent, ")")
+		g.P("if err := dec(in); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return srv.(", serverName, ").", method.GoName, "(ctx, in)")
+		g.P("}")
+		g.P()
+	}
+
+	g.P("// ", serviceName, "_ServiceDesc is the ", rpcPackage.Ident("ServiceDesc"), " of ", serviceName, " service.")
+	g.P("var ", serviceName, "_ServiceDesc = ", rpcPackage.Ident("ServiceDesc"), "{")
+	g.P(`ServiceName: "`, service.Desc.FullName(), `",`)
+	g.P("HandlerType: (*", serverName, ")(nil),")
+	g.P("Methods: []", rpcPackage.Ident("MethodDesc"), "{")
+	for _, method := range methods {
+		g.P("{")
+		g.P(`MethodName: "`, method.Desc.Name(), `",`)
+		g.P("Handler: ", handlerName(service, method), ",")
+		g.P("},")
+	}
+	g.P("},")
+	g.P("}")
+	g.P()
+}
+
+func fullMethodName(service *protogen.Service, method *protogen.Method) string {
+	return service.GoName + "_" + method.GoName + "_FullMethodName"
+}
+
+func handlerName(service *protogen.Service, method *protogen.Method) string {
+	return "_" + service.GoName + "_" + method.GoName + "_Handler"
+}
+
+func methodComments(method *protogen.Method) protogen.Comments {
+	return appendDeprecationSuffix(method.Comments.Leading,
+		method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated())
+}
+
+func unexport(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
@@ -0,0 +1,415 @@
+package generator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	regexpPackage = protogen.GoImportPath("regexp")
+	utf8Package   = protogen.GoImportPath("unicode/utf8")
+)
+
+// validateFile is the file declaring the buf.validate constraints, the
+// messages of the files importing it get a Validate method.
+const validateFile = "buf/validate/validate.proto"
+
+// The numbers of buf.validate.field and of the fields of
+// buf.validate.FieldConstraints used by the generator.
+const (
+	validateFieldNumber = 1159
+
+	requiredNumber = 25
+	stringNumber   = 14
+	enumNumber     = 16
+	repeatedNumber = 18
+	mapNumber      = 19
+)
+
+// fieldConstraints is the subset of buf.validate.FieldConstraints supported
+// by the generator.
+type fieldConstraints struct {
+	required bool
+
+	// numeric rules, rulesKind is the number of the rules in
+	// FieldConstraints, from FloatRules (1) to SFixed64Rules (12)
+	rulesKind           protowire.Number
+	constVal            string
+	lt, lte, gt, gte    string
+	minLen, maxLen, len string
+	pattern             string
+	hasPattern          bool
+	definedOnly         bool
+	minItems, maxItems  string
+}
+
+// fileValidates reports whether the messages of f get a Validate method.
+func fileValidates(f *fileInfo) bool {
+	for i, imps := 0, f.Desc.Imports(); i < imps.Len(); i++ {
+		if imps.Get(i).Path() == validateFile {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldConstraintsOf parses the buf.validate.field option of a field, it is
+// read from the unknown fields of the options like the golite options.
+func fieldConstraintsOf(field *protogen.Field) *fieldConstraints {
+	c := new(fieldConstraints)
+	opts, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return c
+	}
+	rangeFields(opts.ProtoReflect().GetUnknown(), func(num protowire.Number, typ protowire.Type, b []byte) {
+		if num != validateFieldNumber || typ != protowire.BytesType {
+			return
+		}
+		rangeFields(bytesValue(b), func(num protowire.Number, typ protowire.Type, b []byte) {
+			switch {
+			case num == requiredNumber:
+				c.required = varintValue(b) != 0
+			case num >= 1 && num <= 12:
+				c.rulesKind = num
+				rangeFields(bytesValue(b), func(num protowire.Number, typ protowire.Type, b []byte) {
+					v := numericLiteral(c.rulesKind, typ, b)
+					switch num {
+					case 1:
+						c.constVal = v
+					case 2:
+						c.lt = v
+					case 3:
+						c.lte = v
+					case 4:
+						c.gt = v
+					case 5:
+						c.gte = v
+					}
+				})
+			case num == stringNumber:
+				rangeFields(bytesValue(b), func(num protowire.Number, typ protowire.Type, b []byte) {
+					switch num {
+					case 19:
+						c.len = strconv.FormatUint(varintValue(b), 10)
+					case 2:
+						c.minLen = strconv.FormatUint(varintValue(b), 10)
+					case 3:
+						c.maxLen = strconv.FormatUint(varintValue(b), 10)
+					case 6:
+						c.pattern, c.hasPattern = string(bytesValue(b)), true
+					}
+				})
+			case num == enumNumber:
+				rangeFields(bytesValue(b), func(num protowire.Number, typ protowire.Type, b []byte) {
+					if num == 2 {
+						c.definedOnly = varintValue(b) != 0
+					}
+				})
+			case num == repeatedNumber || num == mapNumber:
+				// min_items and max_items, or min_pairs and max_pairs
+				rangeFields(bytesValue(b), func(num protowire.Number, typ protowire.Type, b []byte) {
+					switch num {
+					case 1:
+						c.minItems = strconv.FormatUint(varintValue(b), 10)
+					case 2:
+						c.maxItems = strconv.FormatUint(varintValue(b), 10)
+					}
+				})
+			}
+		})
+	})
+	return c
+}
+
+// rangeFields calls fn with the number, type and encoded value of every
+// field in b, it stops at the first malformed field.
+func rangeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return
+		}
+		fn(num, typ, b[:n])
+		b = b[n:]
+	}
+}
+
+func varintValue(b []byte) uint64 {
+	v, _ := protowire.ConsumeVarint(b)
+	return v
+}
+
+func bytesValue(b []byte) []byte {
+	v, _ := protowire.ConsumeBytes(b)
+	return v
+}
+
+// numericLiteral returns the Go literal of a value of the numeric rules
+// rulesKind.
+func numericLiteral(rulesKind protowire.Number, typ protowire.Type, b []byte) string {
+	var raw uint64
+	switch typ {
+	case protowire.VarintType:
+		raw = varintValue(b)
+	case protowire.Fixed32Type:
+		v, _ := protowire.ConsumeFixed32(b)
+		raw = uint64(v)
+	case protowire.Fixed64Type:
+		raw, _ = protowire.ConsumeFixed64(b)
+	default:
+		return ""
+	}
+	switch rulesKind {
+	case 1: // float
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(raw))), 'g', -1, 32)
+	case 2: // double
+		return strconv.FormatFloat(math.Float64frombits(raw), 'g', -1, 64)
+	case 3, 4: // int32, int64
+		return strconv.FormatInt(int64(raw), 10)
+	case 7, 8: // sint32, sint64
+		return strconv.FormatInt(protowire.DecodeZigZag(raw), 10)
+	case 11: // sfixed32
+		return strconv.FormatInt(int64(int32(raw)), 10)
+	case 12: // sfixed64
+		return strconv.FormatInt(int64(raw), 10)
+	}
+	return strconv.FormatUint(raw, 10) // uint32, uint64, fixed32, fixed64
+}
+
+// genMessageValidate generates the Validate method of a message.
+func genMessageValidate(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo) {
+	constraints := make([]*fieldConstraints, len(m.Fields))
+	for i, field := range m.Fields {
+		c := fieldConstraintsOf(field)
+		constraints[i] = c
+		if c.hasPattern {
+			g.P("var ", patternVarName(m, field), " = ", regexpPackage.Ident("MustCompile"), "(", strconv.Quote(c.pattern), ")")
+			g.P()
+		}
+	}
+
+	g.P("// Validate checks the constraints of the fields of x and its nested")
+	g.P("// messages, it returns a *", protoPackage.Ident("ValidationError"), " listing the violations.")
+	g.P("func (x *", m.GoIdent, ") Validate() error {")
+	g.P("if x == nil {")
+	g.P("return nil")
+	g.P("}")
+	g.P("var v ", protoPackage.Ident("Validator"))
+	for i, field := range m.Fields {
+		genFieldValidate(g, f, m, field, constraints[i])
+	}
+	g.P("return v.Err()")
+	g.P("}")
+	g.P()
+}
+
+func patternVarName(m *messageInfo, field *protogen.Field) string {
+	return "_" + m.GoIdent.GoName + "_" + field.GoName + "_pattern"
+}
+
+func genFieldValidate(g *protogen.GeneratedFile, f *fileInfo, m *messageInfo, field *protogen.Field, c *fieldConstraints) {
+	path := strconv.Quote(string(field.Desc.Name()))
+	name := "x." + field.GoName
+	isMessage := field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind
+	oneof := field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+
+	// Repeated fields and maps.
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		if c.required {
+			g.P("if len(", name, ") == 0 {")
+			g.P("v.Addf(", path, `, "value is required")`)
+			g.P("}")
+		}
+		if c.minItems != "" {
+			g.P("if len(", name, ") < ", c.minItems, " {")
+			g.P("v.Addf(", path, `, "value must contain at least %d item(s)", `, c.minItems, ")")
+			g.P("}")
+		}
+		if c.maxItems != "" {
+			g.P("if len(", name, ") > ", c.maxItems, " {")
+			g.P("v.Addf(", path, `, "value must contain no more than %d item(s)", `, c.maxItems, ")")
+			g.P("}")
+		}
+		switch {
+		case field.Desc.IsList() && isMessage:
+			g.P("for i, e := range ", name, " {")
+			g.P("v.NestedElem(", path, ", i, e)")
+			g.P("}")
+		case field.Desc.IsMap() && field.Message.Fields[1].Desc.Kind() == protoreflect.MessageKind:
+			g.P("for k, e := range ", name, " {")
+			g.P("v.NestedElem(", path, ", k, e)")
+			g.P("}")
+		}
+		return
+	}
+
+	// Singular fields, val is the value of the field when it is set.
+	var cond, val string
+	style := ""
+	if _, presence, _ := fieldGoType(g, f, field); presence && !oneof && !isMessage {
+		style = fieldOptionalStyle(f, field)
+	}
+	switch {
+	case oneof:
+		cond = "w, ok := x." + field.Oneof.GoName + ".(*" + g.QualifiedGoIdent(field.GoIdent) + "); ok"
+		val = "w." + field.GoName
+	case isMessage && fieldLazy(field):
+		cond = name + ".IsSome()"
+		val = "x.Get" + field.GoName + "()"
+	case isMessage:
+		cond = name + " != nil"
+		val = name
+	case style == optionalOption:
+		cond = name + ".IsSome()"
+		val = name + ".Unwrap()"
+	case style == optionalPointer:
+		cond = name + " != nil"
+		val = "*" + name
+	}
+
+	if c.required && !oneof {
+		switch {
+		case style == optionalOption:
+			g.P("if ", name, ".IsNone() {")
+		case fieldLazy(field):
+			g.P("if !", cond, " {")
+		case cond != "":
+			g.P("if ", name, " == nil {")
+		case field.Desc.Kind() == protoreflect.BytesKind:
+			g.P("if len(", name, ") == 0 {")
+		default:
+			g.P("if ", name, " == ", fieldZeroValue(field), " {")
+		}
+		g.P("v.Addf(", path, `, "value is required")`)
+		g.P("}")
+	}
+
+	if isMessage {
+		if oneof {
+			g.P("if ", cond, " {")
+			g.P("v.Nested(", path, ", ", val, ")")
+			g.P("}")
+		} else {
+			g.P("v.Nested(", path, ", ", val, ")")
+		}
+		return
+	}
+
+	checks := c.rulesKind != 0 || c.minLen != "" || c.maxLen != "" || c.len != "" || c.hasPattern || c.definedOnly
+	if !checks {
+		return
+	}
+	if cond != "" {
+		g.P("if ", cond, " {")
+		g.P("val := ", val)
+		val = "val"
+	} else {
+		val = name
+	}
+	genNumericChecks(g, path, val, c)
+	genStringChecks(g, m, field, path, val, c)
+	if c.definedOnly && field.Enum != nil {
+		g.P("switch ", val, " {")
+		var numbers []string
+		seen := make(map[protoreflect.EnumNumber]bool)
+		for _, value := range field.Enum.Values {
+			if n := value.Desc.Number(); !seen[n] {
+				seen[n] = true
+				numbers = append(numbers, strconv.Itoa(int(n)))
+			}
+		}
+		g.P("case ", strings.Join(numbers, ", "), ":")
+		g.P("default:")
+		g.P("v.Addf(", path, `, "value must be one of the defined enum values")`)
+		g.P("}")
+	}
+	if cond != "" {
+		g.P("}")
+	}
+}
+
+func genNumericChecks(g *protogen.GeneratedFile, path, val string, c *fieldConstraints) {
+	check := func(lit, op, msg string) {
+		if lit == "" {
+			return
+		}
+		nan := lit == "NaN"
+		lit = floatExpr(g, c.rulesKind, lit)
+		if op == "==" && nan {
+			// NaN is only equal to itself with !=
+			g.P("if !(", val, " != ", val, ") {")
+		} else {
+			g.P("if !(", val, " ", op, " ", lit, ") {")
+		}
+		g.P("v.Addf(", path, `, "value must `, msg, ` %v", `, lit, ")")
+		g.P("}")
+	}
+	check(c.constVal, "==", "equal")
+	check(c.lt, "<", "be less than")
+	check(c.lte, "<=", "be less than or equal to")
+	check(c.gt, ">", "be greater than")
+	check(c.gte, ">=", "be greater than or equal to")
+}
+
+// floatExpr returns the Go expression of the numeric literal lit, the
+// infinities and NaN of the float and double rules have no literal.
+func floatExpr(g *protogen.GeneratedFile, rulesKind protowire.Number, lit string) string {
+	var expr string
+	switch lit {
+	case "+Inf":
+		expr = g.QualifiedGoIdent(mathPackage.Ident("Inf")) + "(1)"
+	case "-Inf":
+		expr = g.QualifiedGoIdent(mathPackage.Ident("Inf")) + "(-1)"
+	case "NaN":
+		expr = g.QualifiedGoIdent(mathPackage.Ident("NaN")) + "()"
+	default:
+		return lit
+	}
+	if rulesKind == 1 { // float
+		expr = "float32(" + expr + ")"
+	}
+	return expr
+}
+
+func genStringChecks(g *protogen.GeneratedFile, m *messageInfo, field *protogen.Field, path, val string, c *fieldConstraints) {
+	if c.len != "" || c.minLen != "" || c.maxLen != "" {
+		count := g.QualifiedGoIdent(utf8Package.Ident("RuneCountInString"))
+		check := func(lit, op, msg string) {
+			if lit == "" {
+				return
+			}
+			g.P("if !(", count, "(", val, ") ", op, " ", lit, ") {")
+			g.P("v.Addf(", path, `, "value length must be `, msg, `%d character(s)", `, lit, ")")
+			g.P("}")
+		}
+		check(c.len, "==", "")
+		check(c.minLen, ">=", "at least ")
+		check(c.maxLen, "<=", "at most ")
+	}
+	if c.hasPattern {
+		g.P("if !", patternVarName(m, field), ".MatchString(", val, ") {")
+		g.P("v.Addf(", path, `, "value does not match regex pattern %q", `, strconv.Quote(c.pattern), ")")
+		g.P("}")
+	}
+}
+
+func fieldZeroValue(field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "false"
+	case protoreflect.StringKind:
+		return `""`
+	}
+	return "0"
+}
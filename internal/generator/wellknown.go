@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// wellKnownTypes maps the well-known types having a golite version to their
+// Go packages.
+var wellKnownTypes = map[string]protogen.GoImportPath{
+	"google/protobuf/any.proto":        "github.com/RomiChan/protobuf/proto/types/known/anypb",
+	"google/protobuf/duration.proto":   "github.com/RomiChan/protobuf/proto/types/known/durationpb",
+	"google/protobuf/empty.proto":      "github.com/RomiChan/protobuf/proto/types/known/emptypb",
+	"google/protobuf/field_mask.proto": "github.com/RomiChan/protobuf/proto/types/known/fieldmaskpb",
+	"google/protobuf/struct.proto":     "github.com/RomiChan/protobuf/proto/types/known/structpb",
+	"google/protobuf/timestamp.proto":  "github.com/RomiChan/protobuf/proto/types/known/timestamppb",
+	"google/protobuf/wrappers.proto":   "github.com/RomiChan/protobuf/proto/types/known/wrapperspb",
+}
+
+// MapWellKnownTypes makes the files of gen reference the golite versions of
+// the well-known types instead of the upstream packages.
+func MapWellKnownTypes(gen *protogen.Plugin) {
+	for path, importPath := range wellKnownTypes {
+		file, ok := gen.FilesByPath[path]
+		if !ok {
+			continue
+		}
+		file.GoImportPath = importPath
+		for _, enum := range file.Enums {
+			mapEnum(enum, importPath)
+		}
+		for _, message := range file.Messages {
+			mapMessage(message, importPath)
+		}
+	}
+}
+
+func mapEnum(enum *protogen.Enum, importPath protogen.GoImportPath) {
+	enum.GoIdent.GoImportPath = importPath
+	for _, value := range enum.Values {
+		value.GoIdent.GoImportPath = importPath
+	}
+}
+
+func mapMessage(message *protogen.Message, importPath protogen.GoImportPath) {
+	message.GoIdent.GoImportPath = importPath
+	for _, field := range message.Fields {
+		field.GoIdent.GoImportPath = importPath
+	}
+	for _, oneof := range message.Oneofs {
+		oneof.GoIdent.GoImportPath = importPath
+	}
+	for _, enum := range message.Enums {
+		mapEnum(enum, importPath)
+	}
+	for _, nested := range message.Messages {
+		mapMessage(nested, importPath)
+	}
+}
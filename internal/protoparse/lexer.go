@@ -0,0 +1,400 @@
+package protoparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenSymbol
+)
+
+// token is a token of a .proto file. The comments around it are attached
+// like protoc does: leading are the comments documenting the declaration
+// starting at the token, trailing are the comments following the previous
+// token.
+type token struct {
+	kind tokenKind
+	text string // the source text, or the value of a string
+	line int    // zero based, like in SourceCodeInfo
+	col  int
+	end  int // the column after the token
+
+	leading        string
+	trailingOfPrev string
+}
+
+// Error is a syntax or semantic error in a .proto file.
+type Error struct {
+	File      string
+	Line, Col int // one based
+	Msg       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Col, e.Msg)
+}
+
+type lexer struct {
+	file string
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+// tokenize splits src into tokens, the last token is tokenEOF.
+func tokenize(file, src string) ([]token, error) {
+	l := &lexer{file: file, src: strings.TrimPrefix(src, "\uFEFF")}
+	var toks []token
+	first := true
+	for {
+		trailing, leading, err := l.comments(first)
+		if err != nil {
+			return nil, err
+		}
+		first = false
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tok.leading = leading
+		tok.trailingOfPrev = trailing
+		toks = append(toks, tok)
+		if tok.kind == tokenEOF {
+			return toks, nil
+		}
+	}
+}
+
+func (l *lexer) errorf(format string, a ...interface{}) error {
+	return &Error{File: l.file, Line: l.line + 1, Col: l.col + 1, Msg: fmt.Sprintf(format, a...)}
+}
+
+func (l *lexer) peek(off int) byte {
+	if l.pos+off < len(l.src) {
+		return l.src[l.pos+off]
+	}
+	return 0
+}
+
+func (l *lexer) advance() {
+	if l.src[l.pos] == '\n' {
+		l.line++
+		l.col = 0
+	} else {
+		l.col++
+	}
+	l.pos++
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ', '\t', '\r', '\v', '\f':
+			l.advance()
+		default:
+			return
+		}
+	}
+}
+
+// lineComment consumes a // comment, it returns its text after the slashes
+// with the newline.
+func (l *lexer) lineComment() string {
+	l.advance()
+	l.advance()
+	start := l.pos
+	for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+		l.advance()
+	}
+	text := l.src[start:l.pos]
+	if l.pos < len(l.src) {
+		l.advance()
+	}
+	return text + "\n"
+}
+
+// blockComment consumes a /* */ comment, the leading stars of its lines are
+// removed.
+func (l *lexer) blockComment() (string, error) {
+	l.advance()
+	l.advance()
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return "", l.errorf("unterminated block comment")
+		}
+		if l.peek(0) == '*' && l.peek(1) == '/' {
+			l.advance()
+			l.advance()
+			return b.String(), nil
+		}
+		c := l.src[l.pos]
+		b.WriteByte(c)
+		l.advance()
+		if c == '\n' {
+			l.skipSpaces()
+			if l.peek(0) == '*' && l.peek(1) != '/' {
+				l.advance()
+			}
+		}
+	}
+}
+
+// commentCollector groups the comments between two tokens, see
+// io::Tokenizer::NextWithComments of protoc.
+type commentCollector struct {
+	trailing string
+
+	buf        strings.Builder
+	hasComment bool
+	isLine     bool
+	canAttach  bool
+}
+
+func (c *commentCollector) lineBuffer() *strings.Builder {
+	if c.hasComment && !c.isLine {
+		c.flush()
+	}
+	c.hasComment, c.isLine = true, true
+	return &c.buf
+}
+
+func (c *commentCollector) blockBuffer() *strings.Builder {
+	if c.hasComment {
+		c.flush()
+	}
+	c.hasComment, c.isLine = true, false
+	return &c.buf
+}
+
+func (c *commentCollector) flush() {
+	if c.hasComment {
+		if c.canAttach {
+			c.trailing = c.buf.String()
+			c.canAttach = false
+		}
+		// detached comments are dropped
+		c.buf.Reset()
+		c.hasComment = false
+	}
+}
+
+// comments consumes the spaces and comments before the next token, it
+// returns the trailing comment of the previous token and the leading comment
+// of the next one.
+func (l *lexer) comments(start bool) (trailing, leading string, err error) {
+	c := &commentCollector{canAttach: !start}
+	if !start {
+		l.skipSpaces()
+		switch {
+		case l.peek(0) == '/' && l.peek(1) == '/':
+			c.lineBuffer().WriteString(l.lineComment())
+			c.flush()
+		case l.peek(0) == '/' && l.peek(1) == '*':
+			text, err := l.blockComment()
+			if err != nil {
+				return "", "", err
+			}
+			c.blockBuffer().WriteString(text)
+			l.skipSpaces()
+			if l.peek(0) != '\n' {
+				// the next token is on the same line
+				return "", "", nil
+			}
+			l.advance()
+			c.flush()
+		default:
+			if l.peek(0) != '\n' {
+				return "", "", nil
+			}
+			l.advance()
+		}
+	}
+	for {
+		l.skipSpaces()
+		switch {
+		case l.peek(0) == '/' && l.peek(1) == '/':
+			c.lineBuffer().WriteString(l.lineComment())
+		case l.peek(0) == '/' && l.peek(1) == '*':
+			text, err := l.blockComment()
+			if err != nil {
+				return "", "", err
+			}
+			c.blockBuffer().WriteString(text)
+			l.skipSpaces()
+			if l.peek(0) == '\n' {
+				l.advance()
+			}
+		case l.peek(0) == '\n':
+			// blank line
+			l.advance()
+			c.flush()
+			c.canAttach = false
+		default:
+			if l.pos >= len(l.src) || strings.IndexByte("}])", l.src[l.pos]) >= 0 {
+				// at the end of a scope the comments don't document the
+				// next token
+				c.flush()
+			}
+			if c.hasComment {
+				leading = c.buf.String()
+			}
+			return c.trailing, leading, nil
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	tok := token{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		tok.end = l.col
+		return tok, nil
+	}
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case isLetter(c):
+		for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance()
+		}
+		tok.kind = tokenIdent
+		tok.text = l.src[start:l.pos]
+	case isDigit(c) || c == '.' && isDigit(l.peek(1)):
+		tok.kind = tokenInt
+		hex := c == '0' && (l.peek(1) == 'x' || l.peek(1) == 'X')
+		for l.pos < len(l.src) {
+			c := l.src[l.pos]
+			if c == '.' || !hex && (c == 'e' || c == 'E') {
+				tok.kind = tokenFloat
+			}
+			if (c == '-' || c == '+') && !hex && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E') {
+				l.advance()
+				continue
+			}
+			if !isLetter(c) && !isDigit(c) && c != '.' {
+				break
+			}
+			l.advance()
+		}
+		tok.text = l.src[start:l.pos]
+	case c == '"' || c == '\'':
+		s, err := l.stringLit()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = tokenString
+		tok.text = s
+	default:
+		tok.kind = tokenSymbol
+		tok.text = string(c)
+		l.advance()
+	}
+	tok.end = l.col
+	return tok, nil
+}
+
+// stringLit consumes a quoted string and returns its value.
+func (l *lexer) stringLit() (string, error) {
+	quote := l.src[l.pos]
+	l.advance()
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return "", l.errorf("unterminated string")
+		}
+		c := l.src[l.pos]
+		l.advance()
+		if c == quote {
+			return b.String(), nil
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		if l.pos >= len(l.src) {
+			return "", l.errorf("unterminated string")
+		}
+		c = l.src[l.pos]
+		l.advance()
+		switch c {
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '\\', '\'', '"', '?':
+			b.WriteByte(c)
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			v := int(c - '0')
+			for i := 0; i < 2 && l.peek(0) >= '0' && l.peek(0) <= '7'; i++ {
+				v = v*8 + int(l.src[l.pos]-'0')
+				l.advance()
+			}
+			if v > 0xff {
+				return "", l.errorf("octal escape out of range")
+			}
+			b.WriteByte(byte(v))
+		case 'x', 'X':
+			n := l.digits(2, 16)
+			if n == "" {
+				return "", l.errorf("invalid hex escape")
+			}
+			v, _ := strconv.ParseUint(n, 16, 8)
+			b.WriteByte(byte(v))
+		case 'u', 'U':
+			size := 4
+			if c == 'U' {
+				size = 8
+			}
+			n := l.digits(size, 16)
+			v, err := strconv.ParseUint(n, 16, 32)
+			if len(n) != size || err != nil || !utf8.ValidRune(rune(v)) {
+				return "", l.errorf("invalid unicode escape")
+			}
+			b.WriteRune(rune(v))
+		default:
+			return "", l.errorf("invalid escape \\%c", c)
+		}
+	}
+}
+
+// digits consumes up to max digits of the base.
+func (l *lexer) digits(max, base int) string {
+	start := l.pos
+	for i := 0; i < max && l.pos < len(l.src); i++ {
+		c := l.src[l.pos]
+		if _, err := strconv.ParseUint(string(c), base, 8); err != nil {
+			break
+		}
+		l.advance()
+	}
+	return l.src[start:l.pos]
+}
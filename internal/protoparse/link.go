@@ -0,0 +1,514 @@
+package protoparse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type symbolKind int
+
+const (
+	symbolPackage symbolKind = iota + 1
+	symbolMessage
+	symbolEnum
+	symbolEnumValue
+	symbolField
+	symbolExtension
+	symbolOneof
+	symbolService
+	symbolMethod
+)
+
+// linker resolves the names used in the parsed files.
+type linker struct {
+	symbols map[string]symbolKind
+
+	// files and extensions are set once the files are linked, to apply
+	// the custom options.
+	files      *protoregistry.Files
+	extensions map[protoreflect.FullName]protoreflect.ExtensionType
+}
+
+func newLinker() *linker {
+	return &linker{
+		symbols:    make(map[string]symbolKind),
+		extensions: make(map[protoreflect.FullName]protoreflect.ExtensionType),
+	}
+}
+
+func (l *linker) addFile(fd *descriptorpb.FileDescriptorProto) {
+	pkg := fd.GetPackage()
+	if pkg != "" {
+		parts := strings.Split(pkg, ".")
+		for i := range parts {
+			name := strings.Join(parts[:i+1], ".")
+			if _, ok := l.symbols[name]; !ok {
+				l.symbols[name] = symbolPackage
+			}
+		}
+	}
+	for _, m := range fd.MessageType {
+		l.addMessage(pkg, m)
+	}
+	for _, e := range fd.EnumType {
+		l.addEnum(pkg, e)
+	}
+	for _, x := range fd.Extension {
+		l.symbols[join(pkg, x.GetName())] = symbolExtension
+	}
+	for _, s := range fd.Service {
+		name := join(pkg, s.GetName())
+		l.symbols[name] = symbolService
+		for _, m := range s.Method {
+			l.symbols[join(name, m.GetName())] = symbolMethod
+		}
+	}
+}
+
+func (l *linker) addMessage(scope string, m *descriptorpb.DescriptorProto) {
+	name := join(scope, m.GetName())
+	l.symbols[name] = symbolMessage
+	for _, f := range m.Field {
+		l.symbols[join(name, f.GetName())] = symbolField
+	}
+	for _, o := range m.OneofDecl {
+		l.symbols[join(name, o.GetName())] = symbolOneof
+	}
+	for _, x := range m.Extension {
+		l.symbols[join(name, x.GetName())] = symbolExtension
+	}
+	for _, n := range m.NestedType {
+		l.addMessage(name, n)
+	}
+	for _, e := range m.EnumType {
+		l.addEnum(name, e)
+	}
+}
+
+func (l *linker) addEnum(scope string, e *descriptorpb.EnumDescriptorProto) {
+	l.symbols[join(scope, e.GetName())] = symbolEnum
+	// enum values are siblings of their enum
+	for _, v := range e.Value {
+		l.symbols[join(scope, v.GetName())] = symbolEnumValue
+	}
+}
+
+func isType(kind symbolKind) bool {
+	return kind == symbolMessage || kind == symbolEnum
+}
+
+// resolve looks up name from scope with the scoping rules of protoc, the
+// innermost scope first. If onlyTypes, the symbols which are not types are
+// skipped.
+func (l *linker) resolve(scope, name string, onlyTypes bool) (string, symbolKind, bool) {
+	if strings.HasPrefix(name, ".") {
+		kind, ok := l.symbols[name[1:]]
+		return name[1:], kind, ok
+	}
+	first := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		first = name[:i]
+	}
+	for {
+		candidate := join(scope, first)
+		if kind, ok := l.symbols[candidate]; ok {
+			if first != name {
+				// only the first part is found, look up the rest in it
+				if kind == symbolPackage || kind == symbolMessage || kind == symbolEnum {
+					full := join(scope, name)
+					kind, ok := l.symbols[full]
+					return full, kind, ok
+				}
+			} else if !onlyTypes || isType(kind) {
+				return candidate, kind, true
+			}
+		}
+		if scope == "" {
+			return "", 0, false
+		}
+		if i := strings.LastIndexByte(scope, '.'); i >= 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+func (l *linker) resolveRefs(file string, pf *parsedFile) error {
+	for _, ref := range pf.refs {
+		full, kind, ok := l.resolve(ref.scope, ref.name, true)
+		if !ok {
+			return errorAt(file, ref.tok, "%q is not defined", ref.name)
+		}
+		if err := ref.set(full, kind); err != nil {
+			return errorAt(file, ref.tok, "%v", err)
+		}
+	}
+	for f, v := range pf.defaults {
+		s, err := defaultValue(f, v)
+		if err != nil {
+			return errorAt(file, v.tok, "%v", err)
+		}
+		f.DefaultValue = proto.String(s)
+	}
+	return nil
+}
+
+func errorAt(file string, tok token, format string, a ...interface{}) error {
+	return &Error{File: file, Line: tok.line + 1, Col: tok.col + 1, Msg: fmt.Sprintf(format, a...)}
+}
+
+// defaultValue returns the default value of the field f as stored in its
+// descriptor, normalized like protoc does.
+func defaultValue(f *descriptorpb.FieldDescriptorProto, v optionValue) (string, error) {
+	if f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return "", fmt.Errorf("repeated fields can't have default values")
+	}
+	if v.isAggr {
+		return "", fmt.Errorf("invalid default value")
+	}
+	switch f.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return "", fmt.Errorf("messages can't have default values")
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		if v.kind != tokenString {
+			return "", fmt.Errorf("expected string")
+		}
+		return v.text, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		if v.kind != tokenString {
+			return "", fmt.Errorf("expected string")
+		}
+		return cEscape(v.text), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		if v.kind != tokenIdent || v.text != "true" && v.text != "false" {
+			return "", fmt.Errorf("expected \"true\" or \"false\"")
+		}
+		return v.text, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		if v.kind != tokenIdent || v.negative {
+			return "", fmt.Errorf("expected enum identifier")
+		}
+		return v.text, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		x, err := floatValue(v)
+		if err != nil {
+			return "", err
+		}
+		return simpleDtoa(x), nil
+	}
+	x, err := intValue(v, kindOf(f.GetType()))
+	if err != nil {
+		return "", err
+	}
+	return x.String(), nil
+}
+
+func kindOf(t descriptorpb.FieldDescriptorProto_Type) protoreflect.Kind {
+	return protoreflect.Kind(t)
+}
+
+// intValue converts v to a value of the integer kind.
+func intValue(v optionValue, kind protoreflect.Kind) (protoreflect.Value, error) {
+	if v.kind != tokenInt {
+		return protoreflect.Value{}, fmt.Errorf("expected integer")
+	}
+	u, err := parseUint(v.text)
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("integer out of range")
+	}
+	var min, max int64 = math.MinInt32, math.MaxInt32
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		min, max = math.MinInt64, math.MaxInt64
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if v.negative || u > math.MaxUint32 {
+			return protoreflect.Value{}, fmt.Errorf("integer out of range")
+		}
+		return protoreflect.ValueOfUint32(uint32(u)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if v.negative {
+			return protoreflect.Value{}, fmt.Errorf("integer out of range")
+		}
+		return protoreflect.ValueOfUint64(u), nil
+	}
+	var i int64
+	switch {
+	case !v.negative && u <= uint64(max):
+		i = int64(u)
+	case v.negative && u <= uint64(-(min+1))+1:
+		i = -int64(u-1) - 1
+	default:
+		return protoreflect.Value{}, fmt.Errorf("integer out of range")
+	}
+	if max == math.MaxInt32 {
+		return protoreflect.ValueOfInt32(int32(i)), nil
+	}
+	return protoreflect.ValueOfInt64(i), nil
+}
+
+func floatValue(v optionValue) (float64, error) {
+	var x float64
+	switch v.kind {
+	case tokenIdent:
+		switch v.text {
+		case "inf":
+			x = math.Inf(1)
+		case "nan":
+			x = math.NaN()
+		default:
+			return 0, fmt.Errorf("expected number")
+		}
+	case tokenInt:
+		u, err := parseUint(v.text)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", v.text)
+		}
+		x = float64(u)
+	case tokenFloat:
+		f, err := strconv.ParseFloat(v.text, 64)
+		if err != nil && !strings.Contains(err.Error(), "range") {
+			return 0, fmt.Errorf("invalid number %q", v.text)
+		}
+		x = f
+	default:
+		return 0, fmt.Errorf("expected number")
+	}
+	if v.negative {
+		x = -x
+	}
+	return x, nil
+}
+
+// simpleDtoa formats x like SimpleDtoa of protoc, with 15 digits if they
+// are enough to round trip.
+func simpleDtoa(x float64) string {
+	switch {
+	case math.IsInf(x, 1):
+		return "inf"
+	case math.IsInf(x, -1):
+		return "-inf"
+	case math.IsNaN(x):
+		return "nan"
+	}
+	s := strconv.FormatFloat(x, 'g', 15, 64)
+	if y, _ := strconv.ParseFloat(s, 64); y != x {
+		s = strconv.FormatFloat(x, 'g', 17, 64)
+	}
+	return s
+}
+
+// cEscape escapes the bytes default values like CEscape of protoc.
+func cEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// extensionType returns the extension named full, for the custom options.
+func (l *linker) extensionType(full string) (protoreflect.ExtensionType, error) {
+	if xt, ok := l.extensions[protoreflect.FullName(full)]; ok {
+		return xt, nil
+	}
+	d, err := l.files.FindDescriptorByName(protoreflect.FullName(full))
+	if err != nil {
+		return nil, err
+	}
+	xd, ok := d.(protoreflect.ExtensionDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an extension", full)
+	}
+	xt := dynamicpb.NewExtensionType(xd)
+	l.extensions[xd.FullName()] = xt
+	return xt, nil
+}
+
+// applyOption sets the option opt on its target. The linker l resolves the
+// custom options, it is nil for the standard options.
+func applyOption(file string, opt *pendingOption, l *linker) error {
+	m := opt.target()
+	for i, part := range opt.name {
+		var fd protoreflect.FieldDescriptor
+		if part.extension {
+			full, kind, ok := l.resolve(opt.scope, part.name, false)
+			if !ok || kind != symbolExtension {
+				return errorAt(file, opt.value.tok, "option \"(%s)\" unknown", part.name)
+			}
+			xt, err := l.extensionType(full)
+			if err != nil {
+				return errorAt(file, opt.value.tok, "%v", err)
+			}
+			xd := xt.TypeDescriptor()
+			if xd.ContainingMessage().FullName() != m.Descriptor().FullName() {
+				return errorAt(file, opt.value.tok, "option \"(%s)\" extends %s, not %s", part.name,
+					xd.ContainingMessage().FullName(), m.Descriptor().FullName())
+			}
+			if !m.Descriptor().ExtensionRanges().Has(xd.Number()) {
+				return errorAt(file, opt.value.tok, "option \"(%s)\" is not in an extension range of %s", part.name, m.Descriptor().FullName())
+			}
+			fd = xd
+		} else {
+			fd = m.Descriptor().Fields().ByName(protoreflect.Name(part.name))
+			if fd == nil && len(opt.name) == 1 {
+				if num, ok := newerOptions[m.Descriptor().FullName()][part.name]; ok {
+					if err := setUnknownBool(m, num, opt.value); err != nil {
+						return errorAt(file, opt.value.tok, "option %q: %v", part.name, err)
+					}
+					return nil
+				}
+			}
+			if fd == nil {
+				return errorAt(file, opt.value.tok, "option %q unknown", part.name)
+			}
+		}
+		if i == len(opt.name)-1 {
+			if err := setOption(m, fd, opt.value); err != nil {
+				return errorAt(file, opt.value.tok, "option %q: %v", part.name, err)
+			}
+			return nil
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return errorAt(file, opt.value.tok, "option %q is not a message", part.name)
+		}
+		m = m.Mutable(fd).Message()
+	}
+	return nil
+}
+
+// newerOptions are the bool options newer than the descriptorpb package we
+// depend on, they are set as unknown fields like the custom options.
+var newerOptions = map[protoreflect.FullName]map[string]protowire.Number{
+	"google.protobuf.MessageOptions": {
+		"deprecated_legacy_json_field_conflicts": 11,
+	},
+	"google.protobuf.FieldOptions": {
+		"debug_redact": 16,
+	},
+	"google.protobuf.EnumOptions": {
+		"deprecated_legacy_json_field_conflicts": 6,
+	},
+	"google.protobuf.EnumValueOptions": {
+		"debug_redact": 3,
+	},
+}
+
+func setUnknownBool(m protoreflect.Message, num protowire.Number, v optionValue) error {
+	if v.kind != tokenIdent || v.text != "true" && v.text != "false" {
+		return fmt.Errorf("expected \"true\" or \"false\"")
+	}
+	b := protowire.AppendTag(m.GetUnknown(), num, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeBool(v.text == "true"))
+	m.SetUnknown(b)
+	return nil
+}
+
+// setOption sets the field fd of m to v, or appends v to a repeated field.
+func setOption(m protoreflect.Message, fd protoreflect.FieldDescriptor, v optionValue) error {
+	if fd.IsMap() {
+		return fmt.Errorf("map options are not supported")
+	}
+	if fd.Message() != nil {
+		if !v.isAggr {
+			return fmt.Errorf("expected aggregate value")
+		}
+		var dst protoreflect.Message
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			elem := list.NewElement()
+			list.Append(elem)
+			dst = elem.Message()
+		} else {
+			dst = m.Mutable(fd).Message()
+		}
+		tmp := dst.New()
+		if err := prototext.Unmarshal([]byte(v.aggregate), tmp.Interface()); err != nil {
+			return err
+		}
+		proto.Merge(dst.Interface(), tmp.Interface())
+		return nil
+	}
+	if v.isAggr {
+		return fmt.Errorf("unexpected aggregate value")
+	}
+	var val protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.kind != tokenIdent || v.text != "true" && v.text != "false" {
+			return fmt.Errorf("expected \"true\" or \"false\"")
+		}
+		val = protoreflect.ValueOfBool(v.text == "true")
+	case protoreflect.EnumKind:
+		if v.kind != tokenIdent || v.negative {
+			return fmt.Errorf("expected enum identifier")
+		}
+		ev := fd.Enum().Values().ByName(protoreflect.Name(v.text))
+		if ev == nil {
+			return fmt.Errorf("enum %s has no value named %q", fd.Enum().FullName(), v.text)
+		}
+		val = protoreflect.ValueOfEnum(ev.Number())
+	case protoreflect.StringKind:
+		if v.kind != tokenString {
+			return fmt.Errorf("expected string")
+		}
+		val = protoreflect.ValueOfString(v.text)
+	case protoreflect.BytesKind:
+		if v.kind != tokenString {
+			return fmt.Errorf("expected string")
+		}
+		val = protoreflect.ValueOfBytes([]byte(v.text))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		x, err := floatValue(v)
+		if err != nil {
+			return err
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			val = protoreflect.ValueOfFloat32(float32(x))
+		} else {
+			val = protoreflect.ValueOfFloat64(x)
+		}
+	default:
+		x, err := intValue(v, fd.Kind())
+		if err != nil {
+			return err
+		}
+		val = x
+	}
+	if fd.IsList() {
+		m.Mutable(fd).List().Append(val)
+	} else {
+		m.Set(fd, val)
+	}
+	return nil
+}
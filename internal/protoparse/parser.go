@@ -0,0 +1,1350 @@
+package protoparse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The field numbers of the descriptor messages, used in the paths of the
+// source locations.
+const (
+	fileMessageTypeTag = 4
+	fileEnumTypeTag    = 5
+	fileServiceTag     = 6
+	fileExtensionTag   = 7
+
+	messageFieldTag      = 2
+	messageNestedTypeTag = 3
+	messageEnumTypeTag   = 4
+	messageExtensionTag  = 6
+	messageOneofDeclTag  = 8
+
+	enumValueTag     = 2
+	serviceMethodTag = 2
+)
+
+const (
+	maxFieldNumber       = 1<<29 - 1
+	maxMessageSetNumber  = math.MaxInt32 - 1
+	maxEnumNumber        = math.MaxInt32
+	extensionRangeMaxTag = "max"
+)
+
+var scalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+var labels = map[string]descriptorpb.FieldDescriptorProto_Label{
+	"optional": descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL,
+	"required": descriptorpb.FieldDescriptorProto_LABEL_REQUIRED,
+	"repeated": descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+}
+
+// optionName is a part of the name of an option, like (golite.optional).
+type optionName struct {
+	name      string
+	extension bool
+}
+
+// optionValue is the value of an option, an aggregate value is kept as
+// text format.
+type optionValue struct {
+	tok      token
+	kind     tokenKind
+	text     string
+	negative bool
+	// aggregate is the text of a {...} value
+	aggregate string
+	isAggr    bool
+}
+
+// pendingOption is a custom option, applied when the extensions are
+// resolved.
+type pendingOption struct {
+	target func() protoreflect.Message
+	scope  string
+	name   []optionName
+	value  optionValue
+}
+
+// typeRef is a reference to a message or enum type, resolved when all the
+// files are parsed.
+type typeRef struct {
+	scope string
+	name  string
+	tok   token
+	set   func(fullName string, kind symbolKind) error
+}
+
+// parsedFile is a file parsed by the parser, with the references still to
+// be resolved.
+type parsedFile struct {
+	fd       *descriptorpb.FileDescriptorProto
+	refs     []*typeRef
+	options  []*pendingOption
+	defaults map[*descriptorpb.FieldDescriptorProto]optionValue
+}
+
+type parser struct {
+	file string
+	toks []token
+	pos  int
+
+	proto3 bool
+	pkg    string
+
+	pf   *parsedFile
+	locs []*descriptorpb.SourceCodeInfo_Location
+}
+
+// parse parses a .proto file.
+func parse(file, src string) (*parsedFile, error) {
+	toks, err := tokenize(file, src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		file: file,
+		toks: toks,
+		pf: &parsedFile{
+			fd:       &descriptorpb.FileDescriptorProto{Name: proto.String(file)},
+			defaults: make(map[*descriptorpb.FieldDescriptorProto]optionValue),
+		},
+	}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	if len(p.locs) > 0 {
+		p.pf.fd.SourceCodeInfo = &descriptorpb.SourceCodeInfo{Location: p.locs}
+	}
+	return p.pf, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.toks[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, a ...interface{}) error {
+	return &Error{File: p.file, Line: tok.line + 1, Col: tok.col + 1, Msg: fmt.Sprintf(format, a...)}
+}
+
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of file"
+	case tokenString:
+		return strconv.Quote(tok.text)
+	}
+	return "\"" + tok.text + "\""
+}
+
+// lookingAt reports whether the next token is the symbol or keyword text.
+func (p *parser) lookingAt(text string) bool {
+	tok := p.peek()
+	return (tok.kind == tokenSymbol || tok.kind == tokenIdent) && tok.text == text
+}
+
+func (p *parser) tryConsume(text string) bool {
+	if p.lookingAt(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) (token, error) {
+	if !p.lookingAt(text) {
+		return token{}, p.errorf(p.peek(), "expected %q, found %s", text, describe(p.peek()))
+	}
+	return p.next(), nil
+}
+
+func (p *parser) ident() (token, error) {
+	tok := p.peek()
+	if tok.kind != tokenIdent {
+		return tok, p.errorf(tok, "expected identifier, found %s", describe(tok))
+	}
+	return p.next(), nil
+}
+
+// fullIdent parses a dotted name, with a leading dot if allowLeadingDot.
+func (p *parser) fullIdent(allowLeadingDot bool) (string, token, error) {
+	first := p.peek()
+	var b strings.Builder
+	if allowLeadingDot && p.tryConsume(".") {
+		b.WriteByte('.')
+	}
+	for {
+		tok, err := p.ident()
+		if err != nil {
+			return "", first, err
+		}
+		b.WriteString(tok.text)
+		if !p.lookingAt(".") {
+			return b.String(), first, nil
+		}
+		p.next()
+		b.WriteByte('.')
+	}
+}
+
+func (p *parser) stringLit() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokenString {
+		return "", p.errorf(tok, "expected string, found %s", describe(tok))
+	}
+	var b strings.Builder
+	for p.peek().kind == tokenString {
+		b.WriteString(p.next().text)
+	}
+	return b.String(), nil
+}
+
+// intLit parses a possibly negative integer.
+func (p *parser) intLit(allowNegative bool) (int64, token, error) {
+	tok := p.peek()
+	negative := allowNegative && p.tryConsume("-")
+	num := p.peek()
+	if num.kind != tokenInt {
+		return 0, tok, p.errorf(num, "expected integer, found %s", describe(num))
+	}
+	p.next()
+	v, err := parseUint(num.text)
+	if err != nil || v > math.MaxInt64 {
+		return 0, tok, p.errorf(num, "integer out of range")
+	}
+	if negative {
+		return -int64(v), tok, nil
+	}
+	return int64(v), tok, nil
+}
+
+func parseUint(s string) (uint64, error) {
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		return strconv.ParseUint(s[2:], 16, 64)
+	case len(s) > 1 && s[0] == '0':
+		return strconv.ParseUint(s[1:], 8, 64)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func (p *parser) endStatement() (int, error) {
+	if _, err := p.expect(";"); err != nil {
+		return 0, err
+	}
+	return p.pos - 1, nil
+}
+
+// addLocation records the location of a declaration from the token first
+// to the previous token, with the comments before first and after the token
+// at index end, the end of the declaration. The location has no comments if
+// end is negative.
+func (p *parser) addLocation(path []int32, first int, end int) {
+	start, last := p.toks[first], p.toks[p.pos-1]
+	span := []int32{int32(start.line), int32(start.col), int32(last.line), int32(last.end)}
+	if start.line == last.line {
+		span = []int32{int32(start.line), int32(start.col), int32(last.end)}
+	}
+	loc := &descriptorpb.SourceCodeInfo_Location{
+		Path: append([]int32(nil), path...),
+		Span: span,
+	}
+	if end < 0 {
+		p.locs = append(p.locs, loc)
+		return
+	}
+	if start.leading != "" {
+		loc.LeadingComments = proto.String(start.leading)
+	}
+	if trailing := p.toks[end+1].trailingOfPrev; trailing != "" {
+		loc.TrailingComments = proto.String(trailing)
+	}
+	p.locs = append(p.locs, loc)
+}
+
+func appendPath(path []int32, elems ...int32) []int32 {
+	return append(append([]int32(nil), path...), elems...)
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (p *parser) parseFile() error {
+	fd := p.pf.fd
+	if p.lookingAt("syntax") {
+		p.next()
+		if _, err := p.expect("="); err != nil {
+			return err
+		}
+		tok := p.peek()
+		syntax, err := p.stringLit()
+		if err != nil {
+			return err
+		}
+		switch syntax {
+		case "proto2":
+		case "proto3":
+			p.proto3 = true
+			fd.Syntax = proto.String(syntax)
+		default:
+			return p.errorf(tok, "unsupported syntax %q", syntax)
+		}
+		if _, err := p.endStatement(); err != nil {
+			return err
+		}
+	}
+	for p.peek().kind != tokenEOF {
+		tok := p.peek()
+		switch {
+		case p.tryConsume(";"):
+		case p.lookingAt("import"):
+			if err := p.parseImport(); err != nil {
+				return err
+			}
+		case p.lookingAt("package"):
+			p.next()
+			if fd.Package != nil {
+				return p.errorf(tok, "multiple package statements")
+			}
+			pkg, _, err := p.fullIdent(false)
+			if err != nil {
+				return err
+			}
+			p.pkg = pkg
+			fd.Package = proto.String(pkg)
+			if _, err := p.endStatement(); err != nil {
+				return err
+			}
+		case p.lookingAt("option"):
+			target := func() protoreflect.Message {
+				if fd.Options == nil {
+					fd.Options = new(descriptorpb.FileOptions)
+				}
+				return fd.Options.ProtoReflect()
+			}
+			if err := p.parseOption(target, p.pkg); err != nil {
+				return err
+			}
+		case p.lookingAt("message"):
+			path := []int32{fileMessageTypeTag, int32(len(fd.MessageType))}
+			m, err := p.parseMessage(path, p.pkg)
+			if err != nil {
+				return err
+			}
+			fd.MessageType = append(fd.MessageType, m)
+		case p.lookingAt("enum"):
+			path := []int32{fileEnumTypeTag, int32(len(fd.EnumType))}
+			e, err := p.parseEnum(path, p.pkg)
+			if err != nil {
+				return err
+			}
+			fd.EnumType = append(fd.EnumType, e)
+		case p.lookingAt("extend"):
+			if err := p.parseExtend(&fd.Extension, []int32{fileExtensionTag}, &fd.MessageType, []int32{fileMessageTypeTag}, p.pkg); err != nil {
+				return err
+			}
+		case p.lookingAt("service"):
+			path := []int32{fileServiceTag, int32(len(fd.Service))}
+			s, err := p.parseService(path)
+			if err != nil {
+				return err
+			}
+			fd.Service = append(fd.Service, s)
+		default:
+			return p.errorf(tok, "unexpected %s", describe(tok))
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseImport() error {
+	fd := p.pf.fd
+	p.next()
+	index := int32(len(fd.Dependency))
+	switch {
+	case p.tryConsume("public"):
+		fd.PublicDependency = append(fd.PublicDependency, index)
+	case p.tryConsume("weak"):
+		fd.WeakDependency = append(fd.WeakDependency, index)
+	}
+	name, err := p.stringLit()
+	if err != nil {
+		return err
+	}
+	fd.Dependency = append(fd.Dependency, name)
+	_, err = p.endStatement()
+	return err
+}
+
+// parseOption parses an option statement, the options are set on the
+// message returned by target.
+func (p *parser) parseOption(target func() protoreflect.Message, scope string) error {
+	p.next()
+	if err := p.parseOptionAssignment(target, scope, nil); err != nil {
+		return err
+	}
+	_, err := p.endStatement()
+	return err
+}
+
+// parseOptionAssignment parses name = value. The pseudo options of fields
+// are passed to field.
+func (p *parser) parseOptionAssignment(target func() protoreflect.Message, scope string, field *descriptorpb.FieldDescriptorProto) error {
+	nameTok := p.peek()
+	var name []optionName
+	for {
+		if p.tryConsume("(") {
+			n, _, err := p.fullIdent(true)
+			if err != nil {
+				return err
+			}
+			if _, err := p.expect(")"); err != nil {
+				return err
+			}
+			name = append(name, optionName{name: n, extension: true})
+		} else {
+			tok, err := p.ident()
+			if err != nil {
+				return err
+			}
+			name = append(name, optionName{name: tok.text})
+		}
+		if !p.tryConsume(".") {
+			break
+		}
+	}
+	if _, err := p.expect("="); err != nil {
+		return err
+	}
+	value, err := p.parseOptionValue()
+	if err != nil {
+		return err
+	}
+
+	if field != nil && len(name) == 1 && !name[0].extension {
+		switch name[0].name {
+		case "default":
+			if _, ok := p.pf.defaults[field]; ok {
+				return p.errorf(nameTok, "already set option \"default\"")
+			}
+			p.pf.defaults[field] = value
+			return nil
+		case "json_name":
+			if value.kind != tokenString {
+				return p.errorf(value.tok, "json_name must be a string")
+			}
+			field.JsonName = proto.String(value.text)
+			return nil
+		}
+	}
+	opt := &pendingOption{target: target, scope: scope, name: name, value: value}
+	for _, n := range name {
+		if n.extension {
+			p.pf.options = append(p.pf.options, opt)
+			return nil
+		}
+	}
+	// Standard options are set right away.
+	return applyOption(p.file, opt, nil)
+}
+
+func (p *parser) parseOptionValue() (optionValue, error) {
+	v := optionValue{tok: p.peek()}
+	if p.lookingAt("{") {
+		text, err := p.aggregate()
+		if err != nil {
+			return v, err
+		}
+		v.isAggr, v.aggregate = true, text
+		return v, nil
+	}
+	if p.tryConsume("-") {
+		v.negative = true
+	} else {
+		p.tryConsume("+")
+	}
+	tok := p.peek()
+	switch tok.kind {
+	case tokenString:
+		if v.negative {
+			return v, p.errorf(tok, "unexpected string")
+		}
+		s, err := p.stringLit()
+		if err != nil {
+			return v, err
+		}
+		v.kind, v.text = tokenString, s
+		return v, nil
+	case tokenIdent:
+		if v.negative && tok.text != "inf" && tok.text != "nan" {
+			return v, p.errorf(tok, "expected number, found %s", describe(tok))
+		}
+		name, _, err := p.fullIdent(false)
+		if err != nil {
+			return v, err
+		}
+		v.kind, v.text = tokenIdent, name
+		return v, nil
+	case tokenInt, tokenFloat:
+		p.next()
+		v.kind, v.text = tok.kind, tok.text
+		return v, nil
+	}
+	return v, p.errorf(tok, "expected option value, found %s", describe(tok))
+}
+
+// aggregate parses a {...} option value and returns it as text format.
+func (p *parser) aggregate() (string, error) {
+	var b strings.Builder
+	depth := 0
+	for {
+		tok := p.next()
+		switch {
+		case tok.kind == tokenEOF:
+			return "", p.errorf(tok, "unexpected end of file in aggregate value")
+		case tok.kind == tokenString:
+			b.WriteString(strconv.Quote(tok.text))
+		default:
+			b.WriteString(tok.text)
+		}
+		// the text format doesn't allow a space after the sign of a number
+		if tok.kind != tokenSymbol || tok.text != "-" {
+			b.WriteByte(' ')
+		}
+		if tok.kind == tokenSymbol {
+			switch tok.text {
+			case "{", "<":
+				depth++
+			case "}", ">":
+				depth--
+			}
+		}
+		if depth == 0 {
+			s := b.String()
+			// strip the outer braces
+			return strings.TrimSpace(s[1 : len(s)-2]), nil
+		}
+	}
+}
+
+// parseFieldOptions parses the [...] options of a field or an enum value.
+func (p *parser) parseFieldOptions(target func() protoreflect.Message, scope string, field *descriptorpb.FieldDescriptorProto) error {
+	if !p.tryConsume("[") {
+		return nil
+	}
+	for {
+		if err := p.parseOptionAssignment(target, scope, field); err != nil {
+			return err
+		}
+		if p.tryConsume("]") {
+			return nil
+		}
+		if _, err := p.expect(","); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseMessage(path []int32, scope string) (*descriptorpb.DescriptorProto, error) {
+	first := p.pos
+	p.next()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	m := &descriptorpb.DescriptorProto{Name: proto.String(name.text)}
+	if err := p.parseMessageBody(m, path, join(scope, name.text), first); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseMessageBody parses the {...} body of a message or a group, first is
+// the index of the first token of its declaration.
+func (p *parser) parseMessageBody(m *descriptorpb.DescriptorProto, path []int32, scope string, first int) error {
+	if _, err := p.expect("{"); err != nil {
+		return err
+	}
+	end := p.pos - 1
+	var extensionRanges []*descriptorpb.DescriptorProto_ExtensionRange
+	for !p.tryConsume("}") {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "reached end of input in message definition (missing '}')")
+		case p.tryConsume(";"):
+		case p.lookingAt("message"):
+			m2, err := p.parseMessage(appendPath(path, messageNestedTypeTag, int32(len(m.NestedType))), scope)
+			if err != nil {
+				return err
+			}
+			m.NestedType = append(m.NestedType, m2)
+		case p.lookingAt("enum"):
+			e, err := p.parseEnum(appendPath(path, messageEnumTypeTag, int32(len(m.EnumType))), scope)
+			if err != nil {
+				return err
+			}
+			m.EnumType = append(m.EnumType, e)
+		case p.lookingAt("extend"):
+			if err := p.parseExtend(&m.Extension, appendPath(path, messageExtensionTag), &m.NestedType, appendPath(path, messageNestedTypeTag), scope); err != nil {
+				return err
+			}
+		case p.lookingAt("extensions"):
+			ranges, err := p.parseExtensions(scope)
+			if err != nil {
+				return err
+			}
+			m.ExtensionRange = append(m.ExtensionRange, ranges...)
+			extensionRanges = append(extensionRanges, ranges...)
+		case p.lookingAt("reserved"):
+			if err := p.parseReserved(m); err != nil {
+				return err
+			}
+		case p.lookingAt("option"):
+			target := func() protoreflect.Message {
+				if m.Options == nil {
+					m.Options = new(descriptorpb.MessageOptions)
+				}
+				return m.Options.ProtoReflect()
+			}
+			if err := p.parseOption(target, scope); err != nil {
+				return err
+			}
+		case p.lookingAt("oneof"):
+			if err := p.parseOneof(m, path, scope); err != nil {
+				return err
+			}
+		default:
+			if err := p.parseField(m, path, scope, nil); err != nil {
+				return err
+			}
+		}
+	}
+	if m.GetOptions().GetMessageSetWireFormat() {
+		for _, r := range extensionRanges {
+			if r.GetEnd() == maxFieldNumber+1 {
+				r.End = proto.Int32(maxMessageSetNumber + 1)
+			}
+		}
+	}
+	p.addSyntheticOneofs(m)
+	p.addLocation(path, first, end)
+	return nil
+}
+
+// addSyntheticOneofs adds the oneofs of the proto3 optional fields, after
+// the real oneofs, like protoc.
+func (p *parser) addSyntheticOneofs(m *descriptorpb.DescriptorProto) {
+	names := make(map[string]bool)
+	for _, f := range m.Field {
+		names[f.GetName()] = true
+	}
+	for _, o := range m.OneofDecl {
+		names[o.GetName()] = true
+	}
+	for _, f := range m.Field {
+		if !f.GetProto3Optional() {
+			continue
+		}
+		name := f.GetName()
+		if !strings.HasPrefix(name, "_") {
+			name = "_" + name
+		}
+		for names[name] {
+			name = "X" + name
+		}
+		names[name] = true
+		f.OneofIndex = proto.Int32(int32(len(m.OneofDecl)))
+		m.OneofDecl = append(m.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String(name)})
+	}
+}
+
+func (p *parser) parseOneof(m *descriptorpb.DescriptorProto, path []int32, scope string) error {
+	first := p.pos
+	p.next()
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	index := int32(len(m.OneofDecl))
+	oneof := &descriptorpb.OneofDescriptorProto{Name: proto.String(name.text)}
+	m.OneofDecl = append(m.OneofDecl, oneof)
+	if _, err := p.expect("{"); err != nil {
+		return err
+	}
+	end := p.pos - 1
+	for !p.tryConsume("}") {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "reached end of input in oneof definition (missing '}')")
+		case p.tryConsume(";"):
+		case p.lookingAt("option"):
+			target := func() protoreflect.Message {
+				if oneof.Options == nil {
+					oneof.Options = new(descriptorpb.OneofOptions)
+				}
+				return oneof.Options.ProtoReflect()
+			}
+			if err := p.parseOption(target, scope); err != nil {
+				return err
+			}
+		default:
+			if _, ok := labels[tok.text]; ok && tok.kind == tokenIdent {
+				return p.errorf(tok, "fields in oneofs must not have labels")
+			}
+			if err := p.parseField(m, path, scope, &index); err != nil {
+				return err
+			}
+		}
+	}
+	p.addLocation(appendPath(path, messageOneofDeclTag, index), first, end)
+	return nil
+}
+
+// parseField parses a field, a group or a map field of the message m, or of
+// the oneof with the index oneof.
+func (p *parser) parseField(m *descriptorpb.DescriptorProto, path []int32, scope string, oneof *int32) error {
+	fieldPath := appendPath(path, messageFieldTag, int32(len(m.Field)))
+	f, err := p.parseFieldDecl(fieldPath, scope, &m.NestedType, appendPath(path, messageNestedTypeTag), oneof != nil)
+	if err != nil {
+		return err
+	}
+	f.OneofIndex = oneof
+	if oneof != nil {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	}
+	m.Field = append(m.Field, f)
+	return nil
+}
+
+// parseFieldDecl parses a field declaration, the messages of its groups and
+// map entries are added to nested.
+func (p *parser) parseFieldDecl(path []int32, scope string, nested *[]*descriptorpb.DescriptorProto, nestedPath []int32, inOneof bool) (*descriptorpb.FieldDescriptorProto, error) {
+	first := p.pos
+	f := new(descriptorpb.FieldDescriptorProto)
+	if tok := p.peek(); tok.kind == tokenIdent {
+		if label, ok := labels[tok.text]; ok {
+			p.next()
+			if p.proto3 && label == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED {
+				return nil, p.errorf(tok, "required fields are not allowed in proto3")
+			}
+			if p.proto3 && label == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL {
+				f.Proto3Optional = proto.Bool(true)
+			}
+			f.Label = label.Enum()
+		}
+	}
+	if f.Label == nil {
+		if !p.proto3 && !inOneof && !p.lookingAt("map") {
+			return nil, p.errorf(p.peek(), "expected \"required\", \"optional\", or \"repeated\"")
+		}
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	}
+
+	var mapEntry *descriptorpb.DescriptorProto
+	group := false
+	typeTok := p.peek()
+	switch {
+	case p.lookingAt("group") && !p.proto3:
+		p.next()
+		group = true
+		f.Type = descriptorpb.FieldDescriptorProto_TYPE_GROUP.Enum()
+	case p.lookingAt("map") && p.toks[p.pos+1].text == "<":
+		if f.Label.Number() != descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Number() || f.Proto3Optional != nil || inOneof {
+			return nil, p.errorf(typeTok, "map fields cannot have labels")
+		}
+		p.next()
+		p.next()
+		entry, err := p.parseMapTypes(scope)
+		if err != nil {
+			return nil, err
+		}
+		mapEntry = entry
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		f.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	default:
+		if err := p.parseType(scope, f); err != nil {
+			return nil, err
+		}
+	}
+
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	f.Name = proto.String(name.text)
+	if group {
+		if c := name.text[0]; c < 'A' || c > 'Z' {
+			return nil, p.errorf(name, "group names must start with a capital letter")
+		}
+		f.Name = proto.String(strings.ToLower(name.text))
+		f.TypeName = proto.String("." + join(scope, name.text))
+	}
+	if mapEntry != nil {
+		mapEntry.Name = proto.String(mapEntryName(name.text))
+		f.TypeName = proto.String("." + join(scope, mapEntry.GetName()))
+	}
+	if _, err := p.expect("="); err != nil {
+		return nil, err
+	}
+	number, numTok, err := p.intLit(false)
+	if err != nil {
+		return nil, err
+	}
+	if number < 1 || number > maxFieldNumber {
+		return nil, p.errorf(numTok, "field numbers must be in the range 1 to %d", maxFieldNumber)
+	}
+	f.Number = proto.Int32(int32(number))
+	f.JsonName = proto.String(jsonName(f.GetName()))
+
+	target := func() protoreflect.Message {
+		if f.Options == nil {
+			f.Options = new(descriptorpb.FieldOptions)
+		}
+		return f.Options.ProtoReflect()
+	}
+	if err := p.parseFieldOptions(target, scope, f); err != nil {
+		return nil, err
+	}
+
+	var end int
+	switch {
+	case group:
+		m := &descriptorpb.DescriptorProto{Name: proto.String(name.text)}
+		groupPath := appendPath(nestedPath, int32(len(*nested)))
+		if err := p.parseMessageBody(m, groupPath, join(scope, name.text), first); err != nil {
+			return nil, err
+		}
+		*nested = append(*nested, m)
+		// the comments of a group are on its message
+		end = -1
+	default:
+		if end, err = p.endStatement(); err != nil {
+			return nil, err
+		}
+		if mapEntry != nil {
+			*nested = append(*nested, mapEntry)
+		}
+	}
+	p.addLocation(path, first, end)
+	return f, nil
+}
+
+// parseType parses the type of a field, the references to messages and
+// enums are resolved later.
+func (p *parser) parseType(scope string, f *descriptorpb.FieldDescriptorProto) error {
+	tok := p.peek()
+	if t, ok := scalarTypes[tok.text]; ok && tok.kind == tokenIdent {
+		p.next()
+		f.Type = t.Enum()
+		return nil
+	}
+	name, tok, err := p.fullIdent(true)
+	if err != nil {
+		return err
+	}
+	p.pf.refs = append(p.pf.refs, &typeRef{
+		scope: scope,
+		name:  name,
+		tok:   tok,
+		set: func(fullName string, kind symbolKind) error {
+			switch kind {
+			case symbolMessage:
+				f.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			case symbolEnum:
+				f.Type = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum()
+			default:
+				return fmt.Errorf("%q is not a type", name)
+			}
+			f.TypeName = proto.String("." + fullName)
+			return nil
+		},
+	})
+	return nil
+}
+
+// parseMapTypes parses the key and value types of a map<K, V> field and
+// returns its map entry message, without name.
+func (p *parser) parseMapTypes(scope string) (*descriptorpb.DescriptorProto, error) {
+	key := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("key"),
+		Number:   proto.Int32(1),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String("key"),
+	}
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("value"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String("value"),
+	}
+	keyTok := p.peek()
+	if err := p.parseType(scope, key); err != nil {
+		return nil, err
+	}
+	switch key.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES, 0:
+		return nil, p.errorf(keyTok, "invalid map key type")
+	}
+	if _, err := p.expect(","); err != nil {
+		return nil, err
+	}
+	if err := p.parseType(scope, value); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(">"); err != nil {
+		return nil, err
+	}
+	return &descriptorpb.DescriptorProto{
+		Field:   []*descriptorpb.FieldDescriptorProto{key, value},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	}, nil
+}
+
+// mapEntryName returns the name of the map entry message of the field name,
+// like protoc: foo_bar becomes FooBarEntry.
+func mapEntryName(name string) string {
+	var b strings.Builder
+	upper := true
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String() + "Entry"
+}
+
+// jsonName returns the default JSON name of a field, like protoc: the
+// underscores are removed and the following letters capitalized.
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// parseRanges parses the number ranges of extensions and reserved
+// statements, the ends are inclusive.
+func (p *parser) parseRanges(max int64) ([][2]int64, error) {
+	var ranges [][2]int64
+	for {
+		start, startTok, err := p.intLit(max == maxEnumNumber)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if p.tryConsume("to") {
+			if p.tryConsume(extensionRangeMaxTag) {
+				end = max
+			} else if end, _, err = p.intLit(max == maxEnumNumber); err != nil {
+				return nil, err
+			}
+		}
+		if end < start {
+			return nil, p.errorf(startTok, "range end must be greater than or equal to start")
+		}
+		ranges = append(ranges, [2]int64{start, end})
+		if !p.tryConsume(",") {
+			return ranges, nil
+		}
+	}
+}
+
+func (p *parser) parseExtensions(scope string) ([]*descriptorpb.DescriptorProto_ExtensionRange, error) {
+	p.next()
+	ranges, err := p.parseRanges(maxFieldNumber)
+	if err != nil {
+		return nil, err
+	}
+	var extRanges []*descriptorpb.DescriptorProto_ExtensionRange
+	for _, r := range ranges {
+		extRanges = append(extRanges, &descriptorpb.DescriptorProto_ExtensionRange{
+			Start: proto.Int32(int32(r[0])),
+			End:   proto.Int32(int32(r[1] + 1)),
+		})
+	}
+	var opts *descriptorpb.ExtensionRangeOptions
+	target := func() protoreflect.Message {
+		if opts == nil {
+			opts = new(descriptorpb.ExtensionRangeOptions)
+			for _, r := range extRanges {
+				r.Options = opts
+			}
+		}
+		return opts.ProtoReflect()
+	}
+	if err := p.parseFieldOptions(target, scope, nil); err != nil {
+		return nil, err
+	}
+	_, err = p.endStatement()
+	return extRanges, err
+}
+
+func (p *parser) parseReserved(m *descriptorpb.DescriptorProto) error {
+	p.next()
+	if p.peek().kind == tokenString {
+		names, err := p.parseReservedNames()
+		if err != nil {
+			return err
+		}
+		m.ReservedName = append(m.ReservedName, names...)
+	} else {
+		ranges, err := p.parseRanges(maxFieldNumber)
+		if err != nil {
+			return err
+		}
+		for _, r := range ranges {
+			m.ReservedRange = append(m.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+				Start: proto.Int32(int32(r[0])),
+				End:   proto.Int32(int32(r[1] + 1)),
+			})
+		}
+	}
+	_, err := p.endStatement()
+	return err
+}
+
+func (p *parser) parseReservedNames() ([]string, error) {
+	var names []string
+	for {
+		name, err := p.stringLit()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.tryConsume(",") {
+			return names, nil
+		}
+	}
+}
+
+func (p *parser) parseEnum(path []int32, scope string) (*descriptorpb.EnumDescriptorProto, error) {
+	first := p.pos
+	p.next()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	e := &descriptorpb.EnumDescriptorProto{Name: proto.String(name.text)}
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	end := p.pos - 1
+	enumScope := join(scope, name.text)
+	for !p.tryConsume("}") {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenEOF:
+			return nil, p.errorf(tok, "reached end of input in enum definition (missing '}')")
+		case p.tryConsume(";"):
+		case p.lookingAt("option"):
+			target := func() protoreflect.Message {
+				if e.Options == nil {
+					e.Options = new(descriptorpb.EnumOptions)
+				}
+				return e.Options.ProtoReflect()
+			}
+			if err := p.parseOption(target, enumScope); err != nil {
+				return nil, err
+			}
+		case p.lookingAt("reserved"):
+			p.next()
+			if p.peek().kind == tokenString {
+				names, err := p.parseReservedNames()
+				if err != nil {
+					return nil, err
+				}
+				e.ReservedName = append(e.ReservedName, names...)
+			} else {
+				ranges, err := p.parseRanges(maxEnumNumber)
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range ranges {
+					e.ReservedRange = append(e.ReservedRange, &descriptorpb.EnumDescriptorProto_EnumReservedRange{
+						Start: proto.Int32(int32(r[0])),
+						End:   proto.Int32(int32(r[1])),
+					})
+				}
+			}
+			if _, err := p.endStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			v, err := p.parseEnumValue(appendPath(path, enumValueTag, int32(len(e.Value))), enumScope)
+			if err != nil {
+				return nil, err
+			}
+			e.Value = append(e.Value, v)
+		}
+	}
+	p.addLocation(path, first, end)
+	return e, nil
+}
+
+func (p *parser) parseEnumValue(path []int32, scope string) (*descriptorpb.EnumValueDescriptorProto, error) {
+	first := p.pos
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("="); err != nil {
+		return nil, err
+	}
+	number, numTok, err := p.intLit(true)
+	if err != nil {
+		return nil, err
+	}
+	if number < math.MinInt32 || number > math.MaxInt32 {
+		return nil, p.errorf(numTok, "enum value out of range")
+	}
+	v := &descriptorpb.EnumValueDescriptorProto{
+		Name:   proto.String(name.text),
+		Number: proto.Int32(int32(number)),
+	}
+	target := func() protoreflect.Message {
+		if v.Options == nil {
+			v.Options = new(descriptorpb.EnumValueOptions)
+		}
+		return v.Options.ProtoReflect()
+	}
+	if err := p.parseFieldOptions(target, scope, nil); err != nil {
+		return nil, err
+	}
+	end, err := p.endStatement()
+	if err != nil {
+		return nil, err
+	}
+	p.addLocation(path, first, end)
+	return v, nil
+}
+
+// parseExtend parses an extend block, its fields are added to exts and the
+// messages of its groups to nested.
+func (p *parser) parseExtend(exts *[]*descriptorpb.FieldDescriptorProto, path []int32, nested *[]*descriptorpb.DescriptorProto, nestedPath []int32, scope string) error {
+	p.next()
+	extendee, extendeeTok, err := p.fullIdent(true)
+	if err != nil {
+		return err
+	}
+	if _, err := p.expect("{"); err != nil {
+		return err
+	}
+	var fields []*descriptorpb.FieldDescriptorProto
+	for !p.tryConsume("}") {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenEOF:
+			return p.errorf(tok, "reached end of input in extend definition (missing '}')")
+		case p.tryConsume(";"):
+		default:
+			if p.lookingAt("map") {
+				return p.errorf(tok, "map fields are not allowed in extensions")
+			}
+			f, err := p.parseFieldDecl(appendPath(path, int32(len(*exts))), scope, nested, nestedPath, false)
+			if err != nil {
+				return err
+			}
+			f.Extendee = proto.String(extendee)
+			if f.Proto3Optional != nil {
+				// proto3 extensions have explicit presence without oneof
+				f.Proto3Optional = nil
+			}
+			*exts = append(*exts, f)
+			fields = append(fields, f)
+		}
+	}
+	p.pf.refs = append(p.pf.refs, &typeRef{
+		scope: scope,
+		name:  extendee,
+		tok:   extendeeTok,
+		set: func(fullName string, kind symbolKind) error {
+			if kind != symbolMessage {
+				return fmt.Errorf("%q is not a message type", extendee)
+			}
+			for _, f := range fields {
+				f.Extendee = proto.String("." + fullName)
+			}
+			return nil
+		},
+	})
+	return nil
+}
+
+func (p *parser) parseService(path []int32) (*descriptorpb.ServiceDescriptorProto, error) {
+	first := p.pos
+	p.next()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	s := &descriptorpb.ServiceDescriptorProto{Name: proto.String(name.text)}
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	end := p.pos - 1
+	scope := join(p.pkg, name.text)
+	for !p.tryConsume("}") {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenEOF:
+			return nil, p.errorf(tok, "reached end of input in service definition (missing '}')")
+		case p.tryConsume(";"):
+		case p.lookingAt("option"):
+			target := func() protoreflect.Message {
+				if s.Options == nil {
+					s.Options = new(descriptorpb.ServiceOptions)
+				}
+				return s.Options.ProtoReflect()
+			}
+			if err := p.parseOption(target, scope); err != nil {
+				return nil, err
+			}
+		case p.lookingAt("rpc"):
+			m, err := p.parseMethod(appendPath(path, serviceMethodTag, int32(len(s.Method))), scope)
+			if err != nil {
+				return nil, err
+			}
+			s.Method = append(s.Method, m)
+		default:
+			return nil, p.errorf(tok, "expected \"rpc\", found %s", describe(tok))
+		}
+	}
+	p.addLocation(path, first, end)
+	return s, nil
+}
+
+func (p *parser) parseMethod(path []int32, scope string) (*descriptorpb.MethodDescriptorProto, error) {
+	first := p.pos
+	p.next()
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	m := &descriptorpb.MethodDescriptorProto{Name: proto.String(name.text)}
+	methodType := func(set func(string), stream *bool) error {
+		if _, err := p.expect("("); err != nil {
+			return err
+		}
+		if p.tryConsume("stream") {
+			*stream = true
+		}
+		typeName, tok, err := p.fullIdent(true)
+		if err != nil {
+			return err
+		}
+		p.pf.refs = append(p.pf.refs, &typeRef{
+			scope: p.pkg,
+			name:  typeName,
+			tok:   tok,
+			set: func(fullName string, kind symbolKind) error {
+				if kind != symbolMessage {
+					return fmt.Errorf("%q is not a message type", typeName)
+				}
+				set("." + fullName)
+				return nil
+			},
+		})
+		_, err = p.expect(")")
+		return err
+	}
+	var clientStreaming, serverStreaming bool
+	if err := methodType(func(s string) { m.InputType = proto.String(s) }, &clientStreaming); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if err := methodType(func(s string) { m.OutputType = proto.String(s) }, &serverStreaming); err != nil {
+		return nil, err
+	}
+	if clientStreaming {
+		m.ClientStreaming = proto.Bool(true)
+	}
+	if serverStreaming {
+		m.ServerStreaming = proto.Bool(true)
+	}
+
+	var end int
+	if p.lookingAt("{") {
+		p.next()
+		end = p.pos - 1
+		for !p.tryConsume("}") {
+			tok := p.peek()
+			switch {
+			case tok.kind == tokenEOF:
+				return nil, p.errorf(tok, "reached end of input in method definition (missing '}')")
+			case p.tryConsume(";"):
+			case p.lookingAt("option"):
+				target := func() protoreflect.Message {
+					if m.Options == nil {
+						m.Options = new(descriptorpb.MethodOptions)
+					}
+					return m.Options.ProtoReflect()
+				}
+				if err := p.parseOption(target, scope); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, p.errorf(tok, "expected \"option\", found %s", describe(tok))
+			}
+		}
+	} else if end, err = p.endStatement(); err != nil {
+		return nil, err
+	}
+	p.addLocation(path, first, end)
+	return m, nil
+}
@@ -0,0 +1,199 @@
+// Package protoparse parses .proto files into the descriptors protoc would
+// send to a plugin, without protoc.
+//
+// It supports the proto2 and proto3 syntaxes: imports, packages, messages,
+// enums, oneofs, maps, groups, extensions, services and options, with the
+// custom options encoded like protoc does. Only the comments of the
+// declarations are kept in the source code info.
+package protoparse
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// The well-known types can be imported without their .proto files.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Parser parses .proto files.
+type Parser struct {
+	// ImportPaths are the directories searched for the files and their
+	// imports, like the -I flags of protoc. The current directory is used
+	// if empty.
+	ImportPaths []string
+}
+
+// ParseFiles parses the files named by their paths relative to the import
+// paths. It returns the descriptors of the files and of all their imports,
+// the imports before the files importing them.
+//
+// The well-known types and google/protobuf/descriptor.proto are provided
+// if they are not found in the import paths.
+func (p *Parser) ParseFiles(names ...string) ([]*descriptorpb.FileDescriptorProto, error) {
+	l := &loader{
+		importPaths: p.ImportPaths,
+		files:       make(map[string]*loadedFile),
+	}
+	if len(l.importPaths) == 0 {
+		l.importPaths = []string{"."}
+	}
+	for _, name := range names {
+		if err := l.load(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	lk := newLinker()
+	var all []*descriptorpb.FileDescriptorProto
+	for _, f := range l.order {
+		lk.addFile(f.fd)
+		all = append(all, f.fd)
+	}
+	for _, f := range l.order {
+		if f.parsed != nil {
+			if err := lk.resolveRefs(f.name, f.parsed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	files, err := newFiles(all)
+	if err != nil {
+		return nil, err
+	}
+	lk.files = files
+	for _, f := range l.order {
+		if f.parsed == nil {
+			continue
+		}
+		for _, opt := range f.parsed.options {
+			if err := applyOption(f.name, opt, lk); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Round trip the files so that the custom options are unknown fields of
+	// the options, like in the requests of protoc.
+	for i, fd := range all {
+		b, err := proto.Marshal(fd)
+		if err != nil {
+			return nil, err
+		}
+		all[i] = new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(b, all[i]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := newFiles(all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// newFiles validates the files and builds their registry. MessageSets are
+// reported with a clear error, protodesc rejects them as legacy.
+func newFiles(all []*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	for _, fd := range all {
+		err := walkMessages(fd.GetPackage(), fd.MessageType, func(name string, m *descriptorpb.DescriptorProto) error {
+			if m.GetOptions().GetMessageSetWireFormat() {
+				return fmt.Errorf("%s: message %s: MessageSet is not supported", fd.GetName(), name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: all})
+}
+
+// walkMessages calls fn with the full name of the messages and of their
+// nested messages, scope is the package or the enclosing message.
+func walkMessages(scope string, msgs []*descriptorpb.DescriptorProto, fn func(name string, m *descriptorpb.DescriptorProto) error) error {
+	for _, m := range msgs {
+		name := m.GetName()
+		if scope != "" {
+			name = scope + "." + name
+		}
+		if err := fn(name, m); err != nil {
+			return err
+		}
+		if err := walkMessages(name, m.NestedType, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type loadedFile struct {
+	name   string
+	fd     *descriptorpb.FileDescriptorProto
+	parsed *parsedFile // nil for the provided files
+}
+
+type loader struct {
+	importPaths []string
+	files       map[string]*loadedFile
+	order       []*loadedFile
+}
+
+// load loads the file name and its imports, loading lists the files being
+// loaded to detect the import cycles.
+func (l *loader) load(name string, loading []string) error {
+	if _, ok := l.files[name]; ok {
+		return nil
+	}
+	for _, n := range loading {
+		if n == name {
+			return fmt.Errorf("import cycle: %v", append(loading, name))
+		}
+	}
+	f, err := l.open(name)
+	if err != nil {
+		return err
+	}
+	for _, dep := range f.fd.Dependency {
+		if err := l.load(dep, append(loading, name)); err != nil {
+			return err
+		}
+	}
+	l.files[name] = f
+	l.order = append(l.order, f)
+	return nil
+}
+
+func (l *loader) open(name string) (*loadedFile, error) {
+	for _, dir := range l.importPaths {
+		src, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pf, err := parse(name, string(src))
+		if err != nil {
+			return nil, err
+		}
+		return &loadedFile{name: name, fd: pf.fd, parsed: pf}, nil
+	}
+	if fd, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+		return &loadedFile{name: name, fd: protodesc.ToFileDescriptorProto(fd)}, nil
+	}
+	return nil, fmt.Errorf("%s: file not found in the import paths %v", name, l.importPaths)
+}
@@ -0,0 +1,92 @@
+package protoparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func parseSource(t *testing.T, src string) ([]*descriptorpb.FileDescriptorProto, error) {
+	t.Helper()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test.proto"), []byte(src), 0o644))
+	p := Parser{ImportPaths: []string{dir}}
+	return p.ParseFiles("test.proto")
+}
+
+func TestParse(t *testing.T) {
+	files, err := parseSource(t, `
+syntax = "proto2";
+package test.v1;
+import "google/protobuf/timestamp.proto";
+
+// M is a message.
+message M {
+  optional int32 a = 1 [default = 0x10]; // trailing
+  optional double b = 2 [default = -1e10];
+  optional bytes c = 3 [default = "\x01\n"];
+  map<string, Nested.E> d = 4;
+  optional group G = 5 { optional int32 x = 1; }
+  optional google.protobuf.Timestamp t = 6;
+  oneof o {
+    string s = 7;
+  }
+  extensions 100 to max;
+  message Nested {
+    enum E { A = 0; }
+  }
+}
+
+extend M {
+  optional M ext = 100;
+}
+`)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "google/protobuf/timestamp.proto", files[0].GetName())
+
+	m := files[1].MessageType[0]
+	assert.Equal(t, "16", m.Field[0].GetDefaultValue())
+	assert.Equal(t, "-10000000000", m.Field[1].GetDefaultValue())
+	assert.Equal(t, `\001\n`, m.Field[2].GetDefaultValue())
+	assert.Equal(t, ".test.v1.M.DEntry", m.Field[3].GetTypeName())
+	assert.Equal(t, ".test.v1.M.Nested.E", m.NestedType[0].Field[1].GetTypeName())
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_ENUM, m.NestedType[0].Field[1].GetType())
+	assert.Equal(t, "g", m.Field[4].GetName())
+	assert.Equal(t, descriptorpb.FieldDescriptorProto_TYPE_GROUP, m.Field[4].GetType())
+	assert.Equal(t, ".google.protobuf.Timestamp", m.Field[5].GetTypeName())
+	assert.Equal(t, int32(0), m.Field[6].GetOneofIndex())
+	assert.Equal(t, int32(1<<29), m.ExtensionRange[0].GetEnd())
+	assert.Equal(t, ".test.v1.M", files[1].Extension[0].GetExtendee())
+
+	locs := files[1].GetSourceCodeInfo().GetLocation()
+	assert.Equal(t, []int32{4, 0}, locs[len(locs)-2].Path)
+	assert.Equal(t, " M is a message.\n", locs[len(locs)-2].GetLeadingComments())
+	assert.Equal(t, []int32{4, 0, 2, 0}, locs[0].Path)
+	assert.Equal(t, " trailing\n", locs[0].GetTrailingComments())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`syntax = "proto4";`, `test.proto:1:10: unsupported syntax "proto4"`},
+		{`syntax = "proto3"; message M { required int32 a = 1; }`, "test.proto:1:32: required fields are not allowed in proto3"},
+		{`syntax = "proto3"; message M { Unknown a = 1; }`, `test.proto:1:32: "Unknown" is not defined`},
+		{`syntax = "proto3"; message M { int32 a = 1 [(x) = 1]; }`, `test.proto:1:51: option "(x)" unknown`},
+		{`syntax = "proto3"; message M { int32 a = 0; }`, "test.proto:1:42: field numbers must be in the range 1 to 536870911"},
+		{`syntax = "proto3"; message M { int32 a = 1;`, "test.proto:1:44: reached end of input in message definition (missing '}')"},
+		{`syntax = "proto3"; import "missing.proto";`, "missing.proto: file not found in the import paths"},
+		{`syntax = "proto2"; package p; message M { option message_set_wire_format = true; extensions 4 to max; }`, "test.proto: message p.M: MessageSet is not supported"},
+	}
+	for _, tt := range tests {
+		_, err := parseSource(t, tt.src)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+}
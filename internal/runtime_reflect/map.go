@@ -8,89 +8,38 @@
 // to expose APIs that are efficient enough that we can drop the need for this
 // package, but until then we will be maintaining bridges to these Go runtime
 // functions and types.
+//
+// The maps are operated on through MapType and MapIter, which have one
+// implementation per layout of the runtime map iterator:
+//
+//   - map_hiter.go, the hash maps of Go 1.23 and older
+//   - map_swiss.go, the Swiss table maps of Go 1.24 and newer
+//   - map_reflect.go, reflect.MapIter and reflect.Value.SetMapIndex
+//
+// The last one doesn't depend on the runtime internals, it is used for the
+// Go versions whose map internals have not been checked yet and with the
+// protosafemap build tag. MapBackend names the implementation in use.
 package runtime_reflect
 
-import "unsafe"
+import (
+	"reflect"
+	"unsafe"
+)
 
 func Assign(typ, dst, src unsafe.Pointer) {
 	typedmemmove(typ, dst, src)
 }
 
-func MapAssign(t, m, k unsafe.Pointer) unsafe.Pointer {
-	return mapassign(t, m, k)
+type iface struct {
+	typ unsafe.Pointer
+	ptr unsafe.Pointer
 }
 
-func MakeMap(t unsafe.Pointer, cap int) unsafe.Pointer {
-	return makemap(t, cap)
+// typePointer returns the runtime type of t.
+func typePointer(t reflect.Type) unsafe.Pointer {
+	return (*iface)(unsafe.Pointer(&t)).ptr
 }
 
-type MapIter struct{ hiter }
-
-func (it *MapIter) Init(t unsafe.Pointer, m unsafe.Pointer) {
-	mapiterinit(t, m, &it.hiter)
-}
-
-func (it *MapIter) Done() {
-	if it.h != nil {
-		it.key = nil
-		mapiternext(&it.hiter)
-	}
-}
-
-func (it *MapIter) Next() {
-	mapiternext(&it.hiter)
-}
-
-func (it *MapIter) HasNext() bool {
-	return it.key != nil
-}
-
-func (it *MapIter) Key() unsafe.Pointer { return it.key }
-
-func (it *MapIter) Value() unsafe.Pointer { return it.value }
-
-// copied from src/runtime/map.go, all pointer types replaced with
-// unsafe.Pointer.
-//
-// Alternatively we could get away with a heap allocation and only
-// defining key and val if we were using reflect.mapiterinit instead,
-// which returns a heap-allocated *hiter.
-type hiter struct {
-	key         unsafe.Pointer // nil when iteration is done
-	value       unsafe.Pointer
-	t           unsafe.Pointer
-	h           unsafe.Pointer
-	buckets     unsafe.Pointer // bucket ptr at hash_iter initialization time
-	bptr        unsafe.Pointer // current bucket
-	overflow    unsafe.Pointer // keeps overflow buckets of hmap.buckets alive
-	oldoverflow unsafe.Pointer // keeps overflow buckets of hmap.oldbuckets alive
-	startBucket uintptr        // bucket iteration started at
-	offset      uint8          // intra-bucket offset to start from during iteration (should be big enough to hold bucketCnt-1)
-	wrapped     bool           // already wrapped around from end of bucket array to beginning
-	B           uint8
-	i           uint8
-	bucket      uintptr
-	checkBucket uintptr
-}
-
-//go:noescape
-//go:linkname makemap reflect.makemap
-func makemap(t unsafe.Pointer, cap int) unsafe.Pointer
-
-// m escapes into the return value, but the caller of mapiterinit
-// doesn't let the return value escape.
-//go:noescape
-//go:linkname mapiterinit runtime.mapiterinit
-func mapiterinit(t unsafe.Pointer, m unsafe.Pointer, it *hiter)
-
-//go:noescape
-//go:linkname mapiternext runtime.mapiternext
-func mapiternext(it *hiter)
-
-//go:noescape
-//go:linkname mapassign runtime.mapassign
-func mapassign(t, m, k unsafe.Pointer) unsafe.Pointer
-
 //go:nosplit
 //go:noescape
 //go:linkname typedmemmove runtime.typedmemmove
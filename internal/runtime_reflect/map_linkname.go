@@ -0,0 +1,72 @@
+//go:build !protosafemap && !go1.28
+
+package runtime_reflect
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// MapType holds what the runtime needs to operate on the maps of a type.
+type MapType struct {
+	typ  unsafe.Pointer
+	elem unsafe.Pointer
+}
+
+// NewMapType returns the MapType of the map type t.
+func NewMapType(t reflect.Type) *MapType {
+	return &MapType{typ: typePointer(t), elem: typePointer(t.Elem())}
+}
+
+// Make returns a new map with room for cap elements.
+func (t *MapType) Make(cap int) unsafe.Pointer {
+	return makemap(t.typ, cap)
+}
+
+// Assign stores the value pointed to by val under the key pointed to by key
+// in the map m.
+func (t *MapType) Assign(m, key, val unsafe.Pointer) {
+	typedmemmove(t.elem, mapassign(t.typ, m, key), val)
+}
+
+type MapIter struct{ hiter }
+
+func (it *MapIter) Init(t *MapType, m unsafe.Pointer) {
+	mapiterinit(t.typ, m, &it.hiter)
+}
+
+// Done releases the references of the iterator to the map.
+func (it *MapIter) Done() {
+	it.hiter = hiter{}
+}
+
+func (it *MapIter) Next() {
+	mapiternext(&it.hiter)
+}
+
+func (it *MapIter) HasNext() bool {
+	return it.key != nil
+}
+
+func (it *MapIter) Key() unsafe.Pointer { return it.key }
+
+func (it *MapIter) Value() unsafe.Pointer { return it.value }
+
+//go:noescape
+//go:linkname makemap reflect.makemap
+func makemap(t unsafe.Pointer, cap int) unsafe.Pointer
+
+// m escapes into the return value, but the caller of mapiterinit
+// doesn't let the return value escape.
+//
+//go:noescape
+//go:linkname mapiterinit runtime.mapiterinit
+func mapiterinit(t unsafe.Pointer, m unsafe.Pointer, it *hiter)
+
+//go:noescape
+//go:linkname mapiternext runtime.mapiternext
+func mapiternext(it *hiter)
+
+//go:noescape
+//go:linkname mapassign runtime.mapassign
+func mapassign(t, m, k unsafe.Pointer) unsafe.Pointer
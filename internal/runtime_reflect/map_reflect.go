@@ -0,0 +1,69 @@
+//go:build protosafemap || go1.28
+
+package runtime_reflect
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+const MapBackend = "reflect"
+
+// MapType holds what the runtime needs to operate on the maps of a type.
+type MapType struct {
+	typ reflect.Type
+}
+
+// NewMapType returns the MapType of the map type t.
+func NewMapType(t reflect.Type) *MapType {
+	return &MapType{typ: t}
+}
+
+// Make returns a new map with room for cap elements.
+func (t *MapType) Make(cap int) unsafe.Pointer {
+	return reflect.MakeMapWithSize(t.typ, cap).UnsafePointer()
+}
+
+// Assign stores the value pointed to by val under the key pointed to by key
+// in the map m.
+func (t *MapType) Assign(m, key, val unsafe.Pointer) {
+	reflect.NewAt(t.typ, unsafe.Pointer(&m)).Elem().SetMapIndex(
+		reflect.NewAt(t.typ.Key(), key).Elem(),
+		reflect.NewAt(t.typ.Elem(), val).Elem(),
+	)
+}
+
+// MapIter iterates over a map with a reflect.MapIter, copying its keys and
+// values to variables of the iterator.
+type MapIter struct {
+	iter     reflect.MapIter
+	key, val reflect.Value
+	hasNext  bool
+}
+
+func (it *MapIter) Init(t *MapType, m unsafe.Pointer) {
+	it.iter.Reset(reflect.NewAt(t.typ, unsafe.Pointer(&m)).Elem())
+	it.key = reflect.New(t.typ.Key()).Elem()
+	it.val = reflect.New(t.typ.Elem()).Elem()
+	it.Next()
+}
+
+// Done releases the references of the iterator to the map.
+func (it *MapIter) Done() {
+	*it = MapIter{}
+}
+
+func (it *MapIter) Next() {
+	if it.hasNext = it.iter.Next(); it.hasNext {
+		it.key.SetIterKey(&it.iter)
+		it.val.SetIterValue(&it.iter)
+	}
+}
+
+func (it *MapIter) HasNext() bool {
+	return it.hasNext
+}
+
+func (it *MapIter) Key() unsafe.Pointer { return unsafe.Pointer(it.key.UnsafeAddr()) }
+
+func (it *MapIter) Value() unsafe.Pointer { return unsafe.Pointer(it.val.UnsafeAddr()) }
@@ -0,0 +1,17 @@
+//go:build !protosafemap && go1.24 && !go1.28 && (go1.26 || goexperiment.swissmap)
+
+package runtime_reflect
+
+import "unsafe"
+
+const MapBackend = "swiss"
+
+// copied from linknameIter in src/runtime (linkname_swiss.go in Go 1.24 and
+// 1.25), which keeps the first fields of the hiter of the previous maps for
+// the users of mapiterinit. The runtime allocates the real iterator.
+type hiter struct {
+	key   unsafe.Pointer // nil when iteration is done
+	value unsafe.Pointer
+	t     unsafe.Pointer
+	it    unsafe.Pointer
+}
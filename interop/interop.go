@@ -0,0 +1,188 @@
+// Package interop converts golite messages to and from the messages of
+// google.golang.org/protobuf, so that golite values can be handed to
+// libraries which only accept protoreflect messages, such as protojson.
+//
+// The conversion goes through the wire format, and the struct tags of the
+// golite message are checked against the message descriptor first: every
+// tagged field must exist in the descriptor with the same number, kind and
+// cardinality. Fields of the descriptor that the golite message does not
+// declare are allowed, they are dropped when converting to golite.
+package interop
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	upstream "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// ToDynamic converts the golite message v to a dynamic message of type md.
+func ToDynamic(v interface{}, md protoreflect.MessageDescriptor) (*dynamicpb.Message, error) {
+	m := dynamicpb.NewMessage(md)
+	if err := ToProto(v, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ToProto replaces the contents of the upstream message m with the golite
+// message v.
+func ToProto(v interface{}, m upstream.Message) error {
+	if err := Verify(v, m.ProtoReflect().Descriptor()); err != nil {
+		return err
+	}
+	b, err := proto.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return upstream.Unmarshal(b, m)
+}
+
+// FromProto replaces the contents of the golite message v with the upstream
+// message m, which may be a *dynamicpb.Message.
+func FromProto(m upstream.Message, v interface{}) error {
+	if err := Verify(v, m.ProtoReflect().Descriptor()); err != nil {
+		return err
+	}
+	b, err := upstream.Marshal(m)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return &proto.InvalidUnmarshalError{Type: rv.Type()}
+	}
+	rv.Elem().Set(reflect.Zero(rv.Type().Elem()))
+	return proto.Unmarshal(b, v)
+}
+
+type verifyKey struct {
+	t  reflect.Type
+	md protoreflect.MessageDescriptor
+}
+
+var verified sync.Map // map[verifyKey]error
+
+// Verify checks that the struct tags of the golite message v, a pointer to a
+// struct, match the message descriptor md. The result is cached.
+func Verify(v interface{}, md protoreflect.MessageDescriptor) error {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("interop: %T is not a pointer to a struct", v)
+	}
+	key := verifyKey{t: t, md: md}
+	if err, ok := verified.Load(key); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+	err := verify(t, md, make(map[verifyKey]bool))
+	verified.Store(key, err)
+	return err
+}
+
+func verify(t reflect.Type, md protoreflect.MessageDescriptor, seen map[verifyKey]bool) error {
+	key := verifyKey{t: t, md: md}
+	if seen[key] {
+		return nil // recursive message
+	}
+	seen[key] = true
+
+	fds := md.Fields()
+	for _, f := range proto.Fields(reflect.New(t.Elem()).Interface()) {
+		fd := fds.ByNumber(protoreflect.FieldNumber(f.Number))
+		if fd == nil {
+			return fmt.Errorf("interop: %s.%s: field %d not found in %s", t.Elem(), f.Name, f.Number, md.FullName())
+		}
+		if err := verifyField(f, fd); err != nil {
+			return fmt.Errorf("interop: %s.%s: %w", t.Elem(), f.Name, err)
+		}
+
+		var elem reflect.Type
+		var nested protoreflect.MessageDescriptor
+		switch {
+		case f.Kind == proto.MapKind:
+			if f.MapValue == proto.MessageKind {
+				elem, nested = f.Type.Elem(), fd.MapValue().Message()
+			}
+		case f.Kind == proto.MessageKind || f.Kind == proto.GroupKind:
+			elem, nested = f.Type, fd.Message()
+			if f.Cardinality == proto.Repeated {
+				elem = elem.Elem()
+			}
+		}
+		if nested != nil {
+			if err := verify(elem, nested, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func verifyField(f proto.Field, fd protoreflect.FieldDescriptor) error {
+	if fd.IsMap() != (f.Kind == proto.MapKind) {
+		return fmt.Errorf("kind %s does not match %s", f.Kind, describe(fd))
+	}
+	if fd.IsMap() {
+		if !kindMatches(f.MapKey, fd.MapKey().Kind()) || !kindMatches(f.MapValue, fd.MapValue().Kind()) {
+			return fmt.Errorf("map<%s, %s> does not match %s", f.MapKey, f.MapValue, describe(fd))
+		}
+		return nil
+	}
+	if !kindMatches(f.Kind, fd.Kind()) {
+		return fmt.Errorf("kind %s does not match %s", f.Kind, describe(fd))
+	}
+	if (f.Cardinality == proto.Repeated) != (fd.Cardinality() == protoreflect.Repeated) {
+		return fmt.Errorf("%s field does not match %s", f.Cardinality, describe(fd))
+	}
+	oneof := ""
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		oneof = string(od.Name())
+	}
+	if f.Oneof != oneof {
+		return fmt.Errorf("oneof %q does not match %s", f.Oneof, describe(fd))
+	}
+	return nil
+}
+
+func describe(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s> %s", fd.MapKey().Kind(), fd.MapValue().Kind(), fd.FullName())
+	}
+	return fmt.Sprintf("%s %s %s", fd.Cardinality(), fd.Kind(), fd.FullName())
+}
+
+var kinds = map[proto.Kind]protoreflect.Kind{
+	proto.BoolKind:     protoreflect.BoolKind,
+	proto.Int32Kind:    protoreflect.Int32Kind,
+	proto.Sint32Kind:   protoreflect.Sint32Kind,
+	proto.Uint32Kind:   protoreflect.Uint32Kind,
+	proto.Int64Kind:    protoreflect.Int64Kind,
+	proto.Sint64Kind:   protoreflect.Sint64Kind,
+	proto.Uint64Kind:   protoreflect.Uint64Kind,
+	proto.Fixed32Kind:  protoreflect.Fixed32Kind,
+	proto.Sfixed32Kind: protoreflect.Sfixed32Kind,
+	proto.FloatKind:    protoreflect.FloatKind,
+	proto.Fixed64Kind:  protoreflect.Fixed64Kind,
+	proto.Sfixed64Kind: protoreflect.Sfixed64Kind,
+	proto.DoubleKind:   protoreflect.DoubleKind,
+	proto.StringKind:   protoreflect.StringKind,
+	proto.BytesKind:    protoreflect.BytesKind,
+	proto.MessageKind:  protoreflect.MessageKind,
+	proto.GroupKind:    protoreflect.GroupKind,
+}
+
+func kindMatches(k proto.Kind, want protoreflect.Kind) bool {
+	if k == proto.Int32Kind && want == protoreflect.EnumKind {
+		return true // enums are generated as int32
+	}
+	pk, ok := kinds[k]
+	return ok && pk == want
+}
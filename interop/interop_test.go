@@ -0,0 +1,174 @@
+package interop
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testFile = `
+name: "interop_test.proto"
+package: "interop.test"
+syntax: "proto3"
+message_type {
+	name: "User"
+	field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT64 json_name: "id" }
+	field { name: "name" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+	field { name: "tags" number: 3 label: LABEL_REPEATED type: TYPE_STRING json_name: "tags" }
+	field { name: "status" number: 4 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".interop.test.Status" json_name: "status" }
+	field { name: "friends" number: 5 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".interop.test.User" json_name: "friends" }
+	field { name: "scores" number: 6 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".interop.test.User.ScoresEntry" json_name: "scores" }
+	field { name: "email" number: 7 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "email" }
+	field { name: "phone" number: 8 label: LABEL_OPTIONAL type: TYPE_SINT64 oneof_index: 0 json_name: "phone" }
+	field { name: "note" number: 9 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "note" }
+	nested_type {
+		name: "ScoresEntry"
+		field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+		field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_SFIXED32 json_name: "value" }
+		options { map_entry: true }
+	}
+	oneof_decl { name: "contact" }
+}
+enum_type {
+	name: "Status"
+	value { name: "UNKNOWN" number: 0 }
+	value { name: "ACTIVE" number: 1 }
+}
+`
+
+func userDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := prototext.Unmarshal([]byte(testFile), fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().ByName("User")
+}
+
+// User declares every field of interop.test.User except note.
+type User struct {
+	Id      uint64           `protobuf:"varint,1,opt"`
+	Name    string           `protobuf:"bytes,2,opt"`
+	Tags    []string         `protobuf:"bytes,3,rep"`
+	Status  int32            `protobuf:"varint,4,opt"`
+	Friends []*User          `protobuf:"bytes,5,rep"`
+	Scores  map[string]int32 `protobuf:"bytes,6,rep" protobuf_key:"bytes,1,opt" protobuf_val:"fixed32,2,opt"`
+	Contact isUser_Contact   `protobuf_oneof:"contact"`
+}
+
+type isUser_Contact interface{ isUser_Contact() }
+
+type User_Email struct {
+	Email string `protobuf:"bytes,7,opt"`
+}
+
+type User_Phone struct {
+	Phone int64 `protobuf:"zigzag64,8,opt"`
+}
+
+func (*User_Email) isUser_Contact() {}
+func (*User_Phone) isUser_Contact() {}
+
+func (*User) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*User_Email)(nil),
+		(*User_Phone)(nil),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	md := userDescriptor(t)
+	u := &User{
+		Id:      1,
+		Name:    "alice",
+		Tags:    []string{"a", "b"},
+		Status:  1,
+		Friends: []*User{{Id: 2, Contact: &User_Phone{Phone: -42}}},
+		Scores:  map[string]int32{"go": -1},
+		Contact: &User_Email{Email: "alice@example.com"},
+	}
+
+	m, err := ToDynamic(u, md)
+	assert.NoError(t, err)
+
+	b, err := protojson.Marshal(m)
+	assert.NoError(t, err)
+	j := string(b)
+	for _, s := range []string{`"name":"alice"`, `"status":"ACTIVE"`, `"phone":"-42"`, `"scores":{"go":-1}`, `"email":"alice@example.com"`} {
+		assert.Contains(t, strings.ReplaceAll(j, " ", ""), s)
+	}
+
+	// fields unknown to golite are dropped
+	m.Set(md.Fields().ByName("note"), protoreflect.ValueOfString("dropped"))
+	got := &User{Name: "stale"}
+	assert.NoError(t, FromProto(m, got))
+	assert.Equal(t, u, got)
+}
+
+func TestVerify(t *testing.T) {
+	md := userDescriptor(t)
+	assert.NoError(t, Verify(&User{}, md))
+
+	tests := []struct {
+		v   interface{}
+		err string
+	}{
+		{&struct {
+			X int32 `protobuf:"varint,100,opt"`
+		}{}, "field 100 not found in interop.test.User"},
+		{&struct {
+			Id int64 `protobuf:"zigzag64,1,opt"`
+		}{}, "kind sint64 does not match optional uint64 interop.test.User.id"},
+		{&struct {
+			Name string `protobuf:"bytes,3,opt"`
+		}{}, "optional field does not match repeated string interop.test.User.tags"},
+		{&struct {
+			Scores map[string]int32 `protobuf:"bytes,6,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+		}{}, "map<string, int32> does not match map<string, sfixed32> interop.test.User.scores"},
+		{&struct {
+			Email string `protobuf:"bytes,7,opt"`
+		}{}, `oneof "" does not match optional string interop.test.User.email`},
+		{&struct {
+			Friends []*struct {
+				Name []byte `protobuf:"bytes,2,opt"`
+			} `protobuf:"bytes,5,rep"`
+		}{}, "kind bytes does not match optional string interop.test.User.name"},
+	}
+	for _, tt := range tests {
+		err := Verify(tt.v, md)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+
+	_, err := ToDynamic(tests[0].v, md)
+	assert.Error(t, err)
+}
+
+func TestToProto(t *testing.T) {
+	type Duration struct {
+		Seconds int64 `protobuf:"varint,1,opt"`
+		Nanos   int32 `protobuf:"varint,2,opt"`
+	}
+	d := &durationpb.Duration{}
+	assert.NoError(t, ToProto(&Duration{Seconds: 90, Nanos: 5}, d))
+	assert.Equal(t, int64(90), d.Seconds)
+	assert.Equal(t, int32(5), d.Nanos)
+
+	var got Duration
+	assert.NoError(t, FromProto(durationpb.New(1500000000), &got))
+	assert.Equal(t, Duration{Seconds: 1, Nanos: 500000000}, got)
+
+	assert.Error(t, FromProto(d, (*Duration)(nil)))
+}
@@ -0,0 +1,303 @@
+//go:build purego
+
+package proto
+
+import (
+	"io"
+	"math"
+	"reflect"
+)
+
+// scalar describes the encoding of the values of a scalar type, without
+// their tag.
+type scalar struct {
+	size   func(v reflect.Value) int
+	encode func(b []byte, v reflect.Value) []byte
+	decode decodeFunc
+	isZero func(v reflect.Value) bool
+}
+
+// codec returns the codec of the fields of the scalar type, their zero value
+// is omitted.
+func (s *scalar) codec() *codec {
+	return &codec{
+		size: func(v reflect.Value, f *structField) int {
+			if s.isZero(v) {
+				return 0
+			}
+			return s.size(v) + f.tagsize
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			if s.isZero(v) {
+				return b
+			}
+			b = appendVarint(b, f.wiretag)
+			return s.encode(b, v)
+		},
+		decode: s.decode,
+	}
+}
+
+// requiredCodec returns the codec of the values of the scalar type which are
+// always encoded: the elements of repeated fields, map entries, oneof members
+// and the values of pointers and options.
+func (s *scalar) requiredCodec() *codec {
+	return &codec{
+		size: func(v reflect.Value, f *structField) int {
+			return s.size(v) + f.tagsize
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			b = appendVarint(b, f.wiretag)
+			return s.encode(b, v)
+		},
+		decode: s.decode,
+	}
+}
+
+func isZeroInt(v reflect.Value) bool   { return v.Int() == 0 }
+func isZeroUint(v reflect.Value) bool  { return v.Uint() == 0 }
+func isZeroFloat(v reflect.Value) bool { return v.Float() == 0 && !math.Signbit(v.Float()) }
+
+var boolScalar = scalar{
+	size: func(reflect.Value) int { return 1 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		if len(b) == 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		v.SetBool(b[0] != 0)
+		return 1, nil
+	},
+	isZero: func(v reflect.Value) bool { return !v.Bool() },
+}
+
+var stringScalar = scalar{
+	size: func(v reflect.Value) int { return sizeOfVarlen(v.Len()) },
+	encode: func(b []byte, v reflect.Value) []byte {
+		b = appendVarint(b, uint64(v.Len()))
+		return append(b, v.String()...)
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		s, n, err := decodeVarlen(b)
+		v.SetString(string(s))
+		return n, err
+	},
+	isZero: func(v reflect.Value) bool { return v.Len() == 0 },
+}
+
+// bytesScalar omits nil slices even when it is required, an empty slice
+// which is not nil is encoded.
+var bytesScalar = scalar{
+	size: func(v reflect.Value) int { return sizeOfVarlen(v.Len()) },
+	encode: func(b []byte, v reflect.Value) []byte {
+		b = appendVarint(b, uint64(v.Len()))
+		return append(b, v.Bytes()...)
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		s, n, err := decodeVarlen(b)
+		if v.IsNil() {
+			v.SetBytes(make([]byte, 0, len(s)))
+		}
+		v.SetBytes(append(v.Bytes()[:0], s...))
+		return n, err
+	},
+	isZero: func(v reflect.Value) bool { return v.IsNil() },
+}
+
+var float32Scalar = scalar{
+	size: func(reflect.Value) int { return 4 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE32(b, math.Float32bits(float32(v.Float())))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE32(b)
+		v.SetFloat(float64(math.Float32frombits(u)))
+		return n, err
+	},
+	isZero: isZeroFloat,
+}
+
+var float64Scalar = scalar{
+	size: func(reflect.Value) int { return 8 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE64(b, math.Float64bits(v.Float()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE64(b)
+		v.SetFloat(math.Float64frombits(u))
+		return n, err
+	},
+	isZero: isZeroFloat,
+}
+
+// int32 and int64 varints have the same encoding, negative values are
+// sign-extended to 64 bits, the setters truncate the values to the size of
+// the field.
+var intScalar = scalar{
+	size: func(v reflect.Value) int { return sizeOfVarint(uint64(v.Int())) },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return appendVarint(b, uint64(v.Int()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeVarint(b)
+		v.SetInt(int64(u))
+		return n, err
+	},
+	isZero: isZeroInt,
+}
+
+var uintScalar = scalar{
+	size: func(v reflect.Value) int { return sizeOfVarint(v.Uint()) },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return appendVarint(b, v.Uint())
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeVarint(b)
+		v.SetUint(u)
+		return n, err
+	},
+	isZero: isZeroUint,
+}
+
+var zigzagScalar = scalar{
+	size: func(v reflect.Value) int { return sizeOfVarint(encodeZigZag64(v.Int())) },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return appendVarint(b, encodeZigZag64(v.Int()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeVarint(b)
+		v.SetInt(decodeZigZag64(u))
+		return n, err
+	},
+	isZero: isZeroInt,
+}
+
+var fixed32Scalar = scalar{
+	size: func(reflect.Value) int { return 4 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE32(b, uint32(v.Uint()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE32(b)
+		v.SetUint(uint64(u))
+		return n, err
+	},
+	isZero: isZeroUint,
+}
+
+var fixed64Scalar = scalar{
+	size: func(reflect.Value) int { return 8 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE64(b, v.Uint())
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE64(b)
+		v.SetUint(u)
+		return n, err
+	},
+	isZero: isZeroUint,
+}
+
+var sfixed32Scalar = scalar{
+	size: func(reflect.Value) int { return 4 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE32(b, uint32(v.Int()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE32(b)
+		v.SetInt(int64(int32(u)))
+		return n, err
+	},
+	isZero: isZeroInt,
+}
+
+var sfixed64Scalar = scalar{
+	size: func(reflect.Value) int { return 8 },
+	encode: func(b []byte, v reflect.Value) []byte {
+		return encodeLE64(b, uint64(v.Int()))
+	},
+	decode: func(b []byte, v reflect.Value) (int, error) {
+		u, n, err := decodeLE64(b)
+		v.SetInt(int64(u))
+		return n, err
+	},
+	isZero: isZeroInt,
+}
+
+var (
+	boolCodec     = boolScalar.codec()
+	stringCodec   = stringScalar.codec()
+	bytesCodec    = bytesScalar.codec()
+	float32Codec  = float32Scalar.codec()
+	float64Codec  = float64Scalar.codec()
+	intCodec      = intScalar.codec()
+	uintCodec     = uintScalar.codec()
+	zigzagCodec   = zigzagScalar.codec()
+	fixed32Codec  = fixed32Scalar.codec()
+	fixed64Codec  = fixed64Scalar.codec()
+	sfixed32Codec = sfixed32Scalar.codec()
+	sfixed64Codec = sfixed64Scalar.codec()
+
+	boolRequiredCodec     = boolScalar.requiredCodec()
+	stringRequiredCodec   = stringScalar.requiredCodec()
+	float32RequiredCodec  = float32Scalar.requiredCodec()
+	float64RequiredCodec  = float64Scalar.requiredCodec()
+	intRequiredCodec      = intScalar.requiredCodec()
+	uintRequiredCodec     = uintScalar.requiredCodec()
+	zigzagRequiredCodec   = zigzagScalar.requiredCodec()
+	fixed32RequiredCodec  = fixed32Scalar.requiredCodec()
+	fixed64RequiredCodec  = fixed64Scalar.requiredCodec()
+	sfixed32RequiredCodec = sfixed32Scalar.requiredCodec()
+	sfixed64RequiredCodec = sfixed64Scalar.requiredCodec()
+)
+
+// scalarCodec returns the codec of the scalar type t, nil if t is not a
+// scalar type. The zero value is omitted unless required is true.
+func scalarCodec(t reflect.Type, conf *walkerConfig) *codec {
+	pick := func(normal, required *codec) *codec {
+		if conf.required {
+			return required
+		}
+		return normal
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return pick(boolCodec, boolRequiredCodec)
+	case reflect.Int32, reflect.Int64:
+		switch {
+		case conf.zigzag:
+			return pick(zigzagCodec, zigzagRequiredCodec)
+		case t.Kind() == reflect.Int32 && conf.wireType == fixed32:
+			return pick(sfixed32Codec, sfixed32RequiredCodec)
+		case t.Kind() == reflect.Int64 && conf.wireType == fixed64:
+			return pick(sfixed64Codec, sfixed64RequiredCodec)
+		}
+		return pick(intCodec, intRequiredCodec)
+	case reflect.Uint32:
+		if conf.wireType == fixed32 {
+			return pick(fixed32Codec, fixed32RequiredCodec)
+		}
+		return pick(uintCodec, uintRequiredCodec)
+	case reflect.Uint64:
+		if conf.wireType == fixed64 {
+			return pick(fixed64Codec, fixed64RequiredCodec)
+		}
+		return pick(uintCodec, uintRequiredCodec)
+	case reflect.Float32:
+		return pick(float32Codec, float32RequiredCodec)
+	case reflect.Float64:
+		return pick(float64Codec, float64RequiredCodec)
+	case reflect.String:
+		return pick(stringCodec, stringRequiredCodec)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return bytesCodec
+		}
+	}
+	return nil
+}
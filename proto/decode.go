@@ -4,16 +4,16 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
-	"unsafe"
 )
 
 func decodeZigZag64(v uint64) int64 {
 	return int64(v>>1) ^ -(int64(v) & 1)
 }
 
-type decodeFunc = func([]byte, unsafe.Pointer) (int, error)
-
-var errVarintOverflow = errors.New("varint overflowed 64 bits integer")
+var (
+	errVarintOverflow = errors.New("varint overflowed 64 bits integer")
+	errGroupMismatch  = errors.New("mismatching end group tag")
+)
 
 func decodeVarint(b []byte) (uint64, int, error) {
 	if len(b) != 0 && b[0] < 0x80 {
@@ -75,3 +75,54 @@ func decodeVarlen(b []byte) ([]byte, int, error) {
 	}
 	return b[n : n+int(v)], n + int(v), nil
 }
+
+// decodeGroup returns the length of the group with field number f, including
+// its end group tag. b starts right after the start group tag.
+func decodeGroup(b []byte, f fieldNumber) (int, error) {
+	offset := 0
+	for offset < len(b) {
+		num, typ, n, err := decodeTag(b[offset:])
+		offset += n
+		if err != nil {
+			return offset, err
+		}
+		if typ == endGroup {
+			if num != f {
+				return offset, errGroupMismatch
+			}
+			return offset, nil
+		}
+		n, err = skipField(b[offset:], num, typ)
+		offset += n
+		if err != nil {
+			return offset, err
+		}
+	}
+	return len(b), io.ErrUnexpectedEOF
+}
+
+// skipField returns the length of the value of a field with wire type t.
+func skipField(b []byte, f fieldNumber, t wireType) (int, error) {
+	switch t {
+	case varint:
+		_, n, err := decodeVarint(b)
+		return n, err
+	case varlen:
+		_, n, err := decodeVarlen(b)
+		return n, err
+	case fixed32:
+		if len(b) < 4 {
+			return len(b), io.ErrUnexpectedEOF
+		}
+		return 4, nil
+	case fixed64:
+		if len(b) < 8 {
+			return len(b), io.ErrUnexpectedEOF
+		}
+		return 8, nil
+	case startGroup:
+		return decodeGroup(b, f)
+	default:
+		return 0, ErrWireTypeUnknown
+	}
+}
@@ -2,6 +2,7 @@ package proto
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 )
@@ -93,6 +94,33 @@ func BenchmarkDecodeMap(b *testing.B) {
 	}
 }
 
+// BenchmarkDecodeMapBackend is the decoding counterpart of
+// BenchmarkEncodeMapBackend.
+func BenchmarkDecodeMapBackend(b *testing.B) {
+	type message struct {
+		M map[int32]string `protobuf:"bytes,1,rep" protobuf_key:"varint,1,opt" protobuf_val:"bytes,2,opt"`
+	}
+
+	for _, n := range []int{1, 10, 1000} {
+		m := make(map[int32]string, n)
+		for i := 0; i < n; i++ {
+			m[int32(i)] = "value"
+		}
+		data, _ := Marshal(&message{M: m})
+
+		b.Run(fmt.Sprintf("%s/%d", mapBackend, n), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				msg := message{}
+				if err := Unmarshal(data, &msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkDecodeSlice(b *testing.B) {
 	type message struct {
 		S []int32 `protobuf:"varint,1,opt"`
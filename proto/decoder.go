@@ -0,0 +1,220 @@
+package proto
+
+import (
+	"fmt"
+	"io"
+)
+
+// WireType is the wire type of an encoded field.
+type WireType uint
+
+const (
+	VarintType     = WireType(varint)
+	Fixed64Type    = WireType(fixed64)
+	BytesType      = WireType(varlen)
+	StartGroupType = WireType(startGroup)
+	EndGroupType   = WireType(endGroup)
+	Fixed32Type    = WireType(fixed32)
+)
+
+func (t WireType) String() string {
+	if t == EndGroupType {
+		return "end group"
+	}
+	return wireType(t).String()
+}
+
+// Decoder reads the fields of an encoded message one at a time, without
+// decoding them into a struct. It doesn't allocate: the byte slices it
+// returns alias the encoded message.
+//
+// Next moves to the next field, and one of Varint, Fixed32, Fixed64, Bytes,
+// Message or Skip reads its value. The value of a field which is not read is
+// skipped by the following call to Next.
+type Decoder struct {
+	b    []byte // remaining input, starting with the value of the field
+	num  fieldNumber
+	typ  wireType
+	read bool  // whether the value of the field has been read
+	err  error // first error, returned by every call to Next
+}
+
+// NewDecoder returns a Decoder reading the encoded message b.
+func NewDecoder(b []byte) Decoder {
+	return Decoder{b: b, read: true}
+}
+
+// Next moves to the next field and returns its number and wire type. It
+// returns io.EOF after the last field.
+func (d *Decoder) Next() (num int, typ WireType, err error) {
+	if d.err != nil {
+		return 0, 0, d.err
+	}
+	if !d.read {
+		if err := d.Skip(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if len(d.b) == 0 {
+		return 0, 0, io.EOF
+	}
+	f, t, n, err := decodeTag(d.b)
+	if err == nil && f == 0 {
+		err = fmt.Errorf("invalid field number 0")
+	}
+	if err == nil && t == endGroup {
+		err = errGroupMismatch
+	}
+	if err != nil {
+		d.err = fieldError(f, t, err)
+		return 0, 0, d.err
+	}
+	d.b, d.num, d.typ, d.read = d.b[n:], f, t, false
+	return int(f), WireType(t), nil
+}
+
+// Varint returns the value of a field of wire type VarintType. The value of
+// the sint32 and sint64 fields is zigzag encoded, see DecodeZigZag.
+func (d *Decoder) Varint() (uint64, error) {
+	if err := d.expect(varint); err != nil {
+		return 0, err
+	}
+	v, n, err := decodeVarint(d.b)
+	return v, d.consume(n, err)
+}
+
+// Fixed32 returns the value of a field of wire type Fixed32Type.
+func (d *Decoder) Fixed32() (uint32, error) {
+	if err := d.expect(fixed32); err != nil {
+		return 0, err
+	}
+	v, n, err := decodeLE32(d.b)
+	return v, d.consume(n, err)
+}
+
+// Fixed64 returns the value of a field of wire type Fixed64Type.
+func (d *Decoder) Fixed64() (uint64, error) {
+	if err := d.expect(fixed64); err != nil {
+		return 0, err
+	}
+	v, n, err := decodeLE64(d.b)
+	return v, d.consume(n, err)
+}
+
+// Bytes returns the value of a field of wire type BytesType, which aliases
+// the encoded message.
+func (d *Decoder) Bytes() ([]byte, error) {
+	if err := d.expect(varlen); err != nil {
+		return nil, err
+	}
+	v, n, err := decodeVarlen(d.b)
+	return v, d.consume(n, err)
+}
+
+// Message returns a Decoder reading the message of a field of wire type
+// BytesType or StartGroupType. If the value of the field cannot be read, the
+// Next method of the returned Decoder returns the error.
+func (d *Decoder) Message() Decoder {
+	if d.typ == startGroup && !d.read {
+		b := d.b
+		n, err := decodeGroup(b, d.num)
+		if err = d.consume(n, err); err != nil {
+			return Decoder{err: err}
+		}
+		// the group ends with its end group tag
+		end := sizeOfVarint(uint64(d.num)<<3 | uint64(endGroup))
+		return NewDecoder(b[:n-end])
+	}
+	b, err := d.Bytes()
+	if err != nil {
+		return Decoder{err: err}
+	}
+	return NewDecoder(b)
+}
+
+// Skip skips the value of the field.
+func (d *Decoder) Skip() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.read {
+		return nil
+	}
+	n, err := skipField(d.b, d.num, d.typ)
+	return d.consume(n, err)
+}
+
+func (d *Decoder) expect(t wireType) error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.read {
+		return fmt.Errorf("proto.Decoder: no field to read")
+	}
+	if d.typ != t {
+		return fieldError(d.num, d.typ, fmt.Errorf("expected wire type %d", t))
+	}
+	return nil
+}
+
+// consume advances past the n bytes of the value of the field, or records
+// the error reading it.
+func (d *Decoder) consume(n int, err error) error {
+	if err != nil {
+		d.err = fieldError(d.num, d.typ, err)
+		return d.err
+	}
+	d.b, d.read = d.b[n:], true
+	return nil
+}
+
+// DecodeZigZag decodes a zigzag encoded varint, the encoding of the sint32
+// and sint64 fields.
+func DecodeZigZag(v uint64) int64 {
+	return decodeZigZag64(v)
+}
+
+// DecodeEach decodes the elements of the repeated message field num of the
+// encoded message b one at a time into m, which is reset before each one, and
+// calls fn with it. Unlike Unmarshal, it doesn't hold all the elements in
+// memory at once. It stops at the first error of fn or of the decoding.
+func DecodeEach[T any](b []byte, num int, m *T, fn func(*T) error) error {
+	c, err := CodecFor[T]()
+	if err != nil {
+		return err
+	}
+	d := NewDecoder(b)
+	for {
+		n, typ, err := d.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if n != num {
+			continue
+		}
+		var v []byte
+		switch typ {
+		case BytesType:
+			v, err = d.Bytes()
+		case StartGroupType:
+			md := d.Message()
+			v, err = md.b, md.err
+		default:
+			err = fieldError(d.num, d.typ, fmt.Errorf("expected a message"))
+		}
+		if err != nil {
+			return err
+		}
+		var zero T
+		*m = zero
+		if err := c.Unmarshal(v, m); err != nil {
+			return err
+		}
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package proto_test
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+type decoderItem struct {
+	ID   int64  `protobuf:"varint,1,opt"`
+	Name string `protobuf:"bytes,2,opt"`
+}
+
+type decoderBatch struct {
+	Seq   uint64         `protobuf:"varint,1,opt"`
+	Items []*decoderItem `protobuf:"bytes,2,rep"`
+	Delta int64          `protobuf:"zigzag64,3,opt"`
+	Ratio float32        `protobuf:"fixed32,4,opt"`
+	Sum   float64        `protobuf:"fixed64,5,opt"`
+}
+
+func TestDecoder(t *testing.T) {
+	b, err := Marshal(&decoderBatch{
+		Seq:   42,
+		Items: []*decoderItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}},
+		Delta: -3,
+		Ratio: 0.5,
+		Sum:   1.5,
+	})
+	require.NoError(t, err)
+
+	d := NewDecoder(b)
+	var names []string
+	for {
+		num, typ, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		switch num {
+		case 1:
+			assert.Equal(t, VarintType, typ)
+			v, err := d.Varint()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(42), v)
+		case 2:
+			assert.Equal(t, BytesType, typ)
+			m := d.Message()
+			for {
+				num, _, err := m.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				if num == 2 {
+					name, err := m.Bytes()
+					require.NoError(t, err)
+					names = append(names, string(name))
+				}
+			}
+		case 3:
+			v, err := d.Varint()
+			require.NoError(t, err)
+			assert.Equal(t, int64(-3), DecodeZigZag(v))
+		case 4:
+			assert.Equal(t, Fixed32Type, typ)
+			v, err := d.Fixed32()
+			require.NoError(t, err)
+			assert.Equal(t, float32(0.5), math.Float32frombits(v))
+		case 5:
+			// skipped by the next call to Next
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+func TestDecoderGroup(t *testing.T) {
+	// field 1 is a group holding the varint field 2, field 3 follows it
+	b := []byte{0x0b, 0x10, 0x07, 0x0c, 0x18, 0x01}
+
+	d := NewDecoder(b)
+	num, typ, err := d.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 1, num)
+	assert.Equal(t, StartGroupType, typ)
+	g := d.Message()
+	num, _, err = g.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 2, num)
+	v, err := g.Varint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), v)
+	_, _, err = g.Next()
+	assert.Equal(t, io.EOF, err)
+
+	num, _, err = d.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, num)
+	_, _, err = d.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderErrors(t *testing.T) {
+	d := NewDecoder([]byte{0x08, 0x01})
+	_, _, err := d.Next()
+	require.NoError(t, err)
+	_, err = d.Bytes()
+	var fe *UnmarshalFieldError
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, 1, fe.FieldNumber)
+	// the value can still be read with the right wire type
+	v, err := d.Varint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), v)
+
+	// a truncated message fails, and keeps failing
+	d = NewDecoder([]byte{0x0a, 0x05, 'a'})
+	_, _, err = d.Next()
+	require.NoError(t, err)
+	m := d.Message()
+	_, _, err = m.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, _, err = d.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDecoderAllocs(t *testing.T) {
+	b, err := Marshal(&testproto.Envelope{Topic: "route"})
+	require.NoError(t, err)
+	allocs := testing.AllocsPerRun(100, func() {
+		d := NewDecoder(b)
+		for {
+			num, _, err := d.Next()
+			if err != nil {
+				break
+			}
+			if num == 1 {
+				_, _ = d.Bytes()
+			}
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestDecodeEach(t *testing.T) {
+	b, err := Marshal(&decoderBatch{
+		Seq:   1,
+		Items: []*decoderItem{{ID: 1, Name: "a"}, {ID: 2}, {Name: "c"}},
+	})
+	require.NoError(t, err)
+
+	var item decoderItem
+	var got []decoderItem
+	err = DecodeEach(b, 2, &item, func(m *decoderItem) error {
+		assert.Same(t, &item, m)
+		got = append(got, *m)
+		return nil
+	})
+	require.NoError(t, err)
+	// the struct is reset between the elements
+	assert.Equal(t, []decoderItem{{ID: 1, Name: "a"}, {ID: 2}, {Name: "c"}}, got)
+
+	stop := errors.New("stop")
+	n := 0
+	err = DecodeEach(b, 2, &item, func(*decoderItem) error {
+		n++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, n)
+
+	err = DecodeEach(b, 1, &item, func(*decoderItem) error { return nil })
+	assert.Error(t, err)
+}
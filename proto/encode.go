@@ -1,15 +1,9 @@
 package proto
 
-import (
-	"unsafe"
-)
-
 func encodeZigZag64(v int64) uint64 {
 	return (uint64(v) << 1) ^ uint64(v>>63)
 }
 
-type encodeFunc = func([]byte, unsafe.Pointer, *structField) []byte
-
 func appendVarint(b []byte, v uint64) []byte {
 	switch {
 	case v < 1<<7:
@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"fmt"
 	"math"
 	"testing"
 )
@@ -62,6 +63,30 @@ func BenchmarkEncodeMessage(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeMessageCodec(b *testing.B) {
+	msg := &message{
+		A: 1,
+		B: 100,
+		C: 10000,
+		S: &submessage{
+			X: "",
+			Y: "Hello World!",
+		},
+	}
+
+	c, err := CodecFor[message]()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(c.Size(msg)))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Marshal(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkEncodeMap(b *testing.B) {
 	msg := struct {
 		M map[string]string `protobuf:"bytes,1,opt" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
@@ -81,6 +106,32 @@ func BenchmarkEncodeMap(b *testing.B) {
 	}
 }
 
+// BenchmarkEncodeMapBackend measures the map codecs of the build, named in
+// the benchmark name, run with -tags protosafemap or purego to compare the
+// backends.
+func BenchmarkEncodeMapBackend(b *testing.B) {
+	for _, n := range []int{1, 10, 1000} {
+		msg := struct {
+			M map[int32]string `protobuf:"bytes,1,rep" protobuf_key:"varint,1,opt" protobuf_val:"bytes,2,opt"`
+		}{
+			M: make(map[int32]string, n),
+		}
+		for i := 0; i < n; i++ {
+			msg.M[int32(i)] = "value"
+		}
+
+		b.Run(fmt.Sprintf("%s/%d", mapBackend, n), func(b *testing.B) {
+			b.SetBytes(int64(Size(&msg)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Marshal(&msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkEncodeSlice(b *testing.B) {
 	msg := struct {
 		S []int32 `protobuf:"varint,1,rep"`
@@ -0,0 +1,277 @@
+package proto
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Extensions stores the extension fields of a message, it is the type of
+// the XXX_InternalExtensions field generated for messages with extension
+// ranges:
+//
+//	XXX_InternalExtensions proto.Extensions `protobuf_extensions:"100-199"`
+//
+// The struct tag lists the inclusive ranges of the extension field numbers.
+// The fields in these ranges are kept encoded when unmarshalling, they are
+// decoded on the first GetExtension and re-encoded by Marshal.
+type Extensions struct {
+	fields map[fieldNumber]*extensionField
+}
+
+type extensionField struct {
+	// mu guards the decoding of raw into value by GetExtension, which may be
+	// called by several goroutines at once.
+	mu sync.Mutex
+	// raw is the encoding of the field, possibly repeated, until it is
+	// decoded into value.
+	raw []byte
+	// value points to the extensionValue struct of the ExtensionDesc, with
+	// info to encode it.
+	value interface{}
+	info  *structInfo
+}
+
+// state returns the encoded field, or the decoded value and its info.
+func (f *extensionField) state() ([]byte, interface{}, *structInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.raw, f.value, f.info
+}
+
+func (x *Extensions) size() int {
+	n := 0
+	for _, f := range x.fields {
+		if raw, value, info := f.state(); value != nil {
+			n += info.messageSize(value)
+		} else {
+			n += len(raw)
+		}
+	}
+	return n
+}
+
+func (x *Extensions) encode(b []byte) []byte {
+	if len(x.fields) == 0 {
+		return b
+	}
+	numbers := make([]fieldNumber, 0, len(x.fields))
+	for n := range x.fields {
+		numbers = append(numbers, n)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	for _, n := range numbers {
+		if raw, value, info := x.fields[n].state(); value != nil {
+			b = info.appendMessage(b, value)
+		} else {
+			b = append(b, raw...)
+		}
+	}
+	return b
+}
+
+// appendRaw appends the encoding of the field n, if it was decoded the new
+// bytes are merged into the value.
+func (x *Extensions) appendRaw(n fieldNumber, b []byte) error {
+	if x.fields == nil {
+		x.fields = make(map[fieldNumber]*extensionField)
+	}
+	f := x.fields[n]
+	if f == nil {
+		f = new(extensionField)
+		x.fields[n] = f
+	}
+	if f.value != nil {
+		_, err := f.info.decodeMessage(b, f.value)
+		return err
+	}
+	f.raw = append(f.raw, b...)
+	return nil
+}
+
+// extensionRanges locates the Extensions field of a message.
+type extensionRanges struct {
+	offset uintptr
+	index  int
+	ranges [][2]fieldNumber
+}
+
+func (r *extensionRanges) contains(n fieldNumber) bool {
+	for _, rg := range r.ranges {
+		if rg[0] <= n && n <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+var extensionsType = reflect.TypeOf(Extensions{})
+
+func extensionRangesOf(f reflect.StructField) *extensionRanges {
+	r := &extensionRanges{offset: f.Offset, index: f.Index[0]}
+	tag := f.Tag.Get("protobuf_extensions")
+	for _, s := range strings.Split(tag, ",") {
+		lo, hi, ok := strings.Cut(s, "-")
+		if !ok {
+			hi = lo
+		}
+		start, err1 := strconv.ParseUint(lo, 10, 32)
+		end, err2 := strconv.ParseUint(hi, 10, 32)
+		if err1 != nil || err2 != nil || start > end {
+			panic(fmt.Errorf("unsupported extension range in struct tag %q: %s", tag, s))
+		}
+		r.ranges = append(r.ranges, [2]fieldNumber{fieldNumber(start), fieldNumber(end)})
+	}
+	return r
+}
+
+// ExtensionDesc describes an extension field of type T of the message type
+// M. The descriptors are generated by protoc-gen-golite:
+//
+//	var E_Foo = &proto.ExtensionDesc[Base, int32]{
+//		Name: "pkg.foo",
+//		Tag:  "varint,100,opt",
+//	}
+//
+// Singular scalar extensions are stored with presence, the zero value of an
+// extension that is set is encoded.
+type ExtensionDesc[M, T any] struct {
+	// Name is the full name of the extension field.
+	Name string
+	// Tag is the protobuf struct tag of the field.
+	Tag string
+
+	once   sync.Once
+	number fieldNumber
+	info   *structInfo
+	typ    reflect.Type // extensionValue[T] or extensionValue[Option[T]]
+	option bool
+}
+
+// extensionValue holds the value of an extension field, its struct tag is
+// set by the ExtensionDesc with reflect.StructOf.
+type extensionValue[T any] struct {
+	V T
+}
+
+func (xd *ExtensionDesc[M, T]) init() {
+	xd.once.Do(func() {
+		t, err := parseStructTag(xd.Tag)
+		if err != nil {
+			panic(err)
+		}
+		xd.number = t.fieldNumber
+
+		vt := reflect.TypeOf((*T)(nil)).Elem()
+		switch vt.Kind() {
+		case reflect.Bool, reflect.Int32, reflect.Int64, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String:
+			xd.option = true
+			vt = reflect.TypeOf(Option[T]{})
+		}
+		xd.typ = reflect.StructOf([]reflect.StructField{{
+			Name: "V",
+			Type: vt,
+			Tag:  reflect.StructTag(`protobuf:"` + xd.Tag + `"`),
+		}})
+		xd.info = cachedStructInfoOf(xd.typ)
+	})
+}
+
+// get returns the value of the extensionValue p of type xd.typ, which only
+// differs from extensionValue[T] by its struct tag.
+func (xd *ExtensionDesc[M, T]) get(p interface{}) T {
+	v := reflect.ValueOf(p)
+	if xd.option {
+		return v.Convert(reflect.TypeOf((*extensionValue[Option[T]])(nil))).Interface().(*extensionValue[Option[T]]).V.Unwrap()
+	}
+	return v.Convert(reflect.TypeOf((*extensionValue[T])(nil))).Interface().(*extensionValue[T]).V
+}
+
+func (xd *ExtensionDesc[M, T]) set(p interface{}, v T) {
+	pv := reflect.ValueOf(p)
+	if xd.option {
+		pv.Convert(reflect.TypeOf((*extensionValue[Option[T]])(nil))).Interface().(*extensionValue[Option[T]]).V = Some(v)
+	} else {
+		pv.Convert(reflect.TypeOf((*extensionValue[T])(nil))).Interface().(*extensionValue[T]).V = v
+	}
+}
+
+// extensionsOf returns the extension store of m, nil if its type has no
+// extension ranges.
+func extensionsOf[M any](m *M) (*Extensions, *extensionRanges) {
+	info := cachedStructInfoOf(reflect.TypeOf(m).Elem())
+	if info.extensions == nil || m == nil {
+		return nil, info.extensions
+	}
+	return info.extensions.of(m), info.extensions
+}
+
+// HasExtension reports whether the extension field xd is set in m.
+func HasExtension[M, T any](m *M, xd *ExtensionDesc[M, T]) bool {
+	xd.init()
+	x, _ := extensionsOf(m)
+	return x != nil && x.fields[xd.number] != nil
+}
+
+// GetExtension returns the value of the extension field xd in m, the zero
+// value of T if it is not set or can't be decoded, see GetExtensionErr.
+func GetExtension[M, T any](m *M, xd *ExtensionDesc[M, T]) T {
+	v, _ := GetExtensionErr(m, xd)
+	return v
+}
+
+// GetExtensionErr is like GetExtension, but also returns the error of the
+// decoding of the field. The first successful call decodes the field into m,
+// a field that fails to decode is kept encoded and re-emitted as is by
+// Marshal. It may be called by several goroutines at once, but not while m
+// is being modified.
+func GetExtensionErr[M, T any](m *M, xd *ExtensionDesc[M, T]) (T, error) {
+	xd.init()
+	var zero T
+	x, _ := extensionsOf(m)
+	if x == nil {
+		return zero, nil
+	}
+	f := x.fields[xd.number]
+	if f == nil {
+		return zero, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.value == nil {
+		v := reflect.New(xd.typ).Interface()
+		if _, err := xd.info.decodeMessage(f.raw, v); err != nil {
+			return zero, fmt.Errorf("proto.GetExtension(%s): %w", xd.Name, err)
+		}
+		f.raw, f.value, f.info = nil, v, xd.info
+	}
+	return xd.get(f.value), nil
+}
+
+// SetExtension sets the extension field xd of m to v. It panics if the type
+// of m has no extension range containing the field.
+func SetExtension[M, T any](m *M, xd *ExtensionDesc[M, T], v T) {
+	xd.init()
+	x, r := extensionsOf(m)
+	if r == nil || !r.contains(xd.number) {
+		panic(fmt.Errorf("proto.SetExtension(%T): field %d of %s is not in an extension range", m, xd.number, xd.Name))
+	}
+	if x.fields == nil {
+		x.fields = make(map[fieldNumber]*extensionField)
+	}
+	p := reflect.New(xd.typ).Interface()
+	xd.set(p, v)
+	x.fields[xd.number] = &extensionField{value: p, info: xd.info}
+}
+
+// ClearExtension clears the extension field xd of m.
+func ClearExtension[M, T any](m *M, xd *ExtensionDesc[M, T]) {
+	xd.init()
+	if x, _ := extensionsOf(m); x != nil {
+		delete(x.fields, xd.number)
+	}
+}
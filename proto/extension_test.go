@@ -0,0 +1,135 @@
+package proto_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+type extendable struct {
+	A                      proto.Option[int32] `protobuf:"varint,1,opt"`
+	XXX_InternalExtensions proto.Extensions    `protobuf_extensions:"100-199,1000"`
+}
+
+var (
+	extInt32 = &proto.ExtensionDesc[extendable, int32]{
+		Name: "test.int32",
+		Tag:  "varint,100,opt",
+	}
+	extSint64s = &proto.ExtensionDesc[extendable, []int64]{
+		Name: "test.sint64s",
+		Tag:  "zigzag64,101,rep",
+	}
+	extString = &proto.ExtensionDesc[extendable, string]{
+		Name: "test.string",
+		Tag:  "bytes,1000,opt",
+	}
+	extMessage = &proto.ExtensionDesc[extendable, *message]{
+		Name: "test.message",
+		Tag:  "bytes,102,opt",
+	}
+	extGroup = &proto.ExtensionDesc[extendable, *groupData]{
+		Name: "test.group",
+		Tag:  "group,103,opt",
+	}
+	extOutOfRange = &proto.ExtensionDesc[extendable, int32]{
+		Name: "test.out_of_range",
+		Tag:  "varint,200,opt",
+	}
+)
+
+func TestExtensions(t *testing.T) {
+	m := &extendable{A: proto.Some[int32](1)}
+	assert.False(t, proto.HasExtension(m, extInt32))
+	assert.Equal(t, int32(0), proto.GetExtension(m, extInt32))
+
+	proto.SetExtension(m, extInt32, 0)
+	proto.SetExtension(m, extSint64s, []int64{-1, 2})
+	proto.SetExtension(m, extString, "s")
+	proto.SetExtension(m, extMessage, &message{A: 3})
+	proto.SetExtension(m, extGroup, &groupData{X: 4, Y: "y"})
+	assert.True(t, proto.HasExtension(m, extInt32))
+	assert.Equal(t, int32(0), proto.GetExtension(m, extInt32))
+	assert.Panics(t, func() { proto.SetExtension(m, extOutOfRange, 1) })
+
+	b, err := proto.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, len(b), proto.Size(m))
+
+	// unknown fields out of the extension ranges are dropped
+	b2 := append(append([]byte{}, b...), 0xc0, 0x0c, 0x05) // field 200 = 5
+	got := &extendable{}
+	assert.NoError(t, proto.Unmarshal(b2, got))
+	assert.Equal(t, proto.Some[int32](1), got.A)
+
+	// the extensions are re-encoded unchanged until they are decoded
+	b3, err := proto.Marshal(got)
+	assert.NoError(t, err)
+	assert.Equal(t, b, b3)
+
+	assert.True(t, proto.HasExtension(got, extInt32))
+	assert.Equal(t, int32(0), proto.GetExtension(got, extInt32))
+	assert.Equal(t, []int64{-1, 2}, proto.GetExtension(got, extSint64s))
+	assert.Equal(t, "s", proto.GetExtension(got, extString))
+	assert.Equal(t, &message{A: 3}, proto.GetExtension(got, extMessage))
+	assert.Equal(t, &groupData{X: 4, Y: "y"}, proto.GetExtension(got, extGroup))
+	assert.False(t, proto.HasExtension(got, extOutOfRange))
+
+	// repeated extensions are merged
+	assert.NoError(t, proto.Unmarshal(b, got))
+	assert.Equal(t, []int64{-1, 2, -1, 2}, proto.GetExtension(got, extSint64s))
+
+	proto.ClearExtension(got, extString)
+	assert.False(t, proto.HasExtension(got, extString))
+	assert.Equal(t, "", proto.GetExtension(got, extString))
+
+	var nilMessage *extendable
+	assert.False(t, proto.HasExtension(nilMessage, extInt32))
+	assert.Equal(t, int32(0), proto.GetExtension(nilMessage, extInt32))
+}
+
+func TestGetExtensionConcurrent(t *testing.T) {
+	m := &extendable{}
+	proto.SetExtension(m, extString, "s")
+	proto.SetExtension(m, extSint64s, []int64{1, 2})
+	b, err := proto.Marshal(m)
+	assert.NoError(t, err)
+
+	got := &extendable{}
+	assert.NoError(t, proto.Unmarshal(b, got))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "s", proto.GetExtension(got, extString))
+			assert.Equal(t, []int64{1, 2}, proto.GetExtension(got, extSint64s))
+			_, err := proto.Marshal(got)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetExtensionErr(t *testing.T) {
+	// field 102 holds a message with a truncated varint
+	b := []byte{0xb2, 0x06, 0x01, 0x08}
+	m := &extendable{}
+	assert.NoError(t, proto.Unmarshal(b, m))
+
+	v, err := proto.GetExtensionErr(m, extMessage)
+	assert.Error(t, err)
+	assert.Nil(t, v)
+	assert.Nil(t, proto.GetExtension(m, extMessage))
+	// the field is kept as is
+	c, err := proto.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, b, c)
+
+	i, err := proto.GetExtensionErr(m, extInt32)
+	assert.NoError(t, err)
+	assert.Zero(t, i)
+}
@@ -0,0 +1,250 @@
+package proto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maskNode is a field of a field mask, sub holds the fields of the nested
+// message named by longer paths, it is nil if the whole field is covered.
+type maskNode struct {
+	sub map[int]*maskNode
+}
+
+// parseMask builds the tree of the paths for the struct type t.
+func parseMask(t reflect.Type, paths []string) (map[int]*maskNode, error) {
+	root := make(map[int]*maskNode)
+	for _, path := range paths {
+		nodes, typ := root, t
+		segs := strings.Split(path, ".")
+		for i, seg := range segs {
+			f := fieldByPathName(typ, seg)
+			if f == nil {
+				return nil, fmt.Errorf("invalid path %q: %s has no field %s", path, typ, seg)
+			}
+			last := i == len(segs)-1
+			if !last && (f.Kind != MessageKind && f.Kind != GroupKind || f.Cardinality == Repeated) {
+				return nil, fmt.Errorf("invalid path %q: %s is not a singular message field", path, seg)
+			}
+			node, ok := nodes[f.Number]
+			switch {
+			case !ok:
+				node = new(maskNode)
+				if !last {
+					node.sub = make(map[int]*maskNode)
+				}
+				nodes[f.Number] = node
+			case node.sub == nil:
+				// the whole field is already covered
+			case last:
+				node.sub = nil
+			}
+			if node.sub == nil {
+				break
+			}
+			nodes, typ = node.sub, baseTypeOf(f.Type)
+		}
+	}
+	return root, nil
+}
+
+func fieldByPathName(t reflect.Type, name string) *Field {
+	descs := messageInfo(t).descs
+	for i := range descs {
+		if f := &descs[i]; snakeCase(f.Name) == name || f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// messageValue returns the struct pointed to by m, which must be a non-nil
+// pointer to a message.
+func messageValue(m interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct || v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%T is not a non-nil pointer to a message", m)
+	}
+	return v.Elem(), nil
+}
+
+// CheckPaths returns an error if one of the field mask paths doesn't name a
+// field of the message m. The elements of the paths are the proto names of
+// the fields, the snake_case version of their Go names like in SchemaOf, or
+// their Go names. Every element but the last one must be a singular message
+// field, repeated fields and maps can only be last.
+func CheckPaths(m interface{}, paths []string) error {
+	t := reflect.TypeOf(m)
+	if t == nil || baseTypeOf(t).Kind() != reflect.Struct {
+		return fmt.Errorf("proto.CheckPaths: %T is not a message", m)
+	}
+	if _, err := parseMask(baseTypeOf(t), paths); err != nil {
+		return fmt.Errorf("proto.CheckPaths(%T): %w", m, err)
+	}
+	return nil
+}
+
+// Prune clears the fields of the message m which are not covered by the
+// field mask paths, so that only the masked fields are marshaled.
+func Prune(m interface{}, paths []string) error {
+	v, err := messageValue(m)
+	if err != nil {
+		return fmt.Errorf("proto.Prune: %w", err)
+	}
+	mask, err := parseMask(v.Type(), paths)
+	if err != nil {
+		return fmt.Errorf("proto.Prune(%T): %w", m, err)
+	}
+	pruneMessage(v, mask)
+	return nil
+}
+
+func pruneMessage(v reflect.Value, mask map[int]*maskNode) {
+	descs := messageInfo(v.Type()).descs
+	for i := range descs {
+		f := &descs[i]
+		node := mask[f.Number]
+		switch {
+		case node == nil:
+			clearField(v, f)
+		case node.sub != nil && hasField(v, f):
+			pruneMessage(getField(v, f).Elem(), node.sub)
+		}
+	}
+}
+
+// MergeMasked copies the fields covered by the field mask paths from the
+// message src to the message dst, which must be of the same type, following
+// the update semantics of google.protobuf.FieldMask:
+//
+//   - scalars, options and oneof members are replaced, unset fields of src
+//     clear the fields of dst
+//   - the elements of repeated fields are appended and the entries of maps
+//     are added
+//   - a message named by the last element of a path is merged into the
+//     message of dst, like Unmarshal merges, or cleared if it is unset in src
+//
+// The values of src are copied, dst doesn't share memory with src.
+func MergeMasked(dst, src interface{}, paths []string) error {
+	dv, err := messageValue(dst)
+	if err != nil {
+		return fmt.Errorf("proto.MergeMasked: %w", err)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() != reflect.Ptr || sv.Type() != reflect.TypeOf(dst) {
+		return fmt.Errorf("proto.MergeMasked(%T): cannot merge %T", dst, src)
+	}
+	mask, err := parseMask(dv.Type(), paths)
+	if err != nil {
+		return fmt.Errorf("proto.MergeMasked(%T): %w", dst, err)
+	}
+	if sv.IsNil() {
+		sv = reflect.New(dv.Type())
+	}
+	return mergeMasked(dv, sv.Elem(), mask)
+}
+
+func mergeMasked(dv, sv reflect.Value, mask map[int]*maskNode) error {
+	descs := messageInfo(dv.Type()).descs
+	for i := range descs {
+		f := &descs[i]
+		node := mask[f.Number]
+		if node == nil {
+			continue
+		}
+		if node.sub == nil {
+			if err := mergeField(dv, sv, f); err != nil {
+				return err
+			}
+			continue
+		}
+
+		srcSet, dstSet := hasField(sv, f), hasField(dv, f)
+		if !srcSet && !dstSet {
+			continue
+		}
+		if !dstSet {
+			setField(dv, f, reflect.New(baseTypeOf(f.Type)))
+		}
+		sm := reflect.New(baseTypeOf(f.Type))
+		if srcSet {
+			sm = getField(sv, f)
+		}
+		if err := mergeMasked(getField(dv, f).Elem(), sm.Elem(), node.sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeField merges the field f of the struct sv into the struct dv.
+func mergeField(dv, sv reflect.Value, f *Field) error {
+	if !hasField(sv, f) {
+		if f.Cardinality != Repeated {
+			clearField(dv, f)
+		}
+		return nil
+	}
+	x := getField(sv, f)
+	switch {
+	case f.Kind == MapKind:
+		m := dv.Field(f.index)
+		if m.IsNil() {
+			m.Set(reflect.MakeMapWithSize(f.Type, x.Len()))
+		}
+		iter := x.MapRange()
+		for iter.Next() {
+			val, err := cloneValue(iter.Value())
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(iter.Key(), val)
+		}
+	case f.Cardinality == Repeated:
+		l := dv.Field(f.index)
+		for i := 0; i < x.Len(); i++ {
+			e, err := cloneValue(x.Index(i))
+			if err != nil {
+				return err
+			}
+			l.Set(reflect.Append(l, e))
+		}
+	case (f.Kind == MessageKind || f.Kind == GroupKind) && hasField(dv, f):
+		b, err := Marshal(x.Interface())
+		if err != nil {
+			return err
+		}
+		return Unmarshal(b, getField(dv, f).Interface())
+	default:
+		val, err := cloneValue(x)
+		if err != nil {
+			return err
+		}
+		setField(dv, f, val)
+	}
+	return nil
+}
+
+// cloneValue returns a deep copy of the messages and bytes, other values
+// are returned as is.
+func cloneValue(x reflect.Value) (reflect.Value, error) {
+	switch {
+	case x.Kind() == reflect.Ptr && x.Type().Elem().Kind() == reflect.Struct:
+		if x.IsNil() {
+			return x, nil
+		}
+		b, err := Marshal(x.Interface())
+		if err != nil {
+			return x, err
+		}
+		c := reflect.New(x.Type().Elem())
+		return c, Unmarshal(b, c.Interface())
+	case x.Kind() == reflect.Slice && x.Type().Elem().Kind() == reflect.Uint8:
+		if x.IsNil() {
+			return x, nil
+		}
+		return reflect.ValueOf(append([]byte{}, x.Bytes()...)).Convert(x.Type()), nil
+	}
+	return x, nil
+}
@@ -0,0 +1,89 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestCheckPaths(t *testing.T) {
+	assert.NoError(t, CheckPaths(&testproto.User{}, []string{"name", "address.city", "Score", "others", "phone"}))
+	assert.EqualError(t, CheckPaths(&testproto.User{}, []string{"address.town"}),
+		`proto.CheckPaths(*testproto.User): invalid path "address.town": testproto.Address has no field town`)
+	assert.EqualError(t, CheckPaths(&testproto.User{}, []string{"previous.city"}),
+		`proto.CheckPaths(*testproto.User): invalid path "previous.city": previous is not a singular message field`)
+	assert.Error(t, CheckPaths(&testproto.User{}, []string{"name.x"}))
+	assert.Error(t, CheckPaths(42, nil))
+}
+
+func TestPrune(t *testing.T) {
+	m := validUser()
+	m.Others = map[string]*testproto.Address{"home": {City: "Nice"}}
+	require.NoError(t, Prune(m, []string{"age", "address.city", "others", "office"}))
+	assert.Equal(t, &testproto.User{
+		Age:     20,
+		Address: &testproto.Address{City: "Paris"},
+		Others:  map[string]*testproto.Address{"home": {City: "Nice"}},
+	}, m)
+
+	assert.Error(t, Prune(m, []string{"x"}))
+	assert.Error(t, Prune(testproto.User{}, nil))
+}
+
+func TestMergeMasked(t *testing.T) {
+	dst := validUser()
+	dst.Previous = []*testproto.Address{{City: "Lyon"}}
+	dst.Others = map[string]*testproto.Address{"home": {City: "Nice"}}
+	src := &testproto.User{
+		Name:     "new",
+		Email:    "ignored",
+		Address:  &testproto.Address{Zip: 13000},
+		Previous: []*testproto.Address{{City: "Lille"}},
+		Others:   map[string]*testproto.Address{"work": {City: "Nantes"}},
+		Contact:  &testproto.User_Office{Office: &testproto.Address{City: "Brest"}},
+	}
+	paths := []string{"name", "age", "score", "address", "previous", "others", "office", "phone"}
+	require.NoError(t, MergeMasked(dst, src, paths))
+
+	want := validUser()
+	want.Name = "new"
+	want.Age = 0
+	want.Score = None[float64]()
+	want.Address = &testproto.Address{City: "Paris", Zip: 13000}
+	want.Previous = []*testproto.Address{{City: "Lyon"}, {City: "Lille"}}
+	want.Others = map[string]*testproto.Address{"home": {City: "Nice"}, "work": {City: "Nantes"}}
+	want.Contact = &testproto.User_Office{Office: &testproto.Address{City: "Brest"}}
+	assert.Equal(t, want, dst)
+
+	// the values are copied
+	src.Previous[0].City = "Metz"
+	src.Contact.(*testproto.User_Office).Office.City = "Caen"
+	assert.Equal(t, want, dst)
+
+	// unset messages clear the field, nested paths update a single field
+	require.NoError(t, MergeMasked(dst, &testproto.User{Address: &testproto.Address{City: "Lens"}}, []string{"office", "address.city"}))
+	assert.Nil(t, dst.Contact)
+	assert.Equal(t, &testproto.Address{City: "Lens", Zip: 13000}, dst.Address)
+
+	empty := &testproto.User{}
+	require.NoError(t, MergeMasked(empty, &testproto.User{Address: &testproto.Address{City: "Lens", Zip: 1}}, []string{"address.zip"}))
+	assert.Equal(t, &testproto.Address{Zip: 1}, empty.Address)
+
+	assert.Error(t, MergeMasked(dst, &testproto.Address{}, paths))
+	assert.Error(t, MergeMasked(dst, src, []string{"nope"}))
+}
+
+func TestMergeMaskedLazy(t *testing.T) {
+	var dst, src testproto.Envelope
+	dst.Payload.Set(&testproto.Payload{Body: "old", Values: []int32{1}})
+	src.Payload.Set(&testproto.Payload{Values: []int32{2}})
+	require.NoError(t, MergeMasked(&dst, &src, []string{"payload"}))
+	assert.Equal(t, &testproto.Payload{Body: "old", Values: []int32{1, 2}}, dst.GetPayload())
+
+	require.NoError(t, MergeMasked(&dst, &src, []string{"payload.body"}))
+	assert.Equal(t, "", dst.GetPayload().Body)
+}
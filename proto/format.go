@@ -0,0 +1,133 @@
+package proto
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces the values of the fields marked redact in the output of
+// Format and LogValue.
+const Redacted = "[REDACTED]"
+
+// Format returns a compact text rendering of the message m, for logs and
+// debugging, like
+//
+//	Id:1 Name:"golite" Tags:["a" "b"] Owner:{Id:2 Token:[REDACTED]}
+//
+// Only the populated fields are rendered, with their Go names. The values of
+// fields marked with the redact option of the protobuf struct tag, generated
+// for fields with the debug_redact option, are replaced by Redacted. The
+// output is not stable and must not be parsed.
+func Format(m interface{}) string {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return "<not a message>"
+	}
+	var b strings.Builder
+	formatMessage(&b, v)
+	return b.String()
+}
+
+func formatMessage(b *strings.Builder, m reflect.Value) {
+	if m.IsNil() {
+		b.WriteString("<nil>")
+		return
+	}
+	v := m.Elem()
+	first := true
+	for _, f := range messageInfo(m.Type()).descs {
+		f := f
+		if !hasField(v, &f) {
+			continue
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(f.Name)
+		b.WriteByte(':')
+		if f.Redact {
+			b.WriteString(Redacted)
+			continue
+		}
+		formatField(b, &f, getField(v, &f))
+	}
+}
+
+func formatField(b *strings.Builder, f *Field, x reflect.Value) {
+	switch {
+	case f.Kind == MapKind:
+		keys := x.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return lessValue(keys[i], keys[j]) })
+		b.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			formatValue(b, f.MapKey, k)
+			b.WriteByte(':')
+			formatValue(b, f.MapValue, x.MapIndex(k))
+		}
+		b.WriteByte('}')
+	case f.Cardinality == Repeated:
+		b.WriteByte('[')
+		for i := 0; i < x.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			formatValue(b, f.Kind, x.Index(i))
+		}
+		b.WriteByte(']')
+	default:
+		formatValue(b, f.Kind, x)
+	}
+}
+
+func formatValue(b *strings.Builder, k Kind, x reflect.Value) {
+	switch x.Kind() {
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(x.Bool()))
+	case reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(x.Int(), 10))
+	case reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(x.Uint(), 10))
+	case reflect.Float32:
+		b.WriteString(strconv.FormatFloat(x.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		b.WriteString(strconv.FormatFloat(x.Float(), 'g', -1, 64))
+	case reflect.String:
+		b.WriteString(strconv.Quote(x.String()))
+	case reflect.Slice: // bytes
+		b.WriteString(strconv.Quote(string(x.Bytes())))
+	case reflect.Ptr:
+		if k == MessageKind || k == GroupKind {
+			b.WriteByte('{')
+			formatMessage(b, x)
+			b.WriteByte('}')
+			return
+		}
+		if x.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		formatValue(b, k, x.Elem())
+	default:
+		b.WriteString("<unknown>")
+	}
+}
+
+func lessValue(x, y reflect.Value) bool {
+	switch x.Kind() {
+	case reflect.Bool:
+		return !x.Bool() && y.Bool()
+	case reflect.Int32, reflect.Int64:
+		return x.Int() < y.Int()
+	case reflect.Uint32, reflect.Uint64:
+		return x.Uint() < y.Uint()
+	case reflect.String:
+		return x.String() < y.String()
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package proto_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+type credentials struct {
+	User     string         `protobuf:"bytes,1,opt"`
+	Password string         `protobuf:"bytes,2,opt,redact"`
+	Pin      Option[int32]  `protobuf:"varint,3,opt,redact"`
+	Keys     [][]byte       `protobuf:"bytes,4,rep,redact"`
+	Previous []*credentials `protobuf:"bytes,5,rep"`
+}
+
+func TestFormat(t *testing.T) {
+	m := &testproto.Account{
+		Id:     1,
+		Name:   "golite",
+		Token:  "secret",
+		Tags:   []string{"a", "b"},
+		Scores: map[string]int32{"y": 2, "x": 1},
+		Owner:  &testproto.Account{Id: 2, Token: "secret"},
+		Data:   []byte("\x00"),
+		Admin:  Some(false),
+	}
+	want := `Id:1 Name:"golite" Token:[REDACTED] Tags:["a" "b"] Scores:{"x":1 "y":2} Owner:{Id:2 Token:[REDACTED]} Data:"\x00" Admin:false`
+	assert.Equal(t, want, Format(m))
+	assert.Equal(t, want, m.String())
+	assert.Equal(t, want, fmt.Sprint(m))
+	assert.Equal(t, "", Format(&testproto.Account{}))
+	assert.Equal(t, "<nil>", (*testproto.Account)(nil).String())
+
+	c := &credentials{
+		User:     "root",
+		Password: "hunter2",
+		Pin:      Some[int32](0),
+		Previous: []*credentials{{Password: "old"}, nil},
+	}
+	assert.Equal(t, `User:"root" Password:[REDACTED] Pin:[REDACTED] Previous:[{Password:[REDACTED]} {<nil>}]`, Format(c))
+	assert.Equal(t, "<not a message>", Format(credentials{}))
+}
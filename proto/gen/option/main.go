@@ -10,6 +10,8 @@ import (
 
 const decodeTmpl = `// Code generated by gen/option/main.go. DO NOT EDIT.
 
+//go:build !purego
+
 package proto
 
 import "unsafe"
@@ -64,6 +66,8 @@ func main() {
 		{Type: "int64", Name: "Zigzag64"},
 		{Type: "uint32", Name: "Fixed32"},
 		{Type: "uint64", Name: "Fixed64"},
+		{Type: "int32", Name: "Sfixed32"},
+		{Type: "int64", Name: "Sfixed64"},
 		{Type: "float32", Name: "Float32"},
 		{Type: "float64", Name: "Float64"},
 	}
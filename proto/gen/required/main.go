@@ -23,7 +23,7 @@ var needTransform = setOf(
 	"bool", "string",
 	"float32", "float64",
 	"int32", "int64", "uint32", "uint64",
-	"fixed32", "fixed64", "zigzag32", "zigzag64",
+	"fixed32", "fixed64", "sfixed32", "sfixed64", "zigzag32", "zigzag64",
 )
 
 func main() {
@@ -35,7 +35,7 @@ func main() {
 	transform(f)
 	outfs := token.NewFileSet()
 	outfile, _ := os.OpenFile("required_codec.go", os.O_WRONLY|os.O_CREATE|os.O_SYNC|os.O_TRUNC, 0o644)
-	io.WriteString(outfile, "// Code generated by gen/gen_decode.go. DO NOT EDIT.\n\n")
+	io.WriteString(outfile, "// Code generated by gen/gen_decode.go. DO NOT EDIT.\n\n//go:build !purego\n\n")
 	err = format.Node(outfile, outfs, f)
 	if err != nil {
 		panic(err)
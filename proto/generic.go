@@ -0,0 +1,100 @@
+package proto
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec is a precompiled handle to encode and decode messages of type T,
+// which must be a struct.
+//
+// Unlike Marshal and Unmarshal, the methods of a Codec don't inspect their
+// argument or look up the type cache, so it is worth keeping one around on
+// hot paths.
+type Codec[T any] struct {
+	info *structInfo
+}
+
+// codecs caches the Codec of each message type, a map[reflect.Type]*Codec[T].
+var codecs sync.Map
+
+// CodecFor returns the Codec for the message type T. It returns an error if
+// T is not a struct, Go has no constraint to reject such types at compile
+// time. The Codec of a type is built once and shared by the callers.
+func CodecFor[T any]() (*Codec[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if c, ok := codecs.Load(t); ok {
+		return c.(*Codec[T]), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("proto.CodecFor(%s): not a struct", t)
+	}
+	c, _ := codecs.LoadOrStore(t, &Codec[T]{info: cachedStructInfoOf(t)})
+	return c.(*Codec[T]), nil
+}
+
+// Size returns the length of the encoding of v.
+func (c *Codec[T]) Size(v *T) int {
+	return c.info.messageSize(v)
+}
+
+// Marshal returns the encoding of v.
+func (c *Codec[T]) Marshal(v *T) ([]byte, error) {
+	b := make([]byte, 0, c.info.messageSize(v))
+	return c.info.appendMessage(b, v), nil
+}
+
+// Append appends the encoding of v to b.
+func (c *Codec[T]) Append(b []byte, v *T) ([]byte, error) {
+	return c.info.appendMessage(b, v), nil
+}
+
+// Unmarshal parses the encoded message in b and stores the result in v.
+func (c *Codec[T]) Unmarshal(b []byte, v *T) error {
+	if v == nil {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	if len(b) == 0 {
+		// nothing to do
+		return nil
+	}
+	n, err := c.info.decodeMessage(b, v)
+	if err != nil {
+		return err
+	}
+	if n < len(b) {
+		return fmt.Errorf("proto.Unmarshal(%T): read=%d < buffer=%d", v, n, len(b))
+	}
+	return nil
+}
+
+// The helpers below look up the Codec of T on every call, use CodecFor to
+// keep the handle on hot paths.
+
+// SizeOf is like Size, but only accepts a pointer to a message.
+func SizeOf[T any](v *T) (int, error) {
+	c, err := CodecFor[T]()
+	if err != nil {
+		return 0, err
+	}
+	return c.Size(v), nil
+}
+
+// MarshalOf is like Marshal, but only accepts a pointer to a message.
+func MarshalOf[T any](v *T) ([]byte, error) {
+	c, err := CodecFor[T]()
+	if err != nil {
+		return nil, err
+	}
+	return c.Marshal(v)
+}
+
+// UnmarshalOf is like Unmarshal, but only accepts a pointer to a message.
+func UnmarshalOf[T any](b []byte, v *T) error {
+	c, err := CodecFor[T]()
+	if err != nil {
+		return err
+	}
+	return c.Unmarshal(b, v)
+}
@@ -0,0 +1,76 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestCodec(t *testing.T) {
+	c, err := CodecFor[testproto.Proto2]()
+	assert.NoError(t, err)
+	v := &testproto.Proto2{
+		Int32Val:  Int32(1),
+		StringVal: String("Hello World"),
+		Nested: &testproto.Proto2_NestedMessage{
+			Int64Val: Int64(1919810),
+		},
+	}
+
+	want, err := Marshal(v)
+	assert.NoError(t, err)
+
+	b, err := c.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, want, b)
+	assert.Equal(t, len(want), c.Size(v))
+
+	prefix := []byte{0xff}
+	b, err = c.Append(prefix, v)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0xff}, want...), b)
+
+	var out testproto.Proto2
+	assert.NoError(t, c.Unmarshal(want, &out))
+	assert.Equal(t, v, &out)
+
+	var invalid *InvalidUnmarshalError
+	assert.ErrorAs(t, c.Unmarshal(want, nil), &invalid)
+}
+
+func TestGenericHelpers(t *testing.T) {
+	v := &message{A: 1, S: &submessage{X: "hello"}}
+
+	b, err := MarshalOf(v)
+	assert.NoError(t, err)
+	n, err := SizeOf(v)
+	assert.NoError(t, err)
+	assert.Len(t, b, n)
+
+	var out message
+	assert.NoError(t, UnmarshalOf(b, &out))
+	assert.Equal(t, v, &out)
+}
+
+func TestCodecForNonStruct(t *testing.T) {
+	_, err := CodecFor[int32]()
+	assert.Error(t, err)
+
+	v := int32(1)
+	_, err = SizeOf(&v)
+	assert.Error(t, err)
+	_, err = MarshalOf(&v)
+	assert.Error(t, err)
+	assert.Error(t, UnmarshalOf([]byte{0x08, 0x01}, &v))
+}
+
+func TestCodecForShared(t *testing.T) {
+	c1, err := CodecFor[message]()
+	assert.NoError(t, err)
+	c2, err := CodecFor[message]()
+	assert.NoError(t, err)
+	assert.Same(t, c1, c2)
+}
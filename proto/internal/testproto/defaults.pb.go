@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: defaults.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+	math "math"
+)
+
+type Defaults_Color = int32
+
+const (
+	Defaults_RED   Defaults_Color = 0
+	Defaults_GREEN Defaults_Color = 1
+	Defaults_BLUE  Defaults_Color = 2
+)
+
+type Defaults struct {
+	BoolVal     proto.Option[bool]           `protobuf:"varint,1,opt"`
+	Int32Val    proto.Option[int32]          `protobuf:"varint,2,opt"`
+	Uint64Val   proto.Option[uint64]         `protobuf:"varint,3,opt"`
+	Sint64Val   proto.Option[int64]          `protobuf:"zigzag64,4,opt"`
+	FloatVal    proto.Option[float32]        `protobuf:"fixed32,5,opt"`
+	DoubleVal   proto.Option[float64]        `protobuf:"fixed64,6,opt"`
+	NanVal      proto.Option[float64]        `protobuf:"fixed64,7,opt"`
+	StringVal   proto.Option[string]         `protobuf:"bytes,8,opt"`
+	BytesVal    []byte                       `protobuf:"bytes,9,opt"`
+	Color       proto.Option[Defaults_Color] `protobuf:"varint,10,opt"`
+	NoDefault   proto.Option[Defaults_Color] `protobuf:"varint,11,opt"`
+	Nested      *Defaults                    `protobuf:"bytes,12,opt"`
+	RepeatedVal []int32                      `protobuf:"varint,13,rep"`
+}
+
+// Default values for Defaults fields.
+const (
+	Default_Defaults_BoolVal   = bool(true)
+	Default_Defaults_Int32Val  = int32(-32)
+	Default_Defaults_Uint64Val = uint64(64)
+	Default_Defaults_Sint64Val = int64(-64)
+	Default_Defaults_FloatVal  = float32(1.5)
+	Default_Defaults_StringVal = "hello \"world\""
+	Default_Defaults_Color     = Defaults_BLUE
+)
+
+// Default values for Defaults fields.
+var (
+	Default_Defaults_DoubleVal = float64(math.Inf(-1))
+	Default_Defaults_NanVal    = float64(math.NaN())
+	Default_Defaults_BytesVal  = []byte("\x01\x02")
+)
+
+func (x *Defaults) String() string {
+	return proto.Format(x)
+}
+
+func (x *Defaults) GetBoolVal() bool {
+	if x != nil && x.BoolVal.IsSome() {
+		return x.BoolVal.Unwrap()
+	}
+	return Default_Defaults_BoolVal
+}
+
+func (x *Defaults) GetInt32Val() int32 {
+	if x != nil && x.Int32Val.IsSome() {
+		return x.Int32Val.Unwrap()
+	}
+	return Default_Defaults_Int32Val
+}
+
+func (x *Defaults) GetUint64Val() uint64 {
+	if x != nil && x.Uint64Val.IsSome() {
+		return x.Uint64Val.Unwrap()
+	}
+	return Default_Defaults_Uint64Val
+}
+
+func (x *Defaults) GetSint64Val() int64 {
+	if x != nil && x.Sint64Val.IsSome() {
+		return x.Sint64Val.Unwrap()
+	}
+	return Default_Defaults_Sint64Val
+}
+
+func (x *Defaults) GetFloatVal() float32 {
+	if x != nil && x.FloatVal.IsSome() {
+		return x.FloatVal.Unwrap()
+	}
+	return Default_Defaults_FloatVal
+}
+
+func (x *Defaults) GetDoubleVal() float64 {
+	if x != nil && x.DoubleVal.IsSome() {
+		return x.DoubleVal.Unwrap()
+	}
+	return Default_Defaults_DoubleVal
+}
+
+func (x *Defaults) GetNanVal() float64 {
+	if x != nil && x.NanVal.IsSome() {
+		return x.NanVal.Unwrap()
+	}
+	return Default_Defaults_NanVal
+}
+
+func (x *Defaults) GetStringVal() string {
+	if x != nil && x.StringVal.IsSome() {
+		return x.StringVal.Unwrap()
+	}
+	return Default_Defaults_StringVal
+}
+
+func (x *Defaults) GetBytesVal() []byte {
+	if x != nil && x.BytesVal != nil {
+		return x.BytesVal
+	}
+	return append([]byte(nil), Default_Defaults_BytesVal...)
+}
+
+func (x *Defaults) GetColor() Defaults_Color {
+	if x != nil && x.Color.IsSome() {
+		return x.Color.Unwrap()
+	}
+	return Default_Defaults_Color
+}
+
+func (x *Defaults) GetNoDefault() Defaults_Color {
+	if x != nil && x.NoDefault.IsSome() {
+		return x.NoDefault.Unwrap()
+	}
+	return Defaults_RED
+}
+
+func (x *Defaults) GetNested() *Defaults {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Defaults) GetRepeatedVal() []int32 {
+	if x != nil {
+		return x.RepeatedVal
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: lazy.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+	utf8 "unicode/utf8"
+)
+
+type Envelope struct {
+	Topic   string              `protobuf:"bytes,1,opt"`
+	Payload proto.Lazy[Payload] `protobuf:"bytes,2,opt"`
+	History []*Payload          `protobuf:"bytes,3,rep"`
+}
+
+func (x *Envelope) String() string {
+	return proto.Format(x)
+}
+
+func (x *Envelope) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Envelope) GetPayload() *Payload {
+	if x != nil {
+		v, _ := x.Payload.Get()
+		return v
+	}
+	return nil
+}
+
+func (x *Envelope) GetHistory() []*Payload {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// Validate checks the constraints of the fields of x and its nested
+// messages, it returns a *proto.ValidationError listing the violations.
+func (x *Envelope) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v proto.Validator
+	if !x.Payload.IsSome() {
+		v.Addf("payload", "value is required")
+	}
+	v.Nested("payload", x.GetPayload())
+	for i, e := range x.History {
+		v.NestedElem("history", i, e)
+	}
+	return v.Err()
+}
+
+type Payload struct {
+	Body   string              `protobuf:"bytes,1,opt"`
+	Values []int32             `protobuf:"varint,2,rep"`
+	Next   proto.Lazy[Payload] `protobuf:"bytes,3,opt"`
+}
+
+func (x *Payload) String() string {
+	return proto.Format(x)
+}
+
+func (x *Payload) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Payload) GetValues() []int32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Payload) GetNext() *Payload {
+	if x != nil {
+		v, _ := x.Next.Get()
+		return v
+	}
+	return nil
+}
+
+// Validate checks the constraints of the fields of x and its nested
+// messages, it returns a *proto.ValidationError listing the violations.
+func (x *Payload) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v proto.Validator
+	if !(utf8.RuneCountInString(x.Body) >= 1) {
+		v.Addf("body", "value length must be at least %d character(s)", 1)
+	}
+	v.Nested("next", x.GetNext())
+	return v.Err()
+}
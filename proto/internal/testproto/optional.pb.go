@@ -0,0 +1,97 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: optional.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+type OptionalStyles struct {
+	Pointer *int32              `protobuf:"varint,1,opt,presence"`
+	Value   int32               `protobuf:"varint,2,opt"`
+	Option  proto.Option[int32] `protobuf:"varint,3,opt"`
+	Str     *string             `protobuf:"bytes,4,opt,presence"`
+	Req     *bool               `protobuf:"varint,5,req,presence"`
+	// Types that are assignable to O:
+	//	*OptionalStyles_Member
+	O isOptionalStyles_O `protobuf_oneof:"o"`
+	_ [0]func()
+}
+
+// Default values for OptionalStyles fields.
+const (
+	Default_OptionalStyles_Pointer = int32(7)
+	Default_OptionalStyles_Value   = int32(8)
+	Default_OptionalStyles_Option  = int32(9)
+)
+
+func (x *OptionalStyles) String() string {
+	return proto.Format(x)
+}
+
+func (x *OptionalStyles) GetPointer() int32 {
+	if x != nil && x.Pointer != nil {
+		return *x.Pointer
+	}
+	return Default_OptionalStyles_Pointer
+}
+
+func (x *OptionalStyles) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return Default_OptionalStyles_Value
+}
+
+func (x *OptionalStyles) GetOption() int32 {
+	if x != nil && x.Option.IsSome() {
+		return x.Option.Unwrap()
+	}
+	return Default_OptionalStyles_Option
+}
+
+func (x *OptionalStyles) GetStr() string {
+	if x != nil && x.Str != nil {
+		return *x.Str
+	}
+	return ""
+}
+
+func (x *OptionalStyles) GetReq() bool {
+	if x != nil && x.Req != nil {
+		return *x.Req
+	}
+	return false
+}
+
+func (m *OptionalStyles) GetO() isOptionalStyles_O {
+	if m != nil {
+		return m.O
+	}
+	return nil
+}
+
+func (x *OptionalStyles) GetMember() int32 {
+	if x, ok := x.GetO().(*OptionalStyles_Member); ok {
+		return x.Member
+	}
+	return 0
+}
+
+type isOptionalStyles_O interface {
+	isOptionalStyles_O()
+}
+
+type OptionalStyles_Member struct {
+	Member int32 `protobuf:"varint,6,opt"`
+}
+
+func (*OptionalStyles_Member) isOptionalStyles_O() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*OptionalStyles) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*OptionalStyles_Member)(nil),
+	}
+}
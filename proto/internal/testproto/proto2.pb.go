@@ -8,24 +8,168 @@ import (
 )
 
 type Proto2 struct {
-	BoolValue  proto.Option[bool]    `protobuf:"varint,1,opt"`
-	Int32Val   proto.Option[int32]   `protobuf:"varint,2,opt"`
-	Uint32Val  proto.Option[uint32]  `protobuf:"varint,3,opt"`
-	Int64Val   proto.Option[int64]   `protobuf:"varint,4,opt"`
-	Uint64Val  proto.Option[uint64]  `protobuf:"varint,5,opt"`
-	FloatVal   proto.Option[float32] `protobuf:"fixed32,6,opt"`
-	DoubleVal  proto.Option[float64] `protobuf:"fixed64,7,opt"`
-	StringVal  proto.Option[string]  `protobuf:"bytes,8,opt"`
-	BytesVal   []byte                `protobuf:"bytes,9,opt"`
-	Fixed32Val proto.Option[uint32]  `protobuf:"fixed32,10,opt"`
-	Fixed64Val proto.Option[uint64]  `protobuf:"fixed64,11,opt"`
-	Sint32Val  proto.Option[int32]   `protobuf:"zigzag32,12,opt"`
-	Sint64Val  proto.Option[int64]   `protobuf:"zigzag64,13,opt"`
-	Nested     *Proto2_NestedMessage `protobuf:"bytes,14,opt"`
+	BoolValue   proto.Option[bool]    `protobuf:"varint,1,opt"`
+	Int32Val    proto.Option[int32]   `protobuf:"varint,2,opt"`
+	Uint32Val   proto.Option[uint32]  `protobuf:"varint,3,opt"`
+	Int64Val    proto.Option[int64]   `protobuf:"varint,4,opt"`
+	Uint64Val   proto.Option[uint64]  `protobuf:"varint,5,opt"`
+	FloatVal    proto.Option[float32] `protobuf:"fixed32,6,opt"`
+	DoubleVal   proto.Option[float64] `protobuf:"fixed64,7,opt"`
+	StringVal   proto.Option[string]  `protobuf:"bytes,8,opt"`
+	BytesVal    []byte                `protobuf:"bytes,9,opt"`
+	Fixed32Val  proto.Option[uint32]  `protobuf:"fixed32,10,opt"`
+	Fixed64Val  proto.Option[uint64]  `protobuf:"fixed64,11,opt"`
+	Sint32Val   proto.Option[int32]   `protobuf:"zigzag32,12,opt"`
+	Sint64Val   proto.Option[int64]   `protobuf:"zigzag64,13,opt"`
+	Nested      *Proto2_NestedMessage `protobuf:"bytes,14,opt"`
+	Sfixed32Val proto.Option[int32]   `protobuf:"fixed32,15,opt"`
+	Sfixed64Val proto.Option[int64]   `protobuf:"fixed64,16,opt"`
+}
+
+func (x *Proto2) String() string {
+	return proto.Format(x)
+}
+
+func (x *Proto2) GetBoolValue() bool {
+	if x != nil && x.BoolValue.IsSome() {
+		return x.BoolValue.Unwrap()
+	}
+	return false
+}
+
+func (x *Proto2) GetInt32Val() int32 {
+	if x != nil && x.Int32Val.IsSome() {
+		return x.Int32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetUint32Val() uint32 {
+	if x != nil && x.Uint32Val.IsSome() {
+		return x.Uint32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetInt64Val() int64 {
+	if x != nil && x.Int64Val.IsSome() {
+		return x.Int64Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetUint64Val() uint64 {
+	if x != nil && x.Uint64Val.IsSome() {
+		return x.Uint64Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetFloatVal() float32 {
+	if x != nil && x.FloatVal.IsSome() {
+		return x.FloatVal.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetDoubleVal() float64 {
+	if x != nil && x.DoubleVal.IsSome() {
+		return x.DoubleVal.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetStringVal() string {
+	if x != nil && x.StringVal.IsSome() {
+		return x.StringVal.Unwrap()
+	}
+	return ""
+}
+
+func (x *Proto2) GetBytesVal() []byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *Proto2) GetFixed32Val() uint32 {
+	if x != nil && x.Fixed32Val.IsSome() {
+		return x.Fixed32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetFixed64Val() uint64 {
+	if x != nil && x.Fixed64Val.IsSome() {
+		return x.Fixed64Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetSint32Val() int32 {
+	if x != nil && x.Sint32Val.IsSome() {
+		return x.Sint32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetSint64Val() int64 {
+	if x != nil && x.Sint64Val.IsSome() {
+		return x.Sint64Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetNested() *Proto2_NestedMessage {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Proto2) GetSfixed32Val() int32 {
+	if x != nil && x.Sfixed32Val.IsSome() {
+		return x.Sfixed32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2) GetSfixed64Val() int64 {
+	if x != nil && x.Sfixed64Val.IsSome() {
+		return x.Sfixed64Val.Unwrap()
+	}
+	return 0
 }
 
 type Proto2_NestedMessage struct {
 	Int32Val  proto.Option[int32]  `protobuf:"varint,1,opt"`
 	Int64Val  proto.Option[int64]  `protobuf:"varint,2,opt"`
 	StringVal proto.Option[string] `protobuf:"bytes,3,opt"`
+	_         [0]func()
+}
+
+func (x *Proto2_NestedMessage) String() string {
+	return proto.Format(x)
+}
+
+func (x *Proto2_NestedMessage) GetInt32Val() int32 {
+	if x != nil && x.Int32Val.IsSome() {
+		return x.Int32Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2_NestedMessage) GetInt64Val() int64 {
+	if x != nil && x.Int64Val.IsSome() {
+		return x.Int64Val.Unwrap()
+	}
+	return 0
+}
+
+func (x *Proto2_NestedMessage) GetStringVal() string {
+	if x != nil && x.StringVal.IsSome() {
+		return x.StringVal.Unwrap()
+	}
+	return ""
 }
@@ -0,0 +1,79 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: redact.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+type Account struct {
+	Id     int64              `protobuf:"varint,1,opt"`
+	Name   string             `protobuf:"bytes,2,opt"`
+	Token  string             `protobuf:"bytes,3,opt,redact"`
+	Tags   []string           `protobuf:"bytes,4,rep"`
+	Scores map[string]int32   `protobuf:"bytes,5,rep" protobuf_key:"bytes,1,opt" protobuf_val:"varint,2,opt"`
+	Owner  *Account           `protobuf:"bytes,6,opt"`
+	Data   []byte             `protobuf:"bytes,7,opt"`
+	Admin  proto.Option[bool] `protobuf:"varint,8,opt"`
+}
+
+func (x *Account) String() string {
+	return proto.Format(x)
+}
+
+func (x *Account) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Account) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Account) GetScores() map[string]int32 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *Account) GetOwner() *Account {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *Account) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Account) GetAdmin() bool {
+	if x != nil && x.Admin.IsSome() {
+		return x.Admin.Unwrap()
+	}
+	return false
+}
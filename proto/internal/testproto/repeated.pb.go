@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: repeated.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+type Repeated struct {
+	BoolVal        []bool           `protobuf:"varint,1,rep"`
+	Int32Val       []int32          `protobuf:"varint,2,rep"`
+	Uint32Val      []uint32         `protobuf:"varint,3,rep"`
+	Int64Val       []int64          `protobuf:"varint,4,rep"`
+	Uint64Val      []uint64         `protobuf:"varint,5,rep"`
+	FloatVal       []float32        `protobuf:"fixed32,6,rep"`
+	DoubleVal      []float64        `protobuf:"fixed64,7,rep"`
+	StringVal      []string         `protobuf:"bytes,8,rep"`
+	BytesVal       [][]byte         `protobuf:"bytes,9,rep"`
+	Fixed32Val     []uint32         `protobuf:"fixed32,10,rep"`
+	Fixed64Val     []uint64         `protobuf:"fixed64,11,rep"`
+	Sint32Val      []int32          `protobuf:"zigzag32,12,rep"`
+	Sint64Val      []int64          `protobuf:"zigzag64,13,rep"`
+	Sfixed32Val    []int32          `protobuf:"fixed32,14,rep"`
+	Sfixed64Val    []int64          `protobuf:"fixed64,15,rep"`
+	PackedInt32    []int32          `protobuf:"varint,16,rep"`
+	PackedUint64   []uint64         `protobuf:"varint,17,rep"`
+	PackedFloat    []float32        `protobuf:"fixed32,18,rep"`
+	PackedDouble   []float64        `protobuf:"fixed64,19,rep"`
+	PackedFixed32  []uint32         `protobuf:"fixed32,20,rep"`
+	PackedFixed64  []uint64         `protobuf:"fixed64,21,rep"`
+	PackedSint32   []int32          `protobuf:"zigzag32,22,rep"`
+	PackedSint64   []int64          `protobuf:"zigzag64,23,rep"`
+	PackedSfixed32 []int32          `protobuf:"fixed32,24,rep"`
+	PackedSfixed64 []int64          `protobuf:"fixed64,25,rep"`
+	PackedBool     []bool           `protobuf:"varint,26,rep"`
+	FixedMap       map[uint32]int64 `protobuf:"bytes,27,rep" protobuf_key:"fixed32,1,opt" protobuf_val:"fixed64,2,opt"`
+}
+
+func (x *Repeated) String() string {
+	return proto.Format(x)
+}
+
+func (x *Repeated) GetBoolVal() []bool {
+	if x != nil {
+		return x.BoolVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetInt32Val() []int32 {
+	if x != nil {
+		return x.Int32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetUint32Val() []uint32 {
+	if x != nil {
+		return x.Uint32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetInt64Val() []int64 {
+	if x != nil {
+		return x.Int64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetUint64Val() []uint64 {
+	if x != nil {
+		return x.Uint64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetFloatVal() []float32 {
+	if x != nil {
+		return x.FloatVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetDoubleVal() []float64 {
+	if x != nil {
+		return x.DoubleVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetStringVal() []string {
+	if x != nil {
+		return x.StringVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetBytesVal() [][]byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixed32Val() []uint32 {
+	if x != nil {
+		return x.Fixed32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixed64Val() []uint64 {
+	if x != nil {
+		return x.Fixed64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSint32Val() []int32 {
+	if x != nil {
+		return x.Sint32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSint64Val() []int64 {
+	if x != nil {
+		return x.Sint64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSfixed32Val() []int32 {
+	if x != nil {
+		return x.Sfixed32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSfixed64Val() []int64 {
+	if x != nil {
+		return x.Sfixed64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedInt32() []int32 {
+	if x != nil {
+		return x.PackedInt32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedUint64() []uint64 {
+	if x != nil {
+		return x.PackedUint64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFloat() []float32 {
+	if x != nil {
+		return x.PackedFloat
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedDouble() []float64 {
+	if x != nil {
+		return x.PackedDouble
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFixed32() []uint32 {
+	if x != nil {
+		return x.PackedFixed32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFixed64() []uint64 {
+	if x != nil {
+		return x.PackedFixed64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSint32() []int32 {
+	if x != nil {
+		return x.PackedSint32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSint64() []int64 {
+	if x != nil {
+		return x.PackedSint64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSfixed32() []int32 {
+	if x != nil {
+		return x.PackedSfixed32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSfixed64() []int64 {
+	if x != nil {
+		return x.PackedSfixed64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedBool() []bool {
+	if x != nil {
+		return x.PackedBool
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixedMap() map[uint32]int64 {
+	if x != nil {
+		return x.FixedMap
+	}
+	return nil
+}
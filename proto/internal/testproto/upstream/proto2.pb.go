@@ -0,0 +1,375 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: proto2.proto
+
+package upstream
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Proto2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BoolValue   *bool                 `protobuf:"varint,1,opt,name=bool_value,json=boolValue" json:"bool_value,omitempty"`
+	Int32Val    *int32                `protobuf:"varint,2,opt,name=int32_val,json=int32Val" json:"int32_val,omitempty"`
+	Uint32Val   *uint32               `protobuf:"varint,3,opt,name=uint32_val,json=uint32Val" json:"uint32_val,omitempty"`
+	Int64Val    *int64                `protobuf:"varint,4,opt,name=int64_val,json=int64Val" json:"int64_val,omitempty"`
+	Uint64Val   *uint64               `protobuf:"varint,5,opt,name=uint64_val,json=uint64Val" json:"uint64_val,omitempty"`
+	FloatVal    *float32              `protobuf:"fixed32,6,opt,name=float_val,json=floatVal" json:"float_val,omitempty"`
+	DoubleVal   *float64              `protobuf:"fixed64,7,opt,name=double_val,json=doubleVal" json:"double_val,omitempty"`
+	StringVal   *string               `protobuf:"bytes,8,opt,name=string_val,json=stringVal" json:"string_val,omitempty"`
+	BytesVal    []byte                `protobuf:"bytes,9,opt,name=bytes_val,json=bytesVal" json:"bytes_val,omitempty"`
+	Fixed32Val  *uint32               `protobuf:"fixed32,10,opt,name=fixed32_val,json=fixed32Val" json:"fixed32_val,omitempty"`
+	Fixed64Val  *uint64               `protobuf:"fixed64,11,opt,name=fixed64_val,json=fixed64Val" json:"fixed64_val,omitempty"`
+	Sint32Val   *int32                `protobuf:"zigzag32,12,opt,name=sint32_val,json=sint32Val" json:"sint32_val,omitempty"`
+	Sint64Val   *int64                `protobuf:"zigzag64,13,opt,name=sint64_val,json=sint64Val" json:"sint64_val,omitempty"`
+	Nested      *Proto2_NestedMessage `protobuf:"bytes,14,opt,name=nested" json:"nested,omitempty"`
+	Sfixed32Val *int32                `protobuf:"fixed32,15,opt,name=sfixed32_val,json=sfixed32Val" json:"sfixed32_val,omitempty"`
+	Sfixed64Val *int64                `protobuf:"fixed64,16,opt,name=sfixed64_val,json=sfixed64Val" json:"sfixed64_val,omitempty"`
+}
+
+func (x *Proto2) Reset() {
+	*x = Proto2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proto2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proto2) ProtoMessage() {}
+
+func (x *Proto2) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proto2.ProtoReflect.Descriptor instead.
+func (*Proto2) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Proto2) GetBoolValue() bool {
+	if x != nil && x.BoolValue != nil {
+		return *x.BoolValue
+	}
+	return false
+}
+
+func (x *Proto2) GetInt32Val() int32 {
+	if x != nil && x.Int32Val != nil {
+		return *x.Int32Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetUint32Val() uint32 {
+	if x != nil && x.Uint32Val != nil {
+		return *x.Uint32Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetInt64Val() int64 {
+	if x != nil && x.Int64Val != nil {
+		return *x.Int64Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetUint64Val() uint64 {
+	if x != nil && x.Uint64Val != nil {
+		return *x.Uint64Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetFloatVal() float32 {
+	if x != nil && x.FloatVal != nil {
+		return *x.FloatVal
+	}
+	return 0
+}
+
+func (x *Proto2) GetDoubleVal() float64 {
+	if x != nil && x.DoubleVal != nil {
+		return *x.DoubleVal
+	}
+	return 0
+}
+
+func (x *Proto2) GetStringVal() string {
+	if x != nil && x.StringVal != nil {
+		return *x.StringVal
+	}
+	return ""
+}
+
+func (x *Proto2) GetBytesVal() []byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *Proto2) GetFixed32Val() uint32 {
+	if x != nil && x.Fixed32Val != nil {
+		return *x.Fixed32Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetFixed64Val() uint64 {
+	if x != nil && x.Fixed64Val != nil {
+		return *x.Fixed64Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetSint32Val() int32 {
+	if x != nil && x.Sint32Val != nil {
+		return *x.Sint32Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetSint64Val() int64 {
+	if x != nil && x.Sint64Val != nil {
+		return *x.Sint64Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetNested() *Proto2_NestedMessage {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Proto2) GetSfixed32Val() int32 {
+	if x != nil && x.Sfixed32Val != nil {
+		return *x.Sfixed32Val
+	}
+	return 0
+}
+
+func (x *Proto2) GetSfixed64Val() int64 {
+	if x != nil && x.Sfixed64Val != nil {
+		return *x.Sfixed64Val
+	}
+	return 0
+}
+
+type Proto2_NestedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Int32Val  *int32  `protobuf:"varint,1,opt,name=int32_val,json=int32Val" json:"int32_val,omitempty"`
+	Int64Val  *int64  `protobuf:"varint,2,opt,name=int64_val,json=int64Val" json:"int64_val,omitempty"`
+	StringVal *string `protobuf:"bytes,3,opt,name=string_val,json=stringVal" json:"string_val,omitempty"`
+}
+
+func (x *Proto2_NestedMessage) Reset() {
+	*x = Proto2_NestedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proto2_NestedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proto2_NestedMessage) ProtoMessage() {}
+
+func (x *Proto2_NestedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proto2_NestedMessage.ProtoReflect.Descriptor instead.
+func (*Proto2_NestedMessage) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Proto2_NestedMessage) GetInt32Val() int32 {
+	if x != nil && x.Int32Val != nil {
+		return *x.Int32Val
+	}
+	return 0
+}
+
+func (x *Proto2_NestedMessage) GetInt64Val() int64 {
+	if x != nil && x.Int64Val != nil {
+		return *x.Int64Val
+	}
+	return 0
+}
+
+func (x *Proto2_NestedMessage) GetStringVal() string {
+	if x != nil && x.StringVal != nil {
+		return *x.StringVal
+	}
+	return ""
+}
+
+var File_proto2_proto protoreflect.FileDescriptor
+
+var file_proto2_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf6,
+	0x04, 0x0a, 0x06, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x5f,
+	0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x75, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61,
+	0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x07, 0x52, 0x0a, 0x66,
+	0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x78,
+	0x65, 0x64, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x06, 0x52, 0x0a,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69,
+	0x6e, 0x74, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x11, 0x52, 0x09,
+	0x73, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x12, 0x52, 0x09, 0x73,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x2d, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x32, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0f, 0x52, 0x0b, 0x73,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x66,
+	0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x10,
+	0x52, 0x0b, 0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x1a, 0x68, 0x0a,
+	0x0d, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x69,
+	0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x2f, 0x3b, 0x74, 0x65,
+	0x73, 0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_proto2_proto_rawDescOnce sync.Once
+	file_proto2_proto_rawDescData = file_proto2_proto_rawDesc
+)
+
+func file_proto2_proto_rawDescGZIP() []byte {
+	file_proto2_proto_rawDescOnce.Do(func() {
+		file_proto2_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto2_proto_rawDescData)
+	})
+	return file_proto2_proto_rawDescData
+}
+
+var file_proto2_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto2_proto_goTypes = []interface{}{
+	(*Proto2)(nil),               // 0: Proto2
+	(*Proto2_NestedMessage)(nil), // 1: Proto2.NestedMessage
+}
+var file_proto2_proto_depIdxs = []int32{
+	1, // 0: Proto2.nested:type_name -> Proto2.NestedMessage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto2_proto_init() }
+func file_proto2_proto_init() {
+	if File_proto2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proto2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proto2_NestedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto2_proto_goTypes,
+		DependencyIndexes: file_proto2_proto_depIdxs,
+		MessageInfos:      file_proto2_proto_msgTypes,
+	}.Build()
+	File_proto2_proto = out.File
+	file_proto2_proto_rawDesc = nil
+	file_proto2_proto_goTypes = nil
+	file_proto2_proto_depIdxs = nil
+}
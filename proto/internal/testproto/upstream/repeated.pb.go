@@ -0,0 +1,414 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: repeated.proto
+
+package upstream
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Repeated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BoolVal        []bool           `protobuf:"varint,1,rep,name=bool_val,json=boolVal" json:"bool_val,omitempty"`
+	Int32Val       []int32          `protobuf:"varint,2,rep,name=int32_val,json=int32Val" json:"int32_val,omitempty"`
+	Uint32Val      []uint32         `protobuf:"varint,3,rep,name=uint32_val,json=uint32Val" json:"uint32_val,omitempty"`
+	Int64Val       []int64          `protobuf:"varint,4,rep,name=int64_val,json=int64Val" json:"int64_val,omitempty"`
+	Uint64Val      []uint64         `protobuf:"varint,5,rep,name=uint64_val,json=uint64Val" json:"uint64_val,omitempty"`
+	FloatVal       []float32        `protobuf:"fixed32,6,rep,name=float_val,json=floatVal" json:"float_val,omitempty"`
+	DoubleVal      []float64        `protobuf:"fixed64,7,rep,name=double_val,json=doubleVal" json:"double_val,omitempty"`
+	StringVal      []string         `protobuf:"bytes,8,rep,name=string_val,json=stringVal" json:"string_val,omitempty"`
+	BytesVal       [][]byte         `protobuf:"bytes,9,rep,name=bytes_val,json=bytesVal" json:"bytes_val,omitempty"`
+	Fixed32Val     []uint32         `protobuf:"fixed32,10,rep,name=fixed32_val,json=fixed32Val" json:"fixed32_val,omitempty"`
+	Fixed64Val     []uint64         `protobuf:"fixed64,11,rep,name=fixed64_val,json=fixed64Val" json:"fixed64_val,omitempty"`
+	Sint32Val      []int32          `protobuf:"zigzag32,12,rep,name=sint32_val,json=sint32Val" json:"sint32_val,omitempty"`
+	Sint64Val      []int64          `protobuf:"zigzag64,13,rep,name=sint64_val,json=sint64Val" json:"sint64_val,omitempty"`
+	Sfixed32Val    []int32          `protobuf:"fixed32,14,rep,name=sfixed32_val,json=sfixed32Val" json:"sfixed32_val,omitempty"`
+	Sfixed64Val    []int64          `protobuf:"fixed64,15,rep,name=sfixed64_val,json=sfixed64Val" json:"sfixed64_val,omitempty"`
+	PackedInt32    []int32          `protobuf:"varint,16,rep,packed,name=packed_int32,json=packedInt32" json:"packed_int32,omitempty"`
+	PackedUint64   []uint64         `protobuf:"varint,17,rep,packed,name=packed_uint64,json=packedUint64" json:"packed_uint64,omitempty"`
+	PackedFloat    []float32        `protobuf:"fixed32,18,rep,packed,name=packed_float,json=packedFloat" json:"packed_float,omitempty"`
+	PackedDouble   []float64        `protobuf:"fixed64,19,rep,packed,name=packed_double,json=packedDouble" json:"packed_double,omitempty"`
+	PackedFixed32  []uint32         `protobuf:"fixed32,20,rep,packed,name=packed_fixed32,json=packedFixed32" json:"packed_fixed32,omitempty"`
+	PackedFixed64  []uint64         `protobuf:"fixed64,21,rep,packed,name=packed_fixed64,json=packedFixed64" json:"packed_fixed64,omitempty"`
+	PackedSint32   []int32          `protobuf:"zigzag32,22,rep,packed,name=packed_sint32,json=packedSint32" json:"packed_sint32,omitempty"`
+	PackedSint64   []int64          `protobuf:"zigzag64,23,rep,packed,name=packed_sint64,json=packedSint64" json:"packed_sint64,omitempty"`
+	PackedSfixed32 []int32          `protobuf:"fixed32,24,rep,packed,name=packed_sfixed32,json=packedSfixed32" json:"packed_sfixed32,omitempty"`
+	PackedSfixed64 []int64          `protobuf:"fixed64,25,rep,packed,name=packed_sfixed64,json=packedSfixed64" json:"packed_sfixed64,omitempty"`
+	PackedBool     []bool           `protobuf:"varint,26,rep,packed,name=packed_bool,json=packedBool" json:"packed_bool,omitempty"`
+	FixedMap       map[uint32]int64 `protobuf:"bytes,27,rep,name=fixed_map,json=fixedMap" json:"fixed_map,omitempty" protobuf_key:"fixed32,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+}
+
+func (x *Repeated) Reset() {
+	*x = Repeated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repeated_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Repeated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repeated) ProtoMessage() {}
+
+func (x *Repeated) ProtoReflect() protoreflect.Message {
+	mi := &file_repeated_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repeated.ProtoReflect.Descriptor instead.
+func (*Repeated) Descriptor() ([]byte, []int) {
+	return file_repeated_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Repeated) GetBoolVal() []bool {
+	if x != nil {
+		return x.BoolVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetInt32Val() []int32 {
+	if x != nil {
+		return x.Int32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetUint32Val() []uint32 {
+	if x != nil {
+		return x.Uint32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetInt64Val() []int64 {
+	if x != nil {
+		return x.Int64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetUint64Val() []uint64 {
+	if x != nil {
+		return x.Uint64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetFloatVal() []float32 {
+	if x != nil {
+		return x.FloatVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetDoubleVal() []float64 {
+	if x != nil {
+		return x.DoubleVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetStringVal() []string {
+	if x != nil {
+		return x.StringVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetBytesVal() [][]byte {
+	if x != nil {
+		return x.BytesVal
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixed32Val() []uint32 {
+	if x != nil {
+		return x.Fixed32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixed64Val() []uint64 {
+	if x != nil {
+		return x.Fixed64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSint32Val() []int32 {
+	if x != nil {
+		return x.Sint32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSint64Val() []int64 {
+	if x != nil {
+		return x.Sint64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSfixed32Val() []int32 {
+	if x != nil {
+		return x.Sfixed32Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetSfixed64Val() []int64 {
+	if x != nil {
+		return x.Sfixed64Val
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedInt32() []int32 {
+	if x != nil {
+		return x.PackedInt32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedUint64() []uint64 {
+	if x != nil {
+		return x.PackedUint64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFloat() []float32 {
+	if x != nil {
+		return x.PackedFloat
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedDouble() []float64 {
+	if x != nil {
+		return x.PackedDouble
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFixed32() []uint32 {
+	if x != nil {
+		return x.PackedFixed32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedFixed64() []uint64 {
+	if x != nil {
+		return x.PackedFixed64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSint32() []int32 {
+	if x != nil {
+		return x.PackedSint32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSint64() []int64 {
+	if x != nil {
+		return x.PackedSint64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSfixed32() []int32 {
+	if x != nil {
+		return x.PackedSfixed32
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedSfixed64() []int64 {
+	if x != nil {
+		return x.PackedSfixed64
+	}
+	return nil
+}
+
+func (x *Repeated) GetPackedBool() []bool {
+	if x != nil {
+		return x.PackedBool
+	}
+	return nil
+}
+
+func (x *Repeated) GetFixedMap() map[uint32]int64 {
+	if x != nil {
+		return x.FixedMap
+	}
+	return nil
+}
+
+var File_repeated_proto protoreflect.FileDescriptor
+
+var file_repeated_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x95, 0x08, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x08, 0x52,
+	0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74,
+	0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x5f,
+	0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x75, 0x69, 0x6e, 0x74, 0x33,
+	0x32, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61,
+	0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x02, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x01, 0x52, 0x09, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x07, 0x52, 0x0a, 0x66,
+	0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x78,
+	0x65, 0x64, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x06, 0x52, 0x0a,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69,
+	0x6e, 0x74, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x11, 0x52, 0x09,
+	0x73, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x12, 0x52, 0x09, 0x73,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x66, 0x69, 0x78,
+	0x65, 0x64, 0x33, 0x32, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0f, 0x52, 0x0b,
+	0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x10, 0x52, 0x0b, 0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x12, 0x25,
+	0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x49, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x27, 0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f,
+	0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x11, 0x20, 0x03, 0x28, 0x04, 0x42, 0x02, 0x10, 0x01,
+	0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x25,
+	0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x12,
+	0x20, 0x03, 0x28, 0x02, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x27, 0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f,
+	0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x13, 0x20, 0x03, 0x28, 0x01, 0x42, 0x02, 0x10, 0x01,
+	0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x29,
+	0x0a, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x07, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x64, 0x46, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x12, 0x29, 0x0a, 0x0e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x18, 0x15, 0x20, 0x03, 0x28,
+	0x06, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x46, 0x69, 0x78,
+	0x65, 0x64, 0x36, 0x34, 0x12, 0x27, 0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x73,
+	0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x16, 0x20, 0x03, 0x28, 0x11, 0x42, 0x02, 0x10, 0x01, 0x52,
+	0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x27, 0x0a,
+	0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x17,
+	0x20, 0x03, 0x28, 0x12, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x53, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x12, 0x2b, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x5f, 0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0f, 0x42,
+	0x02, 0x10, 0x01, 0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x33, 0x32, 0x12, 0x2b, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x66,
+	0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x18, 0x19, 0x20, 0x03, 0x28, 0x10, 0x42, 0x02, 0x10, 0x01,
+	0x52, 0x0e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34,
+	0x12, 0x23, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x18,
+	0x1a, 0x20, 0x03, 0x28, 0x08, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x64, 0x42, 0x6f, 0x6f, 0x6c, 0x12, 0x34, 0x0a, 0x09, 0x66, 0x69, 0x78, 0x65, 0x64, 0x5f, 0x6d,
+	0x61, 0x70, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x52, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x2e, 0x46, 0x69, 0x78, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x66, 0x69, 0x78, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x1a, 0x3b, 0x0a, 0x0d, 0x46,
+	0x69, 0x78, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x07, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x10, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x2f, 0x3b, 0x74,
+	0x65, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_repeated_proto_rawDescOnce sync.Once
+	file_repeated_proto_rawDescData = file_repeated_proto_rawDesc
+)
+
+func file_repeated_proto_rawDescGZIP() []byte {
+	file_repeated_proto_rawDescOnce.Do(func() {
+		file_repeated_proto_rawDescData = protoimpl.X.CompressGZIP(file_repeated_proto_rawDescData)
+	})
+	return file_repeated_proto_rawDescData
+}
+
+var file_repeated_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_repeated_proto_goTypes = []interface{}{
+	(*Repeated)(nil), // 0: Repeated
+	nil,              // 1: Repeated.FixedMapEntry
+}
+var file_repeated_proto_depIdxs = []int32{
+	1, // 0: Repeated.fixed_map:type_name -> Repeated.FixedMapEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_repeated_proto_init() }
+func file_repeated_proto_init() {
+	if File_repeated_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_repeated_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Repeated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_repeated_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_repeated_proto_goTypes,
+		DependencyIndexes: file_repeated_proto_depIdxs,
+		MessageInfos:      file_repeated_proto_msgTypes,
+	}.Build()
+	File_repeated_proto = out.File
+	file_repeated_proto_rawDesc = nil
+	file_repeated_proto_goTypes = nil
+	file_repeated_proto_depIdxs = nil
+}
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: validate.proto
+
+package testproto
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+	math "math"
+	regexp "regexp"
+	utf8 "unicode/utf8"
+)
+
+type Role = int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_ADMIN       Role = 1
+	Role_ROLE_MEMBER      Role = 2
+)
+
+type User struct {
+	Name     string                `protobuf:"bytes,1,opt"`
+	Email    string                `protobuf:"bytes,2,opt"`
+	Age      int32                 `protobuf:"varint,3,opt"`
+	Score    proto.Option[float64] `protobuf:"fixed64,4,opt"`
+	Role     Role                  `protobuf:"varint,5,opt"`
+	Tags     []string              `protobuf:"bytes,6,rep"`
+	Address  *Address              `protobuf:"bytes,7,opt"`
+	Previous []*Address            `protobuf:"bytes,8,rep"`
+	Others   map[string]*Address   `protobuf:"bytes,9,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,2,opt"`
+	// Types that are assignable to Contact:
+	//	*User_Phone
+	//	*User_Office
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+}
+
+func (x *User) String() string {
+	return proto.Format(x)
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+func (x *User) GetScore() float64 {
+	if x != nil && x.Score.IsSome() {
+		return x.Score.Unwrap()
+	}
+	return 0
+}
+
+func (x *User) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetPrevious() []*Address {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *User) GetOthers() map[string]*Address {
+	if x != nil {
+		return x.Others
+	}
+	return nil
+}
+
+func (m *User) GetContact() isUser_Contact {
+	if m != nil {
+		return m.Contact
+	}
+	return nil
+}
+
+func (x *User) GetPhone() string {
+	if x, ok := x.GetContact().(*User_Phone); ok {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *User) GetOffice() *Address {
+	if x, ok := x.GetContact().(*User_Office); ok {
+		return x.Office
+	}
+	return nil
+}
+
+var _User_Email_pattern = regexp.MustCompile("^[^@]+@[^@]+$")
+
+// Validate checks the constraints of the fields of x and its nested
+// messages, it returns a *proto.ValidationError listing the violations.
+func (x *User) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v proto.Validator
+	if !(utf8.RuneCountInString(x.Name) >= 1) {
+		v.Addf("name", "value length must be at least %d character(s)", 1)
+	}
+	if !(utf8.RuneCountInString(x.Name) <= 16) {
+		v.Addf("name", "value length must be at most %d character(s)", 16)
+	}
+	if !_User_Email_pattern.MatchString(x.Email) {
+		v.Addf("email", "value does not match regex pattern %q", "^[^@]+@[^@]+$")
+	}
+	if !(x.Age < 150) {
+		v.Addf("age", "value must be less than %v", 150)
+	}
+	if !(x.Age >= 0) {
+		v.Addf("age", "value must be greater than or equal to %v", 0)
+	}
+	if x.Score.IsSome() {
+		val := x.Score.Unwrap()
+		if !(val <= 1) {
+			v.Addf("score", "value must be less than or equal to %v", 1)
+		}
+		if !(val > 0) {
+			v.Addf("score", "value must be greater than %v", 0)
+		}
+	}
+	switch x.Role {
+	case 0, 1, 2:
+	default:
+		v.Addf("role", "value must be one of the defined enum values")
+	}
+	if len(x.Tags) < 1 {
+		v.Addf("tags", "value must contain at least %d item(s)", 1)
+	}
+	if len(x.Tags) > 3 {
+		v.Addf("tags", "value must contain no more than %d item(s)", 3)
+	}
+	if x.Address == nil {
+		v.Addf("address", "value is required")
+	}
+	v.Nested("address", x.Address)
+	for i, e := range x.Previous {
+		v.NestedElem("previous", i, e)
+	}
+	if len(x.Others) > 2 {
+		v.Addf("others", "value must contain no more than %d item(s)", 2)
+	}
+	for k, e := range x.Others {
+		v.NestedElem("others", k, e)
+	}
+	if w, ok := x.Contact.(*User_Phone); ok {
+		val := w.Phone
+		if !(utf8.RuneCountInString(val) == 11) {
+			v.Addf("phone", "value length must be %d character(s)", 11)
+		}
+	}
+	if w, ok := x.Contact.(*User_Office); ok {
+		v.Nested("office", w.Office)
+	}
+	return v.Err()
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,10,opt"`
+}
+
+type User_Office struct {
+	Office *Address `protobuf:"bytes,11,opt"`
+}
+
+func (*User_Phone) isUser_Contact() {}
+
+func (*User_Office) isUser_Contact() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*User) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*User_Phone)(nil),
+		(*User_Office)(nil),
+	}
+}
+
+type Address struct {
+	City string `protobuf:"bytes,1,opt"`
+	Zip  uint32 `protobuf:"varint,2,opt"`
+	_    [0]func()
+}
+
+func (x *Address) String() string {
+	return proto.Format(x)
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetZip() uint32 {
+	if x != nil {
+		return x.Zip
+	}
+	return 0
+}
+
+// Validate checks the constraints of the fields of x and its nested
+// messages, it returns a *proto.ValidationError listing the violations.
+func (x *Address) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v proto.Validator
+	if x.City == "" {
+		v.Addf("city", "value is required")
+	}
+	if !(x.Zip <= 99999) {
+		v.Addf("zip", "value must be less than or equal to %v", 99999)
+	}
+	if !(x.Zip >= 10000) {
+		v.Addf("zip", "value must be greater than or equal to %v", 10000)
+	}
+	return v.Err()
+}
+
+type Reading struct {
+	Temperature float32 `protobuf:"fixed32,1,opt"`
+	Ratio       float64 `protobuf:"fixed64,2,opt"`
+	Missing     float64 `protobuf:"fixed64,3,opt"`
+	_           [0]func()
+}
+
+func (x *Reading) String() string {
+	return proto.Format(x)
+}
+
+func (x *Reading) GetTemperature() float32 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Reading) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *Reading) GetMissing() float64 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+// Validate checks the constraints of the fields of x and its nested
+// messages, it returns a *proto.ValidationError listing the violations.
+func (x *Reading) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v proto.Validator
+	if !(x.Temperature < float32(math.Inf(1))) {
+		v.Addf("temperature", "value must be less than %v", float32(math.Inf(1)))
+	}
+	if !(x.Temperature > float32(math.Inf(-1))) {
+		v.Addf("temperature", "value must be greater than %v", float32(math.Inf(-1)))
+	}
+	if !(x.Ratio <= math.Inf(1)) {
+		v.Addf("ratio", "value must be less than or equal to %v", math.Inf(1))
+	}
+	if !(x.Missing != x.Missing) {
+		v.Addf("missing", "value must equal %v", math.NaN())
+	}
+	return v.Err()
+}
@@ -0,0 +1,131 @@
+package proto
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is a message field of type T decoded on first access. Unmarshal
+// keeps the encoded message, which Get decodes, and Marshal re-emits the
+// encoded message as long as Get or Set have not been called.
+//
+// The zero Lazy is an absent field. A copy of a Lazy refers to the same
+// message as the original, like a copy of a pointer: the message is decoded
+// once for both, and the changes made to the decoded message are seen by
+// both. Set and Clear only change the copy they are called on.
+//
+// Get may be called by several goroutines at once, but not while the field
+// is set or the message holding it is being unmarshaled.
+type Lazy[T any] struct {
+	s *lazyState[T] // nil if the field is absent
+}
+
+// lazyState is the state of a Lazy field, it is behind a pointer so that the
+// messages holding Lazy fields can be copied.
+type lazyState[T any] struct {
+	mu    sync.Mutex
+	done  uint32 // 1 once value and err are final
+	raw   []byte // encoded message, nil once decoded
+	value *T
+	err   error
+}
+
+// Get decodes the field on its first call and returns the message, nil if
+// the field is absent. The error of the decoding is returned by every call.
+func (l *Lazy[T]) Get() (*T, error) {
+	s := l.s
+	if s == nil {
+		return nil, nil
+	}
+	if atomic.LoadUint32(&s.done) == 1 {
+		return s.value, s.err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done == 0 {
+		v := new(T)
+		if s.err = Unmarshal(s.raw, v); s.err == nil {
+			// the encoded message is kept to be re-emitted if the
+			// decoding failed
+			s.value, s.raw = v, nil
+		}
+		atomic.StoreUint32(&s.done, 1)
+	}
+	return s.value, s.err
+}
+
+// Set replaces the field with v, nil clears it.
+func (l *Lazy[T]) Set(v *T) {
+	if v == nil {
+		l.s = nil
+		return
+	}
+	l.s = &lazyState[T]{value: v, done: 1}
+}
+
+// Clear clears the field.
+func (l *Lazy[T]) Clear() {
+	l.Set(nil)
+}
+
+// IsSome reports whether the field is present, without decoding it.
+func (l *Lazy[T]) IsSome() bool {
+	return l.s != nil
+}
+
+// state returns the encoded message and the decoded one, at most one of them
+// is not nil.
+func (l *Lazy[T]) state() ([]byte, *T) {
+	s := l.s
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.raw, s.value
+}
+
+// decoded returns the decoded message, nil if the field is absent or not
+// decoded yet. It is used by the decoding, which merges into it.
+func (l *Lazy[T]) decoded() *T {
+	if l.s == nil {
+		return nil
+	}
+	return l.s.value
+}
+
+// appendRaw adds the encoded message b to the encoded message of the field,
+// the occurrences of a message field are merged like their concatenated
+// encodings. The state is replaced as it may be shared with copies of the
+// field.
+func (l *Lazy[T]) appendRaw(b []byte) {
+	var raw []byte
+	if l.s != nil {
+		raw = l.s.raw
+	}
+	l.s = &lazyState[T]{raw: append(append(make([]byte, 0, len(raw)+len(b)), raw...), b...)}
+}
+
+// lazyField is implemented by the Lazy fields, the walker builds their
+// codecs from the codec of *T.
+type lazyField interface {
+	optionValue
+	codec(c *codec) *codec
+}
+
+var lazyFieldType = reflect.TypeOf((*lazyField)(nil)).Elem()
+
+// elemType returns the type *T of the value.
+func (l *Lazy[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil))
+}
+
+func (l *Lazy[T]) get() reflect.Value {
+	v, _ := l.Get()
+	return reflect.ValueOf(v)
+}
+
+func (l *Lazy[T]) set(v reflect.Value) {
+	l.Set(v.Interface().(*T))
+}
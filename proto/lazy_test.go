@@ -0,0 +1,139 @@
+package proto_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestLazy(t *testing.T) {
+	// the values of the payload are packed, the static codecs would encode
+	// them unpacked
+	payload := []byte{0x0a, 0x02, 'h', 'i', 0x12, 0x02, 0x01, 0x02}
+	b := append([]byte{0x0a, 0x01, 't', 0x12, byte(len(payload))}, payload...)
+
+	var m testproto.Envelope
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, "t", m.Topic)
+	assert.True(t, m.Payload.IsSome())
+	assert.True(t, Has(&m, 2))
+
+	// an untouched field is re-emitted as is
+	out, err := Marshal(&m)
+	require.NoError(t, err)
+	assert.Equal(t, b, out)
+	assert.Equal(t, len(b), Size(&m))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := m.Payload.Get()
+			assert.NoError(t, err)
+			assert.Equal(t, "hi", p.Body)
+		}()
+	}
+	wg.Wait()
+	p := m.GetPayload()
+	assert.Equal(t, []int32{1, 2}, p.Values)
+
+	// once decoded the field is encoded from the message
+	p.Body = "hello"
+	out, err = Marshal(&m)
+	require.NoError(t, err)
+	var m2 testproto.Envelope
+	require.NoError(t, Unmarshal(out, &m2))
+	assert.Equal(t, "hello", m2.GetPayload().Body)
+	assert.Equal(t, []int32{1, 2}, m2.GetPayload().Values)
+
+	m.Payload.Clear()
+	assert.False(t, m.Payload.IsSome())
+	assert.Nil(t, m.GetPayload())
+	out, err = Marshal(&m)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x01, 't'}, out)
+}
+
+func TestLazyMerge(t *testing.T) {
+	b := []byte{
+		0x12, 0x04, 0x0a, 0x02, 'h', 'i',
+		0x12, 0x02, 0x10, 0x07,
+	}
+	var m testproto.Envelope
+	require.NoError(t, Unmarshal(b, &m))
+	p, err := m.Payload.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "hi", p.Body)
+	assert.Equal(t, []int32{7}, p.Values)
+
+	// decoded fields are merged into
+	require.NoError(t, Unmarshal([]byte{0x12, 0x02, 0x10, 0x08}, &m))
+	assert.Equal(t, []int32{7, 8}, m.GetPayload().Values)
+}
+
+func TestLazyError(t *testing.T) {
+	// the payload holds a truncated string
+	b := []byte{0x12, 0x02, 0x0a, 0x05}
+	var m testproto.Envelope
+	require.NoError(t, Unmarshal(b, &m))
+	_, err := m.Payload.Get()
+	assert.Error(t, err)
+	_, again := m.Payload.Get()
+	assert.Equal(t, err, again)
+
+	out, err := Marshal(&m)
+	require.NoError(t, err)
+	assert.Equal(t, b, out)
+}
+
+func TestLazyReflect(t *testing.T) {
+	var m testproto.Envelope
+	f := Fields(&m)[1]
+	assert.Equal(t, MessageKind, f.Kind)
+	assert.Equal(t, "*testproto.Payload", f.Type.String())
+	assert.False(t, Has(&m, 2))
+
+	require.NoError(t, Set(&m, 2, &testproto.Payload{Body: "x"}))
+	assert.Equal(t, "x", Get(&m, 2).(*testproto.Payload).Body)
+	assert.Equal(t, "x", m.GetPayload().Body)
+	assert.NoError(t, m.Validate())
+
+	m.Payload.Set(&testproto.Payload{})
+	assert.Error(t, m.Validate())
+	Clear(&m, 2)
+	assert.False(t, m.Payload.IsSome())
+}
+
+func TestLazyCopy(t *testing.T) {
+	b := []byte{0x12, 0x04, 0x0a, 0x02, 'h', 'i'}
+	var m testproto.Envelope
+	require.NoError(t, Unmarshal(b, &m))
+
+	// a copy shares the message with the original
+	c := m
+	p, err := c.Payload.Get()
+	require.NoError(t, err)
+	assert.Same(t, p, m.GetPayload())
+
+	// Set and Clear only change their copy
+	c.Payload.Set(&testproto.Payload{Body: "copy"})
+	assert.Equal(t, "hi", m.GetPayload().Body)
+	c.Payload.Clear()
+	assert.True(t, m.Payload.IsSome())
+
+	// decoding into a copy doesn't change the encoding of the original
+	var m2 testproto.Envelope
+	require.NoError(t, Unmarshal(b, &m2))
+	c = m2
+	require.NoError(t, Unmarshal([]byte{0x12, 0x02, 0x10, 0x07}, &c))
+	assert.Equal(t, []int32{7}, c.GetPayload().Values)
+	out, err := Marshal(&m2)
+	require.NoError(t, err)
+	assert.Equal(t, b, out)
+}
@@ -0,0 +1,52 @@
+//go:build go1.21
+
+package proto
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// LogValue returns the message m as a slog group of its populated fields,
+// generated messages implement slog.LogValuer with it when generated with
+// the slog=true option of protoc-gen-golite. Nested messages are groups,
+// repeated fields, maps and bytes are rendered like Format does, and the
+// values of fields marked redact are replaced by Redacted.
+func LogValue(m interface{}) slog.Value {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		return slog.StringValue("<not a message>")
+	}
+	return logMessage(v)
+}
+
+func logMessage(m reflect.Value) slog.Value {
+	if m.IsNil() {
+		return slog.GroupValue()
+	}
+	v := m.Elem()
+	var attrs []slog.Attr
+	for _, f := range messageInfo(m.Type()).descs {
+		f := f
+		if !hasField(v, &f) {
+			continue
+		}
+		var val slog.Value
+		x := getField(v, &f)
+		switch {
+		case f.Redact:
+			val = slog.StringValue(Redacted)
+		case f.Cardinality != Repeated && (f.Kind == MessageKind || f.Kind == GroupKind):
+			val = logMessage(x)
+		case f.Cardinality != Repeated && f.Kind != BytesKind:
+			val = slog.AnyValue(x.Interface())
+		default:
+			var b strings.Builder
+			formatField(&b, &f, x)
+			val = slog.StringValue(b.String())
+		}
+		attrs = append(attrs, slog.Attr{Key: f.Name, Value: val})
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,35 @@
+//go:build go1.21
+
+package proto_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestLogValue(t *testing.T) {
+	m := &testproto.Account{
+		Id:    1,
+		Token: "secret",
+		Tags:  []string{"a"},
+		Owner: &testproto.Account{Name: "o", Token: "secret"},
+		Admin: Some(true),
+	}
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("login", "account", LogValue(m))
+	assert.Equal(t, `level=INFO msg=login account.Id=1 account.Token=[REDACTED] account.Tags="[\"a\"]" account.Owner.Name=o account.Owner.Token=[REDACTED] account.Admin=true`+"\n", b.String())
+}
@@ -1,7 +1,8 @@
+//go:build !purego
+
 package proto
 
 import (
-	"fmt"
 	"reflect"
 	"sync"
 	"unsafe"
@@ -9,10 +10,13 @@ import (
 	. "github.com/RomiChan/protobuf/internal/runtime_reflect"
 )
 
-const zeroSize = 1 // sizeOfVarint(0)
+// mapBackend names the implementation of the map codecs, for the benchmarks.
+const mapBackend = MapBackend
 
 type mapField struct {
 	wiretag  uint64
+	keyTag   string
+	valTag   string
 	keyField *structField
 	valField *structField
 }
@@ -29,6 +33,7 @@ func (w *walker) mapCodec(t reflect.Type, f *mapField) *codec {
 
 func mapSizeFuncOf(t reflect.Type, f *mapField) sizeFunc {
 	mapTagSize := sizeOfVarint(f.wiretag)
+	mtype := NewMapType(t)
 	keyCodec := f.keyField.codec
 	valCodec := f.valField.codec
 
@@ -43,21 +48,18 @@ func mapSizeFuncOf(t reflect.Type, f *mapField) sizeFunc {
 		m := MapIter{}
 		defer m.Done()
 
-		for m.Init(pointer(t), p); m.HasNext(); m.Next() {
+		for m.Init(mtype, p); m.HasNext(); m.Next() {
 			keySize := keyCodec.size(m.Key(), f.keyField)
 			valSize := valCodec.size(m.Value(), f.valField)
 			n += mapTagSize + sizeOfVarint(uint64(keySize+valSize)) + keySize + valSize
 		}
-		if n == 0 {
-			n = mapTagSize + zeroSize
-		}
 		return n
 	}
 }
 
 func mapEncodeFuncOf(t reflect.Type, f *mapField) encodeFunc {
 	mapTag := appendVarint(nil, f.wiretag)
-	zero := append(mapTag, 0)
+	mtype := NewMapType(t)
 	keyCodec := f.keyField.codec
 	valCodec := f.valField.codec
 
@@ -67,17 +69,15 @@ func mapEncodeFuncOf(t reflect.Type, f *mapField) encodeFunc {
 		}
 		p = *(*unsafe.Pointer)(p)
 
-		origLen := len(b)
-
 		m := MapIter{}
 		defer m.Done()
 
-		for m.Init(pointer(t), p); m.HasNext(); m.Next() {
+		for m.Init(mtype, p); m.HasNext(); m.Next() {
 			key := m.Key()
 			val := m.Value()
 
 			keySize := keyCodec.size(key, f.keyField)
-			valSize := keyCodec.size(val, f.valField)
+			valSize := valCodec.size(val, f.valField)
 			elemSize := keySize + valSize
 
 			b = append(b, mapTag...)
@@ -85,39 +85,30 @@ func mapEncodeFuncOf(t reflect.Type, f *mapField) encodeFunc {
 			b = keyCodec.encode(b, key, f.keyField)
 			b = valCodec.encode(b, val, f.valField)
 		}
-
-		if len(b) == origLen {
-			b = append(b, zero...)
-		}
 		return b
 	}
 }
 
-func formatWireTag(wire uint64) reflect.StructTag {
-	return reflect.StructTag(fmt.Sprintf(`protobuf:"%s,%d,opt"`, wireType(wire&7), wire>>3))
-}
-
 func mapDecodeFuncOf(t reflect.Type, m *mapField, w *walker) decodeFunc {
 	structType := reflect.StructOf([]reflect.StructField{
-		{Name: "Key", Type: t.Key(), Tag: formatWireTag(m.keyField.wiretag)},
-		{Name: "Elem", Type: t.Elem(), Tag: formatWireTag(m.valField.wiretag)},
+		{Name: "Key", Type: t.Key(), Tag: reflect.StructTag(`protobuf:"` + m.keyTag + `"`)},
+		{Name: "Elem", Type: t.Elem(), Tag: reflect.StructTag(`protobuf:"` + m.valTag + `"`)},
 	})
 
 	info := w.structInfo(structType)
 	structPool := new(sync.Pool)
 	structZero := pointer(reflect.Zero(structType).Interface())
 
-	valueType := t.Elem()
+	keyOffset := structType.Field(0).Offset
 	valueOffset := structType.Field(1).Offset
 
-	mtype := pointer(t)
+	mtype := NewMapType(t)
 	stype := pointer(structType)
-	vtype := pointer(valueType)
 
 	return func(b []byte, p unsafe.Pointer) (int, error) {
 		m := (*unsafe.Pointer)(p)
 		if *m == nil {
-			*m = MakeMap(mtype, 10)
+			*m = mtype.Make(10)
 		}
 		if len(b) == 0 {
 			return 0, nil
@@ -134,8 +125,7 @@ func mapDecodeFuncOf(t reflect.Type, m *mapField, w *walker) decodeFunc {
 		}
 		n, err := info.decode(b[nl:], s)
 		if err == nil {
-			v := MapAssign(mtype, *m, s)
-			Assign(vtype, v, unsafe.Pointer(uintptr(s)+valueOffset))
+			mtype.Assign(*m, unsafe.Pointer(uintptr(s)+keyOffset), unsafe.Pointer(uintptr(s)+valueOffset))
 		}
 		Assign(stype, s, structZero)
 		structPool.Put(s)
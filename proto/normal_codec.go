@@ -1,3 +1,5 @@
+//go:build !purego
+
 package proto
 
 import (
@@ -327,6 +329,60 @@ func decodeFixed64(b []byte, p unsafe.Pointer) (int, error) {
 	return n, err
 }
 
+var sfixed32Codec = codec{
+	size:   sizeOfSfixed32,
+	encode: encodeSfixed32,
+	decode: decodeSfixed32,
+}
+
+func sizeOfSfixed32(p unsafe.Pointer, f *structField) int {
+	if *(*int32)(p) != 0 {
+		return 4 + f.tagsize
+	}
+	return 0
+}
+
+func encodeSfixed32(b []byte, p unsafe.Pointer, f *structField) []byte {
+	if v := *(*int32)(p); v != 0 {
+		b = appendVarint(b, f.wiretag)
+		b = encodeLE32(b, uint32(v))
+	}
+	return b
+}
+
+func decodeSfixed32(b []byte, p unsafe.Pointer) (int, error) {
+	v, n, err := decodeLE32(b)
+	*(*int32)(p) = int32(v)
+	return n, err
+}
+
+var sfixed64Codec = codec{
+	size:   sizeOfSfixed64,
+	encode: encodeSfixed64,
+	decode: decodeSfixed64,
+}
+
+func sizeOfSfixed64(p unsafe.Pointer, f *structField) int {
+	if *(*int64)(p) != 0 {
+		return 8 + f.tagsize
+	}
+	return 0
+}
+
+func encodeSfixed64(b []byte, p unsafe.Pointer, f *structField) []byte {
+	if v := *(*int64)(p); v != 0 {
+		b = appendVarint(b, f.wiretag)
+		b = encodeLE64(b, uint64(v))
+	}
+	return b
+}
+
+func decodeSfixed64(b []byte, p unsafe.Pointer) (int, error) {
+	v, n, err := decodeLE64(b)
+	*(*int64)(p) = int64(v)
+	return n, err
+}
+
 var zigzag32Codec = codec{
 	size:   sizeOfZigzag32,
 	encode: encodeZigzag32,
@@ -1,7 +1,16 @@
 package proto
 
-import (
-	"unsafe"
+import "reflect"
+
+var (
+	optionBoolType    = reflect.TypeOf((*Option[bool])(nil)).Elem()
+	optionInt32Type   = reflect.TypeOf((*Option[int32])(nil)).Elem()
+	optionInt64Type   = reflect.TypeOf((*Option[int64])(nil)).Elem()
+	optionUInt32Type  = reflect.TypeOf((*Option[uint32])(nil)).Elem()
+	optionUInt64Type  = reflect.TypeOf((*Option[uint64])(nil)).Elem()
+	optionFloat32Type = reflect.TypeOf((*Option[float32])(nil)).Elem()
+	optionFloat64Type = reflect.TypeOf((*Option[float64])(nil)).Elem()
+	optionStringType  = reflect.TypeOf((*Option[string])(nil)).Elem()
 )
 
 type Option[T any] struct {
@@ -43,8 +52,61 @@ func (o Option[T]) UnwrapOr(defaultValue T) T {
 	return defaultValue
 }
 
-func (o *Option[T]) unsafePointer() unsafe.Pointer {
-	return unsafe.Pointer(&o.value)
+// FromPtr returns Some(*p), or None if p is nil.
+func FromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if o is None.
+func (o Option[T]) Ptr() *T {
+	if o.IsSome() {
+		v := o.value
+		return &v
+	}
+	return nil
+}
+
+// Set replaces the option with Some(val).
+func (o *Option[T]) Set(val T) {
+	*o = Some(val)
+}
+
+// Clear replaces the option with None.
+func (o *Option[T]) Clear() {
+	*o = None[T]()
+}
+
+// Take returns the option and leaves None in its place.
+func (o *Option[T]) Take() Option[T] {
+	v := *o
+	*o = None[T]()
+	return v
+}
+
+// optionValue gives the reflection API access to options of any type.
+type optionValue interface {
+	IsSome() bool
+	Clear()
+	get() reflect.Value
+	set(v reflect.Value)
+	elemType() reflect.Type
+}
+
+func (o *Option[T]) get() reflect.Value {
+	return reflect.ValueOf(o.Unwrap())
+}
+
+func (o *Option[T]) set(v reflect.Value) {
+	o.Set(v.Interface().(T))
+}
+
+// elemType returns the type T of the value, it can be called on a nil
+// pointer.
+func (o *Option[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
 }
 
 // Bool stores v in a new bool value and returns a pointer to it.
@@ -1,5 +1,7 @@
 // Code generated by gen/option/main.go. DO NOT EDIT.
 
+//go:build !purego
+
 package proto
 
 import "unsafe"
@@ -284,6 +286,62 @@ func decodeFixed64Option(b []byte, p unsafe.Pointer) (int, error) {
 	return decodeFixed64(b, v.unsafePointer())
 }
 
+var sfixed32OptionCodec = codec{
+	size:   sizeOfSfixed32Option,
+	encode: encodeSfixed32Option,
+	decode: decodeSfixed32Option,
+}
+
+func sizeOfSfixed32Option(p unsafe.Pointer, f *structField) int {
+	o := (*Option[int32])(p)
+	if o.IsSome() {
+		return sizeOfSfixed32Required(o.unsafePointer(), f)
+	}
+	return 0
+}
+
+func encodeSfixed32Option(b []byte, p unsafe.Pointer, f *structField) []byte {
+	o := (*Option[int32])(p)
+	if o.IsSome() {
+		return encodeSfixed32Required(b, o.unsafePointer(), f)
+	}
+	return b
+}
+
+func decodeSfixed32Option(b []byte, p unsafe.Pointer) (int, error) {
+	v := (*Option[int32])(p)
+	v.some = true
+	return decodeSfixed32(b, v.unsafePointer())
+}
+
+var sfixed64OptionCodec = codec{
+	size:   sizeOfSfixed64Option,
+	encode: encodeSfixed64Option,
+	decode: decodeSfixed64Option,
+}
+
+func sizeOfSfixed64Option(p unsafe.Pointer, f *structField) int {
+	o := (*Option[int64])(p)
+	if o.IsSome() {
+		return sizeOfSfixed64Required(o.unsafePointer(), f)
+	}
+	return 0
+}
+
+func encodeSfixed64Option(b []byte, p unsafe.Pointer, f *structField) []byte {
+	o := (*Option[int64])(p)
+	if o.IsSome() {
+		return encodeSfixed64Required(b, o.unsafePointer(), f)
+	}
+	return b
+}
+
+func decodeSfixed64Option(b []byte, p unsafe.Pointer) (int, error) {
+	v := (*Option[int64])(p)
+	v.some = true
+	return decodeSfixed64(b, v.unsafePointer())
+}
+
 var float32OptionCodec = codec{
 	size:   sizeOfFloat32Option,
 	encode: encodeFloat32Option,
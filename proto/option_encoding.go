@@ -0,0 +1,222 @@
+package proto
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	_ json.Marshaler           = Option[int32]{}
+	_ json.Unmarshaler         = (*Option[int32])(nil)
+	_ encoding.TextMarshaler   = Option[int32]{}
+	_ encoding.TextUnmarshaler = (*Option[int32])(nil)
+	_ sql.Scanner              = (*Option[int32])(nil)
+	_ driver.Valuer            = Option[int32]{}
+	_ fmt.Formatter            = Option[int32]{}
+)
+
+// MarshalJSON implements json.Marshaler. None is encoded as null.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if o.IsNone() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null is decoded as None.
+func (o *Option[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		o.Clear()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. None is encoded as empty
+// text, numbers and bools are formatted with strconv.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if o.IsNone() {
+		return []byte{}, nil
+	}
+	if m, ok := interface{}(o.value).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	v := reflect.ValueOf(o.value)
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(nil, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(nil, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.String:
+		return []byte(v.String()), nil
+	}
+	return nil, fmt.Errorf("proto: cannot marshal %s as text", v.Type())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text is decoded
+// as None, so Some("") does not survive a round trip.
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		o.Clear()
+		return nil
+	}
+	var v T
+	if u, ok := interface{}(&v).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText(text); err != nil {
+			return err
+		}
+		o.Set(v)
+		return nil
+	}
+	if err := convertAssign(reflect.ValueOf(&v).Elem(), string(text)); err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+// Scan implements sql.Scanner. A NULL column is scanned as None.
+func (o *Option[T]) Scan(src interface{}) error {
+	if src == nil {
+		o.Clear()
+		return nil
+	}
+	var v T
+	if s, ok := interface{}(&v).(sql.Scanner); ok {
+		if err := s.Scan(src); err != nil {
+			return err
+		}
+		o.Set(v)
+		return nil
+	}
+	if err := convertAssign(reflect.ValueOf(&v).Elem(), src); err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+// Value implements driver.Valuer. None is stored as NULL.
+func (o Option[T]) Value() (driver.Value, error) {
+	if o.IsNone() {
+		return nil, nil
+	}
+	if v, ok := interface{}(o.value).(driver.Valuer); ok {
+		return v.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// Format implements fmt.Formatter. Some(v) is formatted as v with the same
+// verb and flags, None as "None". With %#v the option is formatted as Go
+// syntax.
+func (o Option[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		if o.IsNone() {
+			fmt.Fprintf(f, "proto.None[%s]()", reflect.TypeOf((*T)(nil)).Elem())
+			return
+		}
+		fmt.Fprintf(f, "proto.Some[%T](%#v)", o.value, o.value)
+		return
+	}
+	if o.IsNone() {
+		io.WriteString(f, "None")
+		return
+	}
+	fmt.Fprintf(f, formatString(f, verb), o.value)
+}
+
+// formatString rebuilds the directive that invoked Format.
+func formatString(f fmt.State, verb rune) string {
+	b := []byte{'%'}
+	for _, flag := range " +-#0" {
+		if f.Flag(int(flag)) {
+			b = append(b, byte(flag))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	return string(append(b, string(verb)...))
+}
+
+// convertAssign stores src, one of the types produced by database/sql
+// drivers or a string, into dst.
+func convertAssign(dst reflect.Value, src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes(append([]byte(nil), v...))
+			return nil
+		}
+		s = string(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		sv := reflect.ValueOf(src)
+		if sv.Type().AssignableTo(dst.Type()) {
+			dst.Set(sv)
+			return nil
+		}
+		return fmt.Errorf("proto: unsupported conversion from %T to %s", src, dst.Type())
+	}
+
+	var err error
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("proto: unsupported conversion from %T to %s", src, dst.Type())
+		}
+		dst.SetBytes([]byte(s))
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, dst.Type().Bits())
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, dst.Type().Bits())
+		dst.SetFloat(f)
+	default:
+		return fmt.Errorf("proto: unsupported conversion from %T to %s", src, dst.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("proto: converting %T to %s: %w", src, dst.Type(), err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package proto_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+)
+
+func TestOptionPtr(t *testing.T) {
+	assert.Nil(t, None[int32]().Ptr())
+	assert.Equal(t, int32(1), *Some[int32](1).Ptr())
+
+	v := "hello"
+	assert.Equal(t, Some(v), FromPtr(&v))
+	assert.Equal(t, None[string](), FromPtr[string](nil))
+}
+
+func TestOptionMutators(t *testing.T) {
+	var o Option[int64]
+	o.Set(42)
+	assert.Equal(t, Int64(42), o)
+
+	taken := o.Take()
+	assert.Equal(t, Int64(42), taken)
+	assert.True(t, o.IsNone())
+
+	o.Set(1)
+	o.Clear()
+	assert.True(t, o.IsNone())
+}
+
+func TestOptionJSON(t *testing.T) {
+	type response struct {
+		Name  Option[string] `json:"name"`
+		Count Option[uint32] `json:"count"`
+	}
+
+	b, err := json.Marshal(response{Name: String("golite")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"golite","count":null}`, string(b))
+
+	var r response
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":null,"count":3}`), &r))
+	assert.Equal(t, response{Count: Uint32(3)}, r)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"count":"x"}`), &r))
+}
+
+func TestOptionText(t *testing.T) {
+	b, err := Float64(0.5).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", string(b))
+
+	b, err = None[bool]().MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+
+	var o Option[int32]
+	assert.NoError(t, o.UnmarshalText([]byte("-7")))
+	assert.Equal(t, Int32(-7), o)
+	assert.NoError(t, o.UnmarshalText(nil))
+	assert.True(t, o.IsNone())
+	assert.Error(t, o.UnmarshalText([]byte("2147483648")))
+}
+
+func TestOptionSQL(t *testing.T) {
+	v, err := Uint32(7).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), v)
+
+	v, err = None[string]().Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	var i Option[int32]
+	assert.NoError(t, i.Scan(int64(12)))
+	assert.Equal(t, Int32(12), i)
+	assert.NoError(t, i.Scan(nil))
+	assert.True(t, i.IsNone())
+	assert.Error(t, i.Scan(int64(1)<<40))
+
+	var s Option[string]
+	assert.NoError(t, s.Scan([]byte("row")))
+	assert.Equal(t, String("row"), s)
+
+	var f Option[float32]
+	assert.NoError(t, f.Scan(float64(1.5)))
+	assert.Equal(t, Float32(1.5), f)
+}
+
+func TestOptionFormat(t *testing.T) {
+	assert.Equal(t, "3", fmt.Sprint(Int32(3)))
+	assert.Equal(t, "None", fmt.Sprint(None[int32]()))
+	assert.Equal(t, "  1.50", fmt.Sprintf("%6.2f", Float64(1.5)))
+	assert.Equal(t, `"hi"`, fmt.Sprintf("%q", String("hi")))
+	assert.Equal(t, `proto.Some[string]("hi")`, fmt.Sprintf("%#v", String("hi")))
+	assert.Equal(t, `proto.None[uint64]()`, fmt.Sprintf("%#v", None[uint64]()))
+
+	type message struct {
+		A Option[int32]
+		B Option[string]
+	}
+	assert.Equal(t, "{A:1 B:None}", fmt.Sprintf("%+v", message{A: Int32(1)}))
+}
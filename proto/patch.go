@@ -0,0 +1,274 @@
+package proto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// maxFieldNumber is the largest valid field number.
+const maxFieldNumber = 1<<29 - 1
+
+// Fixed32 is the value of a fixed32 or sfixed32 field for SetField and
+// AppendField, like proto.Fixed32(v).
+type Fixed32 uint32
+
+// Fixed64 is the value of a fixed64 or sfixed64 field for SetField and
+// AppendField.
+type Fixed64 uint64
+
+// ZigZag is the value of a sint32 or sint64 field for SetField and
+// AppendField, which is zigzag encoded.
+type ZigZag int64
+
+// SetField returns a copy of the encoded message b where the field at path,
+// a list of field numbers from the outer message to the field, holds the
+// value v. The first occurrence of the field in the last occurrence of its
+// message is replaced and the other occurrences are removed, from the earlier
+// occurrences of the message too, so that the decoding, which merges them,
+// sees v. A missing field is appended to its message. The messages enclosing
+// the field are created if they are missing, and their length prefixes are
+// updated.
+//
+// The wire type of the field follows from the type of v: the booleans and
+// integers are varints, float32 and float64 fixed32 and fixed64, strings and
+// byte slices bytes, and pointers to structs messages, which are marshaled.
+// The Fixed32, Fixed64 and ZigZag types select the encoding of the fixed,
+// sfixed and sint integer fields.
+func SetField(b []byte, path []int, v interface{}) ([]byte, error) {
+	typ, val, err := wireValueOf(v)
+	if err != nil {
+		return nil, fmt.Errorf("proto.SetField(%v): %w", path, err)
+	}
+	b, err = editField(b, path, true, func(m []byte, num fieldNumber, last bool) []byte {
+		if !last {
+			return deleteOccurrences(m, num)
+		}
+		out := make([]byte, 0, len(m)+len(val)+sizeOfVarint(uint64(num)<<3))
+		set := false
+		forEachField(m, func(f fieldNumber, start, end int) {
+			switch {
+			case f != num:
+				out = append(out, m[start:end]...)
+			case !set:
+				out = appendTag(out, num, typ)
+				out = append(out, val...)
+				set = true
+			}
+		})
+		if !set {
+			out = appendTag(out, num, typ)
+			out = append(out, val...)
+		}
+		return out
+	})
+	if err != nil {
+		return nil, fmt.Errorf("proto.SetField(%v): %w", path, err)
+	}
+	return b, nil
+}
+
+// AppendField is like SetField, but adds an occurrence of the field at the
+// end of the last occurrence of its message, for repeated fields. The other
+// occurrences are kept.
+func AppendField(b []byte, path []int, v interface{}) ([]byte, error) {
+	typ, val, err := wireValueOf(v)
+	if err != nil {
+		return nil, fmt.Errorf("proto.AppendField(%v): %w", path, err)
+	}
+	b, err = editField(b, path, true, func(m []byte, num fieldNumber, last bool) []byte {
+		if !last {
+			return m
+		}
+		out := make([]byte, 0, len(m)+len(val)+sizeOfVarint(uint64(num)<<3))
+		out = append(out, m...)
+		out = appendTag(out, num, typ)
+		return append(out, val...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("proto.AppendField(%v): %w", path, err)
+	}
+	return b, nil
+}
+
+// DeleteField returns a copy of the encoded message b without the
+// occurrences of the field at path, in every occurrence of its message, see
+// SetField. The length prefixes of the messages enclosing the field are
+// updated, b is returned as is if one of them is missing.
+func DeleteField(b []byte, path []int) ([]byte, error) {
+	b, err := editField(b, path, false, func(m []byte, num fieldNumber, _ bool) []byte {
+		return deleteOccurrences(m, num)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("proto.DeleteField(%v): %w", path, err)
+	}
+	return b, nil
+}
+
+// deleteOccurrences returns a copy of the message m without the occurrences
+// of the field num.
+func deleteOccurrences(m []byte, num fieldNumber) []byte {
+	out := make([]byte, 0, len(m))
+	forEachField(m, func(f fieldNumber, start, end int) {
+		if f != num {
+			out = append(out, m[start:end]...)
+		}
+	})
+	return out
+}
+
+// editField applies edit to every occurrence of the message holding the
+// field at path, nested in the message b, and returns b with the edited
+// messages. last tells edit whether the occurrence is the last one, which
+// the decoding merges into the others and where the field is set. A missing
+// enclosing message is created if create is true, otherwise b is returned as
+// is.
+func editField(b []byte, path []int, create bool, edit func(m []byte, num fieldNumber, last bool) []byte) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+	for _, num := range path {
+		if num < 1 || num > maxFieldNumber {
+			return nil, fmt.Errorf("invalid field number %d", num)
+		}
+	}
+	return editMessage(b, path, create, true, edit)
+}
+
+// editMessage is editField for the message b, last reports whether b is the
+// last occurrence of the messages enclosing it.
+func editMessage(b []byte, path []int, create, last bool, edit func(m []byte, num fieldNumber, last bool) []byte) ([]byte, error) {
+	// check that b is well formed before forEachField walks it
+	if err := checkFields(b); err != nil {
+		return nil, err
+	}
+	num := fieldNumber(path[0])
+	if len(path) == 1 {
+		return edit(b, num, last), nil
+	}
+
+	var bounds [][2]int
+	forEachField(b, func(f fieldNumber, s, e int) {
+		if f == num {
+			bounds = append(bounds, [2]int{s, e})
+		}
+	})
+	if len(bounds) == 0 {
+		if !create || !last {
+			return b, nil
+		}
+		bounds = append(bounds, [2]int{len(b), len(b)})
+	}
+
+	out := make([]byte, 0, len(b)+binary.MaxVarintLen64)
+	prev := 0
+	for i, r := range bounds {
+		start, end := r[0], r[1]
+		var inner []byte
+		if start < end {
+			t, v, err := fieldValue(b[start:end])
+			if err != nil {
+				return nil, err
+			}
+			if t != varlen {
+				return nil, fieldError(num, t, fmt.Errorf("not a message"))
+			}
+			inner = v
+		}
+		inner, err := editMessage(inner, path[1:], create, last && i == len(bounds)-1, edit)
+		if err != nil {
+			return nil, fieldError(num, varlen, err)
+		}
+		out = append(out, b[prev:start]...)
+		out = appendTag(out, num, varlen)
+		out = appendVarint(out, uint64(len(inner)))
+		out = append(out, inner...)
+		prev = end
+	}
+	return append(out, b[prev:]...), nil
+}
+
+// checkFields returns an error if b is not a sequence of valid fields.
+func checkFields(b []byte) error {
+	d := NewDecoder(b)
+	for {
+		_, _, err := d.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// forEachField calls fn with the number and the bounds of the fields of b,
+// which must have been checked with checkFields.
+func forEachField(b []byte, fn func(f fieldNumber, start, end int)) {
+	for offset := 0; offset < len(b); {
+		f, t, n, _ := decodeTag(b[offset:])
+		m, _ := skipField(b[offset+n:], f, t)
+		fn(f, offset, offset+n+m)
+		offset += n + m
+	}
+}
+
+// fieldValue returns the wire type and the value of the encoded field b.
+func fieldValue(b []byte) (wireType, []byte, error) {
+	_, t, n, err := decodeTag(b)
+	if err != nil {
+		return t, nil, err
+	}
+	if t != varlen {
+		return t, b[n:], nil
+	}
+	v, _, err := decodeVarlen(b[n:])
+	return t, v, err
+}
+
+// wireValueOf returns the wire type and the encoding of the value v.
+func wireValueOf(v interface{}) (wireType, []byte, error) {
+	switch x := v.(type) {
+	case bool:
+		if x {
+			return varint, []byte{1}, nil
+		}
+		return varint, []byte{0}, nil
+	case int:
+		return varint, appendVarint(nil, uint64(x)), nil
+	case int32:
+		return varint, appendVarint(nil, uint64(x)), nil
+	case int64:
+		return varint, appendVarint(nil, uint64(x)), nil
+	case uint:
+		return varint, appendVarint(nil, uint64(x)), nil
+	case uint32:
+		return varint, appendVarint(nil, uint64(x)), nil
+	case uint64:
+		return varint, appendVarint(nil, x), nil
+	case Fixed32:
+		return fixed32, encodeLE32(nil, uint32(x)), nil
+	case Fixed64:
+		return fixed64, encodeLE64(nil, uint64(x)), nil
+	case ZigZag:
+		return varint, appendVarint(nil, encodeZigZag64(int64(x))), nil
+	case float32:
+		return fixed32, encodeLE32(nil, math.Float32bits(x)), nil
+	case float64:
+		return fixed64, encodeLE64(nil, math.Float64bits(x)), nil
+	case string:
+		return varlen, append(appendVarint(nil, uint64(len(x))), x...), nil
+	case []byte:
+		return varlen, append(appendVarint(nil, uint64(len(x))), x...), nil
+	}
+	if t := reflect.TypeOf(v); t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+		m, err := Marshal(v)
+		if err != nil {
+			return 0, nil, err
+		}
+		return varlen, append(appendVarint(nil, uint64(len(m))), m...), nil
+	}
+	return 0, nil, fmt.Errorf("unsupported value type %T", v)
+}
@@ -0,0 +1,208 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestSetField(t *testing.T) {
+	b, err := Marshal(&testproto.Proto2{
+		Int32Val: Some[int32](1),
+		Nested:   &testproto.Proto2_NestedMessage{Int64Val: Some[int64](2), StringVal: Some("a")},
+		FloatVal: Some[float32](1.5),
+	})
+	require.NoError(t, err)
+	orig := append([]byte(nil), b...)
+
+	b, err = SetField(b, []int{14, 3}, "a longer string")
+	require.NoError(t, err)
+	b, err = SetField(b, []int{2}, int32(-7))
+	require.NoError(t, err)
+	b, err = SetField(b, []int{6}, float32(2.5))
+	require.NoError(t, err)
+	assert.NotEqual(t, orig, b)
+
+	var m testproto.Proto2
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, int32(-7), m.GetInt32Val())
+	assert.Equal(t, float32(2.5), m.GetFloatVal())
+	assert.Equal(t, int64(2), m.GetNested().GetInt64Val())
+	assert.Equal(t, "a longer string", m.GetNested().GetStringVal())
+
+	// the enclosing messages are created
+	b, err = SetField(nil, []int{14, 1}, 5)
+	require.NoError(t, err)
+	m = testproto.Proto2{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, int32(5), m.GetNested().GetInt32Val())
+
+	// a message value
+	b, err = SetField(nil, []int{14}, &testproto.Proto2_NestedMessage{StringVal: Some("b")})
+	require.NoError(t, err)
+	m = testproto.Proto2{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, "b", m.GetNested().GetStringVal())
+}
+
+func TestSetFieldWireTypes(t *testing.T) {
+	var b []byte
+	var err error
+	sfixed32, sfixed64 := int32(-11), int64(-12)
+	for _, f := range []struct {
+		num int
+		v   interface{}
+	}{
+		{10, Fixed32(7)},
+		{11, Fixed64(8)},
+		{12, ZigZag(-9)},
+		{13, ZigZag(-10)},
+		{15, Fixed32(sfixed32)},
+		{16, Fixed64(sfixed64)},
+	} {
+		b, err = SetField(b, []int{f.num}, f.v)
+		require.NoError(t, err)
+	}
+
+	var m testproto.Proto2
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, uint32(7), m.GetFixed32Val())
+	assert.Equal(t, uint64(8), m.GetFixed64Val())
+	assert.Equal(t, int32(-9), m.GetSint32Val())
+	assert.Equal(t, int64(-10), m.GetSint64Val())
+	assert.Equal(t, int32(-11), m.GetSfixed32Val())
+	assert.Equal(t, int64(-12), m.GetSfixed64Val())
+
+	b, err = SetField(nil, []int{12}, ZigZag(-1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x01}, b)
+	b, err = SetField(nil, []int{10}, Fixed32(1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x55, 0x01, 0x00, 0x00, 0x00}, b)
+}
+
+func TestSetFieldReplacesOccurrences(t *testing.T) {
+	// field 1 appears twice, around field 2
+	b := []byte{0x08, 0x01, 0x10, 0x02, 0x08, 0x03}
+	b, err := SetField(b, []int{1}, uint64(9))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x09, 0x10, 0x02}, b)
+}
+
+func TestAppendField(t *testing.T) {
+	b, err := Marshal(&decoderBatch{Seq: 1, Items: []*decoderItem{{ID: 1}}})
+	require.NoError(t, err)
+	b, err = AppendField(b, []int{2}, &decoderItem{ID: 2, Name: "b"})
+	require.NoError(t, err)
+
+	var m decoderBatch
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, []*decoderItem{{ID: 1}, {ID: 2, Name: "b"}}, m.Items)
+}
+
+func TestDeleteField(t *testing.T) {
+	b, err := Marshal(&testproto.Proto2{
+		Int32Val: Some[int32](1),
+		Nested:   &testproto.Proto2_NestedMessage{Int64Val: Some[int64](2), StringVal: Some("a")},
+	})
+	require.NoError(t, err)
+
+	b, err = DeleteField(b, []int{14, 3})
+	require.NoError(t, err)
+	var m testproto.Proto2
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, int32(1), m.GetInt32Val())
+	assert.Equal(t, int64(2), m.GetNested().GetInt64Val())
+	assert.False(t, m.GetNested().StringVal.IsSome())
+
+	// a missing enclosing message is not created
+	c, err := DeleteField(b, []int{15, 1})
+	require.NoError(t, err)
+	assert.Equal(t, b, c)
+
+	b, err = DeleteField(b, []int{14})
+	require.NoError(t, err)
+	m = testproto.Proto2{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Nil(t, m.Nested)
+}
+
+// duplicatedBatch returns a message whose field 1 holds a batch encoded in
+// two occurrences, with an item in each.
+func duplicatedBatch(t *testing.T) []byte {
+	t.Helper()
+	var b []byte
+	for _, batch := range []*decoderBatch{
+		{Seq: 1, Items: []*decoderItem{{ID: 1}}},
+		{Items: []*decoderItem{{ID: 2}}, Delta: -1},
+	} {
+		m, err := Marshal(batch)
+		require.NoError(t, err)
+		b = append(b, 0x0a, byte(len(m)))
+		b = append(b, m...)
+	}
+	return b
+}
+
+type batchHolder struct {
+	Batch *decoderBatch `protobuf:"bytes,1,opt"`
+}
+
+func TestPatchDuplicatedMessages(t *testing.T) {
+	var m batchHolder
+	require.NoError(t, Unmarshal(duplicatedBatch(t), &m))
+	assert.Equal(t, []*decoderItem{{ID: 1}, {ID: 2}}, m.Batch.Items)
+
+	// the items of the earlier occurrence are removed too
+	b, err := SetField(duplicatedBatch(t), []int{1, 2}, &decoderItem{ID: 3})
+	require.NoError(t, err)
+	m = batchHolder{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, &decoderBatch{Seq: 1, Items: []*decoderItem{{ID: 3}}, Delta: -1}, m.Batch)
+
+	b, err = DeleteField(duplicatedBatch(t), []int{1, 2})
+	require.NoError(t, err)
+	m = batchHolder{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, &decoderBatch{Seq: 1, Delta: -1}, m.Batch)
+
+	b, err = AppendField(duplicatedBatch(t), []int{1, 2}, &decoderItem{ID: 3})
+	require.NoError(t, err)
+	m = batchHolder{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, []*decoderItem{{ID: 1}, {ID: 2}, {ID: 3}}, m.Batch.Items)
+
+	// a scalar set in the last occurrence is removed from the others
+	b, err = SetField(duplicatedBatch(t), []int{1, 1}, uint64(5))
+	require.NoError(t, err)
+	first, err := DeleteField(b[:b[1]+2], []int{1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, first, b[:b[1]+2])
+	m = batchHolder{}
+	require.NoError(t, Unmarshal(b, &m))
+	assert.Equal(t, uint64(5), m.Batch.Seq)
+
+	// the path is created in the last occurrence only
+	b, err = SetField(duplicatedBatch(t), []int{1, 5, 1}, 7)
+	require.NoError(t, err)
+	n := int(b[1]) + 2
+	assert.Equal(t, duplicatedBatch(t)[:n], b[:n])
+}
+
+func TestPatchErrors(t *testing.T) {
+	b := []byte{0x08, 0x01}
+	_, err := SetField(b, []int{1, 2}, 1)
+	assert.Error(t, err, "field 1 is not a message")
+	_, err = SetField(b, nil, 1)
+	assert.Error(t, err)
+	_, err = SetField(b, []int{0}, 1)
+	assert.Error(t, err)
+	_, err = SetField(b, []int{2}, struct{}{})
+	assert.Error(t, err)
+	_, err = DeleteField([]byte{0x0a, 0x05}, []int{1})
+	assert.Error(t, err)
+}
@@ -3,35 +3,36 @@ package proto
 import (
 	"fmt"
 	"reflect"
-	"sync"
-	"unsafe"
-
-	"github.com/RomiChan/syncx"
 )
 
 //go:generate go run ./gen/option
 //go:generate go run ./gen/required
 
 func Size(v interface{}) int {
-	t, p := inspect(v)
-	if t.Kind() != reflect.Ptr {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
 		panic(fmt.Errorf("proto.Marshal(%T): not a pointer", v))
 	}
-	t = t.Elem()
-	info := cachedStructInfoOf(t)
-	return info.size(p)
+	return cachedStructInfoOf(t.Elem()).messageSize(v)
 }
 
 func Marshal(v interface{}) ([]byte, error) {
-	t, p := inspect(v)
-	if t.Kind() != reflect.Ptr {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
 		return nil, fmt.Errorf("proto.Marshal(%T): not a pointer", v)
 	}
-	t = t.Elem()
-	info := cachedStructInfoOf(t)
-	b := make([]byte, 0, info.size(p))
-	b = info.encode(b, p)
-	return b, nil
+	info := cachedStructInfoOf(t.Elem())
+	b := make([]byte, 0, info.messageSize(v))
+	return info.appendMessage(b, v), nil
+}
+
+// MarshalAppend appends the encoding of v to b and returns the result.
+func MarshalAppend(b []byte, v interface{}) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return b, fmt.Errorf("proto.MarshalAppend(%T): not a pointer", v)
+	}
+	return cachedStructInfoOf(t.Elem()).appendMessage(b, v), nil
 }
 
 func Unmarshal(b []byte, v interface{}) error {
@@ -40,8 +41,8 @@ func Unmarshal(b []byte, v interface{}) error {
 		return nil
 	}
 
-	t, p := inspect(v)
-	if t.Kind() != reflect.Pointer || p == nil {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer || reflect.ValueOf(v).IsNil() {
 		return &InvalidUnmarshalError{Type: t}
 	}
 	elem := t.Elem()
@@ -50,7 +51,7 @@ func Unmarshal(b []byte, v interface{}) error {
 	}
 	c := cachedStructInfoOf(elem)
 
-	n, err := c.decode(b, p)
+	n, err := c.decodeMessage(b, v)
 	if err != nil {
 		return err
 	}
@@ -60,28 +61,17 @@ func Unmarshal(b []byte, v interface{}) error {
 	return nil
 }
 
-type iface struct {
-	typ unsafe.Pointer
-	ptr unsafe.Pointer
-}
-
-func inspect(v interface{}) (reflect.Type, unsafe.Pointer) {
-	return reflect.TypeOf(v), pointer(v)
-}
-
-func pointer(v interface{}) unsafe.Pointer {
-	return (*iface)(unsafe.Pointer(&v)).ptr
-}
-
 type fieldNumber uint
 
 type wireType uint
 
 const (
-	varint  wireType = 0
-	fixed64 wireType = 1
-	varlen  wireType = 2
-	fixed32 wireType = 5
+	varint     wireType = 0
+	fixed64    wireType = 1
+	varlen     wireType = 2
+	startGroup wireType = 3
+	endGroup   wireType = 4
+	fixed32    wireType = 5
 )
 
 func (wt wireType) String() string {
@@ -94,32 +84,9 @@ func (wt wireType) String() string {
 		return "fixed32"
 	case fixed64:
 		return "fixed64"
+	case startGroup:
+		return "group"
 	default:
 		return "unknown"
 	}
 }
-
-type codec struct {
-	size   sizeFunc
-	encode encodeFunc
-	decode decodeFunc
-}
-
-var structInfoCache syncx.Map[unsafe.Pointer, *structInfo] // map[unsafe.Pointer]*structInfo
-var codecCache sync.Map                                    // map[reflect.Type]codec
-
-func cachedStructInfoOf(t reflect.Type) *structInfo {
-	c, ok := structInfoCache.Load(pointer(t))
-	if ok {
-		return c
-	}
-
-	w := &walker{
-		codecs: make(map[reflect.Type]*codec),
-		infos:  make(map[reflect.Type]*structInfo),
-	}
-
-	info := w.structInfo(t)
-	actual, _ := structInfoCache.LoadOrStore(pointer(t), info)
-	return actual
-}
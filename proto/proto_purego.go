@@ -0,0 +1,751 @@
+//go:build purego
+
+package proto
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// This file holds the codecs of the purego build. They work on reflect
+// values instead of unsafe pointers to the struct fields and don't link to
+// the runtime internals, but otherwise mirror walker.go and struct.go and
+// produce the same encoding.
+
+type sizeFunc = func(reflect.Value, *structField) int
+
+type encodeFunc = func([]byte, reflect.Value, *structField) []byte
+
+type decodeFunc = func([]byte, reflect.Value) (int, error)
+
+type codec struct {
+	size   sizeFunc
+	encode encodeFunc
+	decode decodeFunc
+
+	// decodePacked decodes the packed encoding of repeated fields, it is nil
+	// for codecs that cannot be packed.
+	decodePacked decodeFunc
+}
+
+type structInfo struct {
+	fields     []*structField
+	fieldIndex map[fieldNumber]*structField
+
+	// descriptors of the fields for the reflection API
+	descs []Field
+
+	// the Extensions field, nil if the message has no extension ranges
+	extensions *extensionRanges
+}
+
+type structField struct {
+	index   int
+	wiretag uint64
+	codec   *codec
+	tagsize int
+}
+
+func (f *structField) fieldNumber() fieldNumber {
+	return fieldNumber(f.wiretag >> 3)
+}
+
+func (f *structField) wireType() wireType {
+	return wireType(f.wiretag & 7)
+}
+
+var structInfoCache sync.Map // map[reflect.Type]*structInfo
+var codecCache sync.Map      // map[reflect.Type]*codec
+var groupCodecCache sync.Map // map[reflect.Type]*codec
+
+func cachedStructInfoOf(t reflect.Type) *structInfo {
+	if c, ok := structInfoCache.Load(t); ok {
+		return c.(*structInfo)
+	}
+
+	w := &walker{
+		codecs:   make(map[reflect.Type]*codec),
+		groups:   make(map[reflect.Type]*codec),
+		infos:    make(map[reflect.Type]*structInfo),
+		pointers: make(map[pointerKey]*codec),
+	}
+
+	info := w.structInfo(t)
+	actual, _ := structInfoCache.LoadOrStore(t, info)
+	return actual.(*structInfo)
+}
+
+// messageSize returns the size of the message m, a pointer to a struct of
+// the type of info.
+func (info *structInfo) messageSize(m interface{}) int {
+	v := reflect.ValueOf(m)
+	if v.IsNil() {
+		return 0
+	}
+	return info.size(v.Elem())
+}
+
+// appendMessage appends the encoding of the message m to b.
+func (info *structInfo) appendMessage(b []byte, m interface{}) []byte {
+	v := reflect.ValueOf(m)
+	if v.IsNil() {
+		return b
+	}
+	return info.encode(b, v.Elem())
+}
+
+// decodeMessage decodes the fields in b into the message m.
+func (info *structInfo) decodeMessage(b []byte, m interface{}) (int, error) {
+	return info.decode(b, reflect.ValueOf(m).Elem())
+}
+
+func (info *structInfo) size(v reflect.Value) int {
+	n := 0
+	for _, f := range info.fields {
+		n += f.codec.size(v.Field(f.index), f)
+	}
+	if info.extensions != nil {
+		n += info.extensions.value(v).size()
+	}
+	return n
+}
+
+func (info *structInfo) encode(b []byte, v reflect.Value) []byte {
+	for _, f := range info.fields {
+		b = f.codec.encode(b, v.Field(f.index), f)
+	}
+	if info.extensions != nil {
+		b = info.extensions.value(v).encode(b)
+	}
+	return b
+}
+
+func (info *structInfo) decode(b []byte, v reflect.Value) (int, error) {
+	offset := 0
+	for offset < len(b) {
+		start := offset
+		fieldNumber, wireType, n, err := decodeTag(b[offset:])
+		offset += n
+		if err != nil {
+			return offset, err
+		}
+
+		f := info.fieldIndex[fieldNumber]
+		if f == nil {
+			skip, err := skipField(b[offset:], fieldNumber, wireType)
+			if (offset + skip) <= len(b) {
+				offset += skip
+			} else {
+				offset, err = len(b), io.ErrUnexpectedEOF
+			}
+			if err == nil && info.extensions != nil && info.extensions.contains(fieldNumber) {
+				err = info.extensions.value(v).appendRaw(fieldNumber, b[start:offset])
+			}
+			if err != nil {
+				return offset, fieldError(fieldNumber, wireType, err)
+			}
+			continue
+		}
+
+		decode := f.codec.decode
+		if wireType != f.wireType() {
+			if wireType != varlen || f.codec.decodePacked == nil {
+				return offset, fieldError(fieldNumber, wireType, fmt.Errorf("expected wire type %d", f.wireType()))
+			}
+			decode = f.codec.decodePacked
+		}
+
+		// `data` will only contain the section of the input buffer where
+		// the data for the next field is available. This is necessary to
+		// limit how many bytes will be consumed by embedded messages.
+		var data []byte
+		switch wireType {
+		case varint:
+			_, n, err := decodeVarint(b[offset:])
+			if err != nil {
+				return offset, fieldError(fieldNumber, wireType, err)
+			}
+			data = b[offset : offset+n]
+
+		case varlen:
+			l, n, err := decodeVarint(b[offset:])
+			if err != nil {
+				return offset + n, fieldError(fieldNumber, wireType, err)
+			}
+			if l > uint64(len(b)-(offset+n)) {
+				return len(b), fieldError(fieldNumber, wireType, io.ErrUnexpectedEOF)
+			}
+			data = b[offset : offset+n+int(l)]
+
+		case fixed32:
+			if (offset + 4) > len(b) {
+				return len(b), fieldError(fieldNumber, wireType, io.ErrUnexpectedEOF)
+			}
+			data = b[offset : offset+4]
+
+		case fixed64:
+			if (offset + 8) > len(b) {
+				return len(b), fieldError(fieldNumber, wireType, io.ErrUnexpectedEOF)
+			}
+			data = b[offset : offset+8]
+
+		case startGroup:
+			n, err := decodeGroup(b[offset:], fieldNumber)
+			if err != nil {
+				return offset + n, fieldError(fieldNumber, wireType, err)
+			}
+			data = b[offset : offset+n]
+
+		default:
+			return offset, fieldError(fieldNumber, wireType, ErrWireTypeUnknown)
+		}
+
+		if wireType == startGroup {
+			// the end group tag is as long as the start group tag
+			data = data[:len(data)-f.tagsize]
+		}
+		n, err = decode(data, v.Field(f.index))
+		offset += n
+		if err != nil {
+			return offset, fieldError(fieldNumber, wireType, err)
+		}
+		if wireType == startGroup {
+			offset += f.tagsize
+		}
+	}
+
+	return offset, nil
+}
+
+// value returns the Extensions field of the struct v.
+func (r *extensionRanges) value(v reflect.Value) *Extensions {
+	return v.Field(r.index).Addr().Interface().(*Extensions)
+}
+
+// of returns the Extensions field of the message m.
+func (r *extensionRanges) of(m interface{}) *Extensions {
+	return r.value(reflect.ValueOf(m).Elem())
+}
+
+type walker struct {
+	codecs   map[reflect.Type]*codec
+	groups   map[reflect.Type]*codec
+	infos    map[reflect.Type]*structInfo
+	pointers map[pointerKey]*codec
+}
+
+type walkerConfig struct {
+	wireType wireType
+	zigzag   bool
+	required bool
+	presence bool // a non-nil pointer to the zero value is encoded
+}
+
+// pointerKey identifies the codec of a pointer to a scalar, which depends on
+// the configuration of the field as well as on the type.
+type pointerKey struct {
+	t    reflect.Type
+	conf walkerConfig
+}
+
+func (w *walker) codec(t reflect.Type, conf *walkerConfig) *codec {
+	if c, ok := w.codecs[t]; ok {
+		return c
+	}
+	if c := scalarCodec(t, conf); c != nil {
+		return c
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if conf.required {
+			panic("nested message must be pointer:" + t.String())
+		}
+	case reflect.Ptr:
+		return w.pointer(t, conf)
+	}
+	panic("unsupported type: " + t.String())
+}
+
+func (w *walker) structCodec(t reflect.Type) *codec {
+	if c, ok := codecCache.Load(t); ok {
+		return c.(*codec)
+	}
+	if c, ok := w.codecs[t]; ok {
+		return c
+	}
+	c := new(codec)
+	w.codecs[t] = c
+	elem := t.Elem()
+	info := w.structInfo(elem)
+	c.size = func(v reflect.Value, f *structField) int {
+		if v.IsNil() {
+			return 0
+		}
+		n := info.size(v.Elem())
+		return n + sizeOfVarint(uint64(n)) + f.tagsize
+	}
+	c.encode = func(b []byte, v reflect.Value, f *structField) []byte {
+		if v.IsNil() {
+			return b
+		}
+		b = appendVarint(b, f.wiretag)
+		b = appendVarint(b, uint64(info.size(v.Elem())))
+		return info.encode(b, v.Elem())
+	}
+	c.decode = func(b []byte, v reflect.Value) (int, error) {
+		if v.IsNil() {
+			v.Set(reflect.New(elem))
+		}
+		_, n, err := decodeVarint(b)
+		if err != nil {
+			return n, err
+		}
+		l, err := info.decode(b[n:], v.Elem())
+		return n + l, err
+	}
+	actualCodec, _ := codecCache.LoadOrStore(t, c)
+	return actualCodec.(*codec)
+}
+
+// groupCodec returns the codec of a message encoded with the deprecated group
+// encoding, where the fields are delimited by start and end group tags.
+func (w *walker) groupCodec(t reflect.Type) *codec {
+	if c, ok := groupCodecCache.Load(t); ok {
+		return c.(*codec)
+	}
+	if c, ok := w.groups[t]; ok {
+		return c
+	}
+	c := new(codec)
+	w.groups[t] = c
+	elem := t.Elem()
+	info := w.structInfo(elem)
+	c.size = func(v reflect.Value, f *structField) int {
+		if v.IsNil() {
+			return 0
+		}
+		return info.size(v.Elem()) + 2*f.tagsize
+	}
+	c.encode = func(b []byte, v reflect.Value, f *structField) []byte {
+		if v.IsNil() {
+			return b
+		}
+		b = appendVarint(b, f.wiretag)
+		b = info.encode(b, v.Elem())
+		return appendVarint(b, f.wiretag&^7|uint64(endGroup))
+	}
+	c.decode = func(b []byte, v reflect.Value) (int, error) {
+		// b only holds the fields of the group, the end group tag is
+		// consumed by the caller.
+		if v.IsNil() {
+			v.Set(reflect.New(elem))
+		}
+		return info.decode(b, v.Elem())
+	}
+	actualCodec, _ := groupCodecCache.LoadOrStore(t, c)
+	return actualCodec.(*codec)
+}
+
+// oneofField returns the field for the wrapper type wt of the oneof field f.
+// The wrapper is a pointer to a struct with a single field, which is encoded
+// as long as the wrapper is set, even if it holds the zero value.
+func (w *walker) oneofField(f reflect.StructField, wt reflect.Type) *structField {
+	inner := wt.Elem().Field(0)
+	t, err := parseStructTag(inner.Tag.Get("protobuf"))
+	if err != nil {
+		panic(err)
+	}
+	field := &structField{
+		index:   f.Index[0],
+		wiretag: uint64(t.fieldNumber)<<3 | uint64(t.wireType),
+	}
+	field.tagsize = sizeOfVarint(field.wiretag)
+	c := w.codec(inner.Type, &walkerConfig{
+		wireType: t.wireType,
+		zigzag:   t.zigzag,
+		required: true,
+	})
+	field.codec = oneofCodecOf(wt, c)
+	return field
+}
+
+func oneofCodecOf(wt reflect.Type, c *codec) *codec {
+	// wrapper returns the wrapper held by the oneof interface v if it is of
+	// type wt.
+	wrapper := func(v reflect.Value) (reflect.Value, bool) {
+		x := v.Elem()
+		return x, x.IsValid() && x.Type() == wt && !x.IsNil()
+	}
+	elem := wt.Elem()
+
+	return &codec{
+		size: func(v reflect.Value, f *structField) int {
+			if x, ok := wrapper(v); ok {
+				return c.size(x.Elem().Field(0), f)
+			}
+			return 0
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			if x, ok := wrapper(v); ok {
+				return c.encode(b, x.Elem().Field(0), f)
+			}
+			return b
+		},
+		decode: func(b []byte, v reflect.Value) (int, error) {
+			x, ok := wrapper(v)
+			if !ok {
+				x = reflect.New(elem)
+				v.Set(x)
+			}
+			return c.decode(b, x.Elem().Field(0))
+		},
+	}
+}
+
+func (w *walker) structInfo(t reflect.Type) *structInfo {
+	if info, ok := structInfoCache.Load(t); ok {
+		return info.(*structInfo)
+	}
+	if i, ok := w.infos[t]; ok {
+		return i
+	}
+
+	info := new(structInfo)
+	w.infos[t] = info
+	numField := t.NumField()
+	fields := make([]*structField, 0, numField)
+	var wrappers []interface{}
+	for i := 0; i < numField; i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		if _, ok := f.Tag.Lookup("protobuf_extensions"); ok && f.Type == extensionsType {
+			info.extensions = extensionRangesOf(f)
+			continue
+		}
+
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok && f.Type.Kind() == reflect.Interface {
+			if wrappers == nil {
+				wrappers = oneofWrappersOf(t)
+			}
+			for _, wrapper := range wrappers {
+				if wt := reflect.TypeOf(wrapper); wt.Implements(f.Type) {
+					fields = append(fields, w.oneofField(f, wt))
+				}
+			}
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup("protobuf")
+		if !ok {
+			continue // no tag
+		}
+
+		field := structField{
+			index: i,
+		}
+
+		t, err := parseStructTag(tag)
+		if err != nil {
+			panic(err)
+		}
+		field.wiretag = uint64(t.fieldNumber)<<3 | uint64(t.wireType)
+		conf := &walkerConfig{
+			wireType: t.wireType,
+			zigzag:   t.zigzag,
+			presence: t.presence,
+		}
+		switch {
+		case reflect.PtrTo(f.Type).Implements(lazyFieldType):
+			lazy := reflect.Zero(reflect.PtrTo(f.Type)).Interface().(lazyField)
+			field.codec = lazy.codec(w.codec(lazy.elemType(), &walkerConfig{wireType: t.wireType}))
+
+		case isOptionType(f.Type):
+			conf.required = true
+			elem := reflect.Zero(reflect.PtrTo(f.Type)).Interface().(optionValue).elemType()
+			field.codec = optionCodecOf(w.codec(elem, conf))
+
+		case baseKindOf(f.Type) == reflect.Slice && f.Type.Elem().Kind() != reflect.Uint8:
+			conf.required = true
+			packable := t.wireType == varint || t.wireType == fixed32 || t.wireType == fixed64
+			field.codec = sliceCodecOf(f.Type, w.codec(f.Type.Elem(), conf), packable)
+
+		case baseKindOf(f.Type) == reflect.Map:
+			field.codec = w.mapCodec(f.Type, f.Tag.Get("protobuf_key"), f.Tag.Get("protobuf_val"))
+
+		default:
+			field.codec = w.codec(f.Type, conf)
+		}
+		field.tagsize = sizeOfVarint(field.wiretag)
+		fields = append(fields, &field)
+	}
+
+	// copy to save capacity
+	fields2 := make([]*structField, len(fields))
+	copy(fields2, fields)
+	info.fields = fields2
+
+	info.fieldIndex = make(map[fieldNumber]*structField, len(info.fields))
+	for _, f := range info.fields {
+		info.fieldIndex[f.fieldNumber()] = f
+	}
+	info.descs = fieldsOf(t)
+
+	structInfoCache.Store(t, info)
+	return info
+}
+
+// @@@ Pointers @@@
+
+func (w *walker) pointer(t reflect.Type, conf *walkerConfig) *codec {
+	switch t.Elem().Kind() {
+	case reflect.Struct:
+		if conf.wireType == startGroup {
+			return w.groupCodec(t)
+		}
+		return w.structCodec(t)
+	}
+	// common value, cached by type and configuration as the codec depends on
+	// the wire type. With the presence option, a non-nil pointer is encoded
+	// even if it points to the zero value.
+	key := pointerKey{t, *conf}
+	if c, ok := w.pointers[key]; ok {
+		return c
+	}
+	elemConf := *conf
+	elemConf.required = conf.required || conf.presence
+	c := w.codec(t.Elem(), &elemConf)
+	elem := t.Elem()
+	p := &codec{
+		size: func(v reflect.Value, f *structField) int {
+			if v.IsNil() {
+				return 0
+			}
+			return c.size(v.Elem(), f)
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			if v.IsNil() {
+				return b
+			}
+			return c.encode(b, v.Elem(), f)
+		},
+		decode: func(b []byte, v reflect.Value) (int, error) {
+			if v.IsNil() {
+				v.Set(reflect.New(elem))
+			}
+			return c.decode(b, v.Elem())
+		},
+	}
+	w.pointers[key] = p
+	return p
+}
+
+// @@@ Options @@@
+
+func isOptionType(t reflect.Type) bool {
+	switch t {
+	case optionBoolType, optionInt32Type, optionInt64Type, optionUInt32Type,
+		optionUInt64Type, optionFloat32Type, optionFloat64Type, optionStringType:
+		return true
+	}
+	return false
+}
+
+// optionCodecOf returns the codec of an Option field, c is the required
+// codec of its value.
+func optionCodecOf(c *codec) *codec {
+	return &codec{
+		size: func(v reflect.Value, f *structField) int {
+			if o := v.Addr().Interface().(optionValue); o.IsSome() {
+				return c.size(o.get(), f)
+			}
+			return 0
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			if o := v.Addr().Interface().(optionValue); o.IsSome() {
+				return c.encode(b, o.get(), f)
+			}
+			return b
+		},
+		decode: func(b []byte, v reflect.Value) (int, error) {
+			o := v.Addr().Interface().(optionValue)
+			x := reflect.New(o.elemType()).Elem()
+			n, err := c.decode(b, x)
+			o.set(x)
+			return n, err
+		},
+	}
+}
+
+// @@@ Slices @@@
+
+// sliceCodecOf returns the codec of repeated fields with elements encoded by c.
+// Repeated fields of scalar numeric types can be packed, in which case the
+// decoder also accepts the packed encoding.
+func sliceCodecOf(t reflect.Type, c *codec, packable bool) *codec {
+	elem := t.Elem()
+	s := &codec{
+		size: func(v reflect.Value, f *structField) int {
+			n := 0
+			for i := 0; i < v.Len(); i++ {
+				n += c.size(v.Index(i), f)
+			}
+			return n
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			for i := 0; i < v.Len(); i++ {
+				b = c.encode(b, v.Index(i), f)
+			}
+			return b
+		},
+		decode: func(b []byte, v reflect.Value) (int, error) {
+			x := reflect.New(elem).Elem()
+			n, err := c.decode(b, x)
+			if err == nil {
+				v.Set(reflect.Append(v, x))
+			}
+			return n, err
+		},
+	}
+	if packable {
+		s.decodePacked = slicePackedDecodeFuncOf(s.decode)
+	}
+	return s
+}
+
+func slicePackedDecodeFuncOf(decode decodeFunc) decodeFunc {
+	return func(b []byte, v reflect.Value) (int, error) {
+		p, n, err := decodeVarlen(b)
+		if err != nil {
+			return n, err
+		}
+		for len(p) > 0 {
+			l, err := decode(p, v)
+			if err != nil {
+				return n, err
+			}
+			p = p[l:]
+		}
+		return n, nil
+	}
+}
+
+// @@@ Maps @@@
+
+// mapBackend names the implementation of the map codecs, for the benchmarks.
+const mapBackend = "purego"
+
+func (w *walker) mapCodec(t reflect.Type, keyTag, valTag string) *codec {
+	m := new(codec)
+	w.codecs[t] = m
+
+	// map key and val should be encoded always
+	fieldOf := func(tag string, typ reflect.Type) *structField {
+		t, _ := parseStructTag(tag)
+		f := &structField{wiretag: uint64(t.fieldNumber)<<3 | uint64(t.wireType)}
+		f.tagsize = sizeOfVarint(f.wiretag)
+		f.codec = w.codec(typ, &walkerConfig{wireType: t.wireType, zigzag: t.zigzag, required: true})
+		return f
+	}
+	keyField := fieldOf(keyTag, t.Key())
+	valField := fieldOf(valTag, t.Elem())
+
+	entrySize := func(k, v reflect.Value) (int, int) {
+		keySize := keyField.codec.size(k, keyField)
+		valSize := valField.codec.size(v, valField)
+		return keySize, valSize
+	}
+	m.size = func(v reflect.Value, f *structField) int {
+		n := 0
+		iter := v.MapRange()
+		for iter.Next() {
+			keySize, valSize := entrySize(iter.Key(), iter.Value())
+			n += f.tagsize + sizeOfVarint(uint64(keySize+valSize)) + keySize + valSize
+		}
+		return n
+	}
+	m.encode = func(b []byte, v reflect.Value, f *structField) []byte {
+		iter := v.MapRange()
+		for iter.Next() {
+			key, val := iter.Key(), iter.Value()
+			keySize, valSize := entrySize(key, val)
+			b = appendVarint(b, f.wiretag)
+			b = appendVarint(b, uint64(keySize+valSize))
+			b = keyField.codec.encode(b, key, keyField)
+			b = valField.codec.encode(b, val, valField)
+		}
+		return b
+	}
+
+	structType := reflect.StructOf([]reflect.StructField{
+		{Name: "Key", Type: t.Key(), Tag: reflect.StructTag(`protobuf:"` + keyTag + `"`)},
+		{Name: "Elem", Type: t.Elem(), Tag: reflect.StructTag(`protobuf:"` + valTag + `"`)},
+	})
+	info := w.structInfo(structType)
+	m.decode = func(b []byte, v reflect.Value) (int, error) {
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, 10))
+		}
+		if len(b) == 0 {
+			return 0, nil
+		}
+		_, nl, err := decodeVarint(b)
+		if err != nil {
+			return 0, err
+		}
+		s := reflect.New(structType).Elem()
+		n, err := info.decode(b[nl:], s)
+		if err == nil {
+			v.SetMapIndex(s.Field(0), s.Field(1))
+		}
+		return n + nl, err
+	}
+	return m
+}
+
+// @@@ Lazy fields @@@
+
+// codec returns the codec of the field, c is the codec of *T.
+func (*Lazy[T]) codec(c *codec) *codec {
+	return &codec{
+		size: func(v reflect.Value, f *structField) int {
+			raw, m := v.Addr().Interface().(*Lazy[T]).state()
+			if m != nil {
+				return c.size(reflect.ValueOf(m), f)
+			}
+			if raw != nil {
+				return sizeOfVarlen(len(raw)) + f.tagsize
+			}
+			return 0
+		},
+		encode: func(b []byte, v reflect.Value, f *structField) []byte {
+			raw, m := v.Addr().Interface().(*Lazy[T]).state()
+			if m != nil {
+				return c.encode(b, reflect.ValueOf(m), f)
+			}
+			if raw != nil {
+				b = appendVarint(b, f.wiretag)
+				b = appendVarint(b, uint64(len(raw)))
+				b = append(b, raw...)
+			}
+			return b
+		},
+		decode: func(b []byte, v reflect.Value) (int, error) {
+			l := v.Addr().Interface().(*Lazy[T])
+			if m := l.decoded(); m != nil {
+				// merge into the decoded message
+				return c.decode(b, reflect.ValueOf(&m).Elem())
+			}
+			p, n, err := decodeVarlen(b)
+			if err != nil {
+				return n, err
+			}
+			l.appendRaw(p)
+			return n, nil
+		},
+	}
+}
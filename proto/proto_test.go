@@ -212,6 +212,77 @@ func TestBoolPointer(t *testing.T) {
 	assert.Equal(t, m, b)
 }
 
+func TestPointerZeroValue(t *testing.T) {
+	type message struct {
+		A *bool    `protobuf:"varint,1,opt"`
+		B *int32   `protobuf:"varint,2,opt"`
+		C *int32   `protobuf:"zigzag32,3,opt"`
+		D *int32   `protobuf:"fixed32,4,opt"`
+		E *string  `protobuf:"bytes,5,opt"`
+		F *float64 `protobuf:"fixed64,6,opt"`
+	}
+
+	// nil pointers and pointers to the zero value are omitted
+	f, i, s, d := false, int32(0), "", float64(0)
+	for _, m := range []*message{{}, {A: &f, B: &i, C: &i, D: &i, E: &s, F: &d}} {
+		b, err := Marshal(m)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(b))
+		assert.Equal(t, 0, Size(m))
+	}
+
+	// pointers of the same type keep the encoding of their own field
+	n := int32(-1)
+	m := &message{B: &n, C: &n, D: &n}
+	b, err := Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+		0x18, 0x01,
+		0x25, 0xff, 0xff, 0xff, 0xff,
+	}, b)
+	got := &message{}
+	assert.NoError(t, Unmarshal(b, got))
+	assert.Equal(t, m, got)
+}
+
+func TestPointerPresence(t *testing.T) {
+	type message struct {
+		A *bool    `protobuf:"varint,1,opt,presence"`
+		B *int32   `protobuf:"varint,2,opt,presence"`
+		C *int32   `protobuf:"zigzag32,3,opt,presence"`
+		D *int32   `protobuf:"fixed32,4,opt,presence"`
+		E *string  `protobuf:"bytes,5,opt,presence"`
+		F *float64 `protobuf:"fixed64,6,opt,presence"`
+		G *int32   `protobuf:"varint,7,opt"`
+	}
+
+	// nil pointers are omitted
+	b, err := Marshal(&message{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(b))
+
+	// non-nil pointers are encoded even if they point to the zero value,
+	// unless the field has no presence option
+	f, i, s, d := false, int32(0), "", float64(0)
+	m := &message{A: &f, B: &i, C: &i, D: &i, E: &s, F: &d, G: &i}
+	b, err = Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x08, 0x00,
+		0x10, 0x00,
+		0x18, 0x00,
+		0x25, 0x00, 0x00, 0x00, 0x00,
+		0x2a, 0x00,
+		0x31, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}, b)
+	assert.Equal(t, len(b), Size(m))
+	got := &message{}
+	assert.NoError(t, Unmarshal(b, got))
+	m.G = nil
+	assert.Equal(t, m, got)
+}
+
 func TestPrivateField(t *testing.T) {
 	type private struct {
 		a uint64 `protobuf:"varint,1,opt"`
@@ -355,3 +426,171 @@ func TestIssue3(t *testing.T) {
 	assert.NoError(t, Unmarshal(b, st2))
 	assert.Equal(t, st1, st2)
 }
+
+type groupMessage struct {
+	A *groupData   `protobuf:"group,1,opt"`
+	B []*groupData `protobuf:"group,2,rep"`
+	C int32        `protobuf:"varint,3,opt"`
+}
+
+type groupData struct {
+	X int32  `protobuf:"varint,4,opt"`
+	Y string `protobuf:"bytes,5,opt"`
+}
+
+func TestGroup(t *testing.T) {
+	m := &groupMessage{
+		A: &groupData{X: 1},
+		B: []*groupData{{Y: "a"}, {}},
+		C: 2,
+	}
+	b, err := Marshal(m)
+	assert.NoError(t, err)
+	assert.Len(t, b, Size(m))
+	assert.Equal(t, "0b20010c"+"132a01611413"+"14"+"1802", hex.EncodeToString(b))
+
+	p := new(groupMessage)
+	assert.NoError(t, Unmarshal(b, p))
+	assert.Equal(t, m, p)
+
+	b[len(b)-3] = 0x0c // end group tag of another field
+	assert.Error(t, Unmarshal(b, new(groupMessage)))
+}
+
+type oneofMessage struct {
+	A int32        `protobuf:"varint,1,opt"`
+	O isOneofValue `protobuf_oneof:"o"`
+}
+
+type isOneofValue interface{ isOneofValue() }
+
+type oneofInt struct {
+	I int64 `protobuf:"zigzag64,2,opt"`
+}
+
+type oneofString struct {
+	S string `protobuf:"bytes,3,opt"`
+}
+
+type oneofMessageValue struct {
+	M *message `protobuf:"bytes,4,opt"`
+}
+
+func (*oneofInt) isOneofValue()          {}
+func (*oneofString) isOneofValue()       {}
+func (*oneofMessageValue) isOneofValue() {}
+
+func (*oneofMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*oneofInt)(nil),
+		(*oneofString)(nil),
+		(*oneofMessageValue)(nil),
+	}
+}
+
+func TestOneof(t *testing.T) {
+	values := []*oneofMessage{
+		{},
+		{A: 1, O: &oneofInt{}},
+		{O: &oneofInt{I: -1}},
+		{O: &oneofString{S: "hello"}},
+		{O: &oneofMessageValue{M: &message{A: 1}}},
+	}
+	for i, v := range values {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			b, err := Marshal(v)
+			assert.NoError(t, err)
+			assert.Len(t, b, Size(v))
+
+			p := new(oneofMessage)
+			assert.NoError(t, Unmarshal(b, p))
+			assert.Equal(t, v, p)
+		})
+	}
+
+	// the last field of the oneof on the wire wins
+	p := new(oneofMessage)
+	assert.NoError(t, Unmarshal([]byte{0x10, 0x01, 0x1a, 0x01, 'a'}, p))
+	assert.Equal(t, &oneofString{S: "a"}, p.O)
+}
+
+func TestMapEntry(t *testing.T) {
+	type message struct {
+		M map[int32]int64 `protobuf:"bytes,1,rep" protobuf_key:"zigzag32,1,opt" protobuf_val:"zigzag64,2,opt"`
+	}
+
+	b, err := Marshal(&message{M: map[int32]int64{-1: -2}})
+	assert.NoError(t, err)
+	assert.Equal(t, "0a0408011003", hex.EncodeToString(b))
+
+	m := new(message)
+	assert.NoError(t, Unmarshal(b, m))
+	assert.Equal(t, map[int32]int64{-1: -2}, m.M)
+
+	// empty maps are not encoded
+	b, err = Marshal(&message{M: map[int32]int64{}})
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+
+	// an empty entry holds the default key and value
+	m = new(message)
+	assert.NoError(t, Unmarshal([]byte{0x0a, 0x00}, m))
+	assert.Equal(t, map[int32]int64{0: 0}, m.M)
+}
+
+func TestDefaults(t *testing.T) {
+	var nilMessage *testproto.Defaults
+	for _, m := range []*testproto.Defaults{nilMessage, {}} {
+		assert.Equal(t, true, m.GetBoolVal())
+		assert.Equal(t, int32(-32), m.GetInt32Val())
+		assert.Equal(t, uint64(64), m.GetUint64Val())
+		assert.Equal(t, int64(-64), m.GetSint64Val())
+		assert.Equal(t, float32(1.5), m.GetFloatVal())
+		assert.True(t, math.IsInf(m.GetDoubleVal(), -1))
+		assert.True(t, math.IsNaN(m.GetNanVal()))
+		assert.Equal(t, `hello "world"`, m.GetStringVal())
+		assert.Equal(t, []byte{1, 2}, m.GetBytesVal())
+		assert.Equal(t, testproto.Defaults_BLUE, m.GetColor())
+		assert.Equal(t, testproto.Defaults_RED, m.GetNoDefault())
+		assert.Nil(t, m.GetNested())
+		assert.Nil(t, m.GetRepeatedVal())
+	}
+
+	m := &testproto.Defaults{
+		BoolVal:  Some(false),
+		Int32Val: Some[int32](0),
+		BytesVal: []byte{},
+		Color:    Some(testproto.Defaults_GREEN),
+		Nested:   &testproto.Defaults{},
+	}
+	assert.Equal(t, false, m.GetBoolVal())
+	assert.Equal(t, int32(0), m.GetInt32Val())
+	assert.Equal(t, []byte{}, m.GetBytesVal())
+	assert.Equal(t, testproto.Defaults_GREEN, m.GetColor())
+	assert.Equal(t, int32(-32), m.GetNested().GetInt32Val())
+
+	// the default of bytes fields is copied
+	m.GetNested().GetBytesVal()[0] = 9
+	assert.Equal(t, testproto.Default_Defaults_BytesVal, m.GetNested().GetBytesVal())
+}
+
+func TestOptionalStyles(t *testing.T) {
+	var m *testproto.OptionalStyles
+	assert.Equal(t, int32(7), m.GetPointer())
+	assert.Equal(t, int32(8), m.GetValue())
+	assert.Equal(t, int32(9), m.GetOption())
+	assert.Equal(t, "", m.GetStr())
+
+	zero, s := int32(0), ""
+	m = &testproto.OptionalStyles{Pointer: &zero, Str: &s, Option: Some[int32](0)}
+	assert.Equal(t, int32(0), m.GetPointer())
+	assert.Equal(t, int32(0), m.GetValue()) // no presence
+	assert.Equal(t, int32(0), m.GetOption())
+
+	b, err := Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 0x00, 0x18, 0x00, 0x22, 0x00}, b)
+	got := &testproto.OptionalStyles{}
+	assert.NoError(t, Unmarshal(b, got))
+	assert.Equal(t, m, got)
+}
@@ -0,0 +1,135 @@
+//go:build !purego
+
+package proto
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/RomiChan/syncx"
+)
+
+// This file holds the glue between the package API and the codecs working on
+// unsafe pointers to the struct fields, see proto_purego.go for the codecs
+// of the purego build.
+
+type sizeFunc = func(unsafe.Pointer, *structField) int
+
+type encodeFunc = func([]byte, unsafe.Pointer, *structField) []byte
+
+type decodeFunc = func([]byte, unsafe.Pointer) (int, error)
+
+type codec struct {
+	size   sizeFunc
+	encode encodeFunc
+	decode decodeFunc
+
+	// decodePacked decodes the packed encoding of repeated fields, it is nil
+	// for codecs that cannot be packed.
+	decodePacked decodeFunc
+}
+
+var structInfoCache syncx.Map[unsafe.Pointer, *structInfo] // map[unsafe.Pointer]*structInfo
+var codecCache sync.Map                                    // map[reflect.Type]codec
+
+func cachedStructInfoOf(t reflect.Type) *structInfo {
+	c, ok := structInfoCache.Load(pointer(t))
+	if ok {
+		return c
+	}
+
+	w := &walker{
+		codecs:   make(map[reflect.Type]*codec),
+		groups:   make(map[reflect.Type]*codec),
+		infos:    make(map[reflect.Type]*structInfo),
+		pointers: make(map[pointerKey]*codec),
+	}
+
+	info := w.structInfo(t)
+	actual, _ := structInfoCache.LoadOrStore(pointer(t), info)
+	return actual
+}
+
+// messageSize returns the size of the message m, a pointer to a struct of
+// the type of info.
+func (info *structInfo) messageSize(m interface{}) int {
+	return info.size(pointer(m))
+}
+
+// appendMessage appends the encoding of the message m to b.
+func (info *structInfo) appendMessage(b []byte, m interface{}) []byte {
+	return info.encode(b, pointer(m))
+}
+
+// decodeMessage decodes the fields in b into the message m.
+func (info *structInfo) decodeMessage(b []byte, m interface{}) (int, error) {
+	return info.decode(b, pointer(m))
+}
+
+type iface struct {
+	typ unsafe.Pointer
+	ptr unsafe.Pointer
+}
+
+func inspect(v interface{}) (reflect.Type, unsafe.Pointer) {
+	return reflect.TypeOf(v), pointer(v)
+}
+
+func pointer(v interface{}) unsafe.Pointer {
+	return (*iface)(unsafe.Pointer(&v)).ptr
+}
+
+func (o *Option[T]) unsafePointer() unsafe.Pointer {
+	return unsafe.Pointer(&o.value)
+}
+
+func (r *extensionRanges) pointer(p unsafe.Pointer) *Extensions {
+	return (*Extensions)(unsafe.Pointer(uintptr(p) + r.offset))
+}
+
+// of returns the Extensions field of the message m.
+func (r *extensionRanges) of(m interface{}) *Extensions {
+	return r.pointer(pointer(m))
+}
+
+// codec returns the codec of the field, c is the codec of *T.
+func (*Lazy[T]) codec(c *codec) *codec {
+	return &codec{
+		size: func(p unsafe.Pointer, f *structField) int {
+			raw, v := (*Lazy[T])(p).state()
+			if v != nil {
+				return c.size(unsafe.Pointer(&v), f)
+			}
+			if raw != nil {
+				return sizeOfVarlen(len(raw)) + f.tagsize
+			}
+			return 0
+		},
+		encode: func(b []byte, p unsafe.Pointer, f *structField) []byte {
+			raw, v := (*Lazy[T])(p).state()
+			if v != nil {
+				return c.encode(b, unsafe.Pointer(&v), f)
+			}
+			if raw != nil {
+				b = appendVarint(b, f.wiretag)
+				b = appendVarint(b, uint64(len(raw)))
+				b = append(b, raw...)
+			}
+			return b
+		},
+		decode: func(b []byte, p unsafe.Pointer) (int, error) {
+			l := (*Lazy[T])(p)
+			if v := l.decoded(); v != nil {
+				// merge into the decoded message
+				return c.decode(b, unsafe.Pointer(&v))
+			}
+			v, n, err := decodeVarlen(b)
+			if err != nil {
+				return n, err
+			}
+			l.appendRaw(v)
+			return n, nil
+		},
+	}
+}
@@ -0,0 +1,494 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Kind is the protobuf type of a field.
+type Kind int
+
+const (
+	InvalidKind Kind = iota
+	BoolKind
+	Int32Kind
+	Sint32Kind
+	Uint32Kind
+	Int64Kind
+	Sint64Kind
+	Uint64Kind
+	Fixed32Kind
+	Sfixed32Kind
+	FloatKind
+	Fixed64Kind
+	Sfixed64Kind
+	DoubleKind
+	StringKind
+	BytesKind
+	MessageKind
+	GroupKind
+	MapKind
+)
+
+var kindNames = [...]string{
+	InvalidKind:  "invalid",
+	BoolKind:     "bool",
+	Int32Kind:    "int32",
+	Sint32Kind:   "sint32",
+	Uint32Kind:   "uint32",
+	Int64Kind:    "int64",
+	Sint64Kind:   "sint64",
+	Uint64Kind:   "uint64",
+	Fixed32Kind:  "fixed32",
+	Sfixed32Kind: "sfixed32",
+	FloatKind:    "float",
+	Fixed64Kind:  "fixed64",
+	Sfixed64Kind: "sfixed64",
+	DoubleKind:   "double",
+	StringKind:   "string",
+	BytesKind:    "bytes",
+	MessageKind:  "message",
+	GroupKind:    "group",
+	MapKind:      "map",
+}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "invalid"
+}
+
+// Cardinality tells whether a field is optional, required or repeated.
+type Cardinality int
+
+const (
+	Optional Cardinality = iota
+	Required
+	Repeated
+)
+
+func (c Cardinality) String() string {
+	switch c {
+	case Optional:
+		return "optional"
+	case Required:
+		return "required"
+	case Repeated:
+		return "repeated"
+	default:
+		return "invalid"
+	}
+}
+
+// Field describes a field of a message, as declared by its struct tags.
+type Field struct {
+	Name        string // Go name of the field
+	Number      int
+	Kind        Kind
+	Cardinality Cardinality // maps are repeated
+	Oneof       string      // name of the oneof the field belongs to, if any
+	Redact      bool        // the value is masked by Format and LogValue
+
+	// MapKey and MapValue are the kinds of the keys and values of map fields.
+	MapKey   Kind
+	MapValue Kind
+
+	// Type is the Go type of the values returned by Get and accepted by Set.
+	// Option[T] and pointers to scalars are unwrapped to T, Lazy[T] to *T.
+	Type reflect.Type
+
+	index   int          // index of the field in the struct
+	wrapper reflect.Type // oneof wrapper type
+	option  bool         // field of type Option[T] or Lazy[T]
+	pointer bool         // pointer to a scalar
+}
+
+// ErrUnknownField is returned by Set for field numbers that the message does
+// not declare.
+var ErrUnknownField = errors.New("unknown field")
+
+// SkipField can be returned by a WalkFunc to skip the messages nested in the
+// visited field.
+var SkipField = errors.New("skip this field")
+
+// WalkFunc is called by Walk for every populated field f of the message m,
+// path holds the fields leading from the root message to m.
+type WalkFunc func(path []Field, m interface{}, f Field) error
+
+var optionValueType = reflect.TypeOf((*optionValue)(nil)).Elem()
+
+// Fields returns the fields of the message v in declaration order. The
+// members of a oneof are returned as separate fields sharing the same Oneof.
+func Fields(v interface{}) []Field {
+	descs := messageInfo(reflect.TypeOf(v)).descs
+	return append([]Field(nil), descs...)
+}
+
+// Get returns the value of the field with number n of the message m, or nil
+// if the message has no such field. Unset optional fields and oneof members
+// return the zero value of Field.Type.
+func Get(m interface{}, n int) interface{} {
+	v, f := lookupField(m, n)
+	if f == nil {
+		return nil
+	}
+	return getField(v, f).Interface()
+}
+
+// Has reports whether the field with number n of the message m is
+// populated: options must be Some, pointers non-nil, repeated fields and
+// maps non-empty, oneof members selected and other fields non-zero.
+func Has(m interface{}, n int) bool {
+	v, f := lookupField(m, n)
+	if f == nil {
+		return false
+	}
+	return hasField(v, f)
+}
+
+// Set stores x in the field with number n of the message m. x must be
+// assignable to Field.Type, a nil x clears the field.
+func Set(m interface{}, n int, x interface{}) error {
+	v, f := lookupField(m, n)
+	if f == nil {
+		return fmt.Errorf("proto.Set(%T): %w %d", m, ErrUnknownField, n)
+	}
+	if x == nil {
+		clearField(v, f)
+		return nil
+	}
+	xv := reflect.ValueOf(x)
+	if !xv.Type().AssignableTo(f.Type) {
+		return fmt.Errorf("proto.Set(%T): cannot use %T as %s in field %s", m, x, f.Type, f.Name)
+	}
+	val := reflect.New(f.Type).Elem()
+	val.Set(xv)
+	setField(v, f, val)
+	return nil
+}
+
+// setField stores val, of type f.Type, in the field f of the struct v.
+func setField(v reflect.Value, f *Field, val reflect.Value) {
+	fv := v.Field(f.index)
+	switch {
+	case f.wrapper != nil:
+		w := reflect.New(f.wrapper.Elem())
+		w.Elem().Field(0).Set(val)
+		fv.Set(w)
+	case f.option:
+		fv.Addr().Interface().(optionValue).set(val)
+	case f.pointer:
+		p := reflect.New(f.Type)
+		p.Elem().Set(val)
+		fv.Set(p)
+	default:
+		fv.Set(val)
+	}
+}
+
+// Clear resets the field with number n of the message m to its zero value.
+// Clearing a oneof member that is not selected does nothing.
+func Clear(m interface{}, n int) {
+	v, f := lookupField(m, n)
+	if f != nil {
+		clearField(v, f)
+	}
+}
+
+// Walk calls fn for every populated field of the message m in declaration
+// order, then descends into the nested messages of the field, including the
+// elements of repeated fields and the values of maps, which are visited in
+// unspecified order.
+//
+// Walk stops at the first error returned by fn, except SkipField.
+func Walk(m interface{}, fn WalkFunc) error {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Type().Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("proto.Walk(%T): not a pointer to a struct", m))
+	}
+	if v.IsNil() {
+		return nil
+	}
+	return walkMessage(nil, v, fn)
+}
+
+func walkMessage(path []Field, m reflect.Value, fn WalkFunc) error {
+	v := m.Elem()
+	for _, f := range messageInfo(m.Type()).descs {
+		f := f
+		if !hasField(v, &f) {
+			continue
+		}
+		switch err := fn(path, m.Interface(), f); err {
+		case nil:
+		case SkipField:
+			continue
+		default:
+			return err
+		}
+
+		// fn may have changed the field
+		var nested []reflect.Value
+		x := getField(v, &f)
+		switch {
+		case f.Kind == MapKind && f.MapValue == MessageKind:
+			iter := x.MapRange()
+			for iter.Next() {
+				nested = append(nested, iter.Value())
+			}
+		case f.Kind != MessageKind && f.Kind != GroupKind:
+		case f.Cardinality == Repeated:
+			for i := 0; i < x.Len(); i++ {
+				nested = append(nested, x.Index(i))
+			}
+		default:
+			nested = append(nested, x)
+		}
+
+		sub := append(path[:len(path):len(path)], f)
+		for _, n := range nested {
+			if n.IsNil() {
+				continue
+			}
+			if err := walkMessage(sub, n, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func messageInfo(t reflect.Type) *structInfo {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("proto: %s is not a message", t))
+	}
+	return cachedStructInfoOf(t)
+}
+
+func lookupField(m interface{}, n int) (reflect.Value, *Field) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Errorf("proto: %T is not a non-nil pointer to a message", m))
+	}
+	descs := messageInfo(v.Type()).descs
+	for i := range descs {
+		if descs[i].Number == n {
+			return v.Elem(), &descs[i]
+		}
+	}
+	return v.Elem(), nil
+}
+
+func getField(v reflect.Value, f *Field) reflect.Value {
+	fv := v.Field(f.index)
+	switch {
+	case f.wrapper != nil:
+		if fv.IsNil() || fv.Elem().Type() != f.wrapper || fv.Elem().IsNil() {
+			return reflect.Zero(f.Type)
+		}
+		return fv.Elem().Elem().Field(0)
+	case f.option:
+		return fv.Addr().Interface().(optionValue).get()
+	case f.pointer:
+		if fv.IsNil() {
+			return reflect.Zero(f.Type)
+		}
+		return fv.Elem()
+	}
+	return fv
+}
+
+func hasField(v reflect.Value, f *Field) bool {
+	fv := v.Field(f.index)
+	switch {
+	case f.wrapper != nil:
+		return !fv.IsNil() && fv.Elem().Type() == f.wrapper && !fv.Elem().IsNil()
+	case f.option:
+		return fv.Addr().Interface().(optionValue).IsSome()
+	}
+	switch fv.Kind() {
+	case reflect.Ptr:
+		return !fv.IsNil()
+	case reflect.Slice, reflect.Map:
+		return fv.Len() > 0
+	}
+	return !fv.IsZero()
+}
+
+func clearField(v reflect.Value, f *Field) {
+	if f.wrapper != nil && !hasField(v, f) {
+		return
+	}
+	fv := v.Field(f.index)
+	fv.Set(reflect.Zero(fv.Type()))
+}
+
+// fieldsOf builds the field descriptors of the struct type t, it follows the
+// same rules as walker.structInfo.
+func fieldsOf(t reflect.Type) []Field {
+	var descs []Field
+	var wrappers []interface{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		if name, ok := f.Tag.Lookup("protobuf_oneof"); ok && f.Type.Kind() == reflect.Interface {
+			if wrappers == nil {
+				wrappers = oneofWrappersOf(t)
+			}
+			for _, wrapper := range wrappers {
+				wt := reflect.TypeOf(wrapper)
+				if !wt.Implements(f.Type) {
+					continue
+				}
+				inner := wt.Elem().Field(0)
+				desc := fieldOf(inner)
+				desc.index = i
+				desc.wrapper = wt
+				desc.Oneof = name
+				descs = append(descs, desc)
+			}
+			continue
+		}
+
+		if _, ok := f.Tag.Lookup("protobuf"); !ok {
+			continue // no tag
+		}
+		desc := fieldOf(f)
+		desc.index = i
+		descs = append(descs, desc)
+	}
+	return descs
+}
+
+func fieldOf(f reflect.StructField) Field {
+	t, err := parseStructTag(f.Tag.Get("protobuf"))
+	if err != nil {
+		panic(err)
+	}
+	desc := Field{
+		Name:   f.Name,
+		Number: int(t.fieldNumber),
+		Redact: t.redact,
+		Type:   f.Type,
+	}
+	if t.required {
+		desc.Cardinality = Required
+	}
+
+	typ := f.Type
+	switch {
+	case reflect.PtrTo(typ).Implements(optionValueType): // Option and Lazy
+		desc.option = true
+		typ = reflect.Zero(reflect.PtrTo(typ)).Interface().(optionValue).elemType()
+		desc.Type = typ
+	case typ.Kind() == reflect.Ptr && typ.Elem().Kind() != reflect.Struct:
+		desc.pointer = true
+		typ = typ.Elem()
+		desc.Type = typ
+	case typ.Kind() == reflect.Map:
+		desc.Kind = MapKind
+		desc.Cardinality = Repeated
+		key, _ := parseStructTag(f.Tag.Get("protobuf_key"))
+		desc.MapKey = kindOf(key, typ.Key())
+		val, _ := parseStructTag(f.Tag.Get("protobuf_val"))
+		desc.MapValue = kindOf(val, typ.Elem())
+		return desc
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8:
+		desc.Cardinality = Repeated
+		typ = typ.Elem()
+	}
+	desc.Kind = kindOf(t, typ)
+	return desc
+}
+
+// kindOf returns the kind of a value of type typ encoded with tag t.
+func kindOf(t structTag, typ reflect.Type) Kind {
+	switch t.wireType {
+	case varint:
+		switch typ.Kind() {
+		case reflect.Bool:
+			return BoolKind
+		case reflect.Int32:
+			if t.zigzag {
+				return Sint32Kind
+			}
+			return Int32Kind
+		case reflect.Int64:
+			if t.zigzag {
+				return Sint64Kind
+			}
+			return Int64Kind
+		case reflect.Uint32:
+			return Uint32Kind
+		case reflect.Uint64:
+			return Uint64Kind
+		}
+	case fixed32:
+		switch typ.Kind() {
+		case reflect.Float32:
+			return FloatKind
+		case reflect.Int32:
+			return Sfixed32Kind
+		case reflect.Uint32:
+			return Fixed32Kind
+		}
+	case fixed64:
+		switch typ.Kind() {
+		case reflect.Float64:
+			return DoubleKind
+		case reflect.Int64:
+			return Sfixed64Kind
+		case reflect.Uint64:
+			return Fixed64Kind
+		}
+	case varlen:
+		switch typ.Kind() {
+		case reflect.String:
+			return StringKind
+		case reflect.Slice:
+			return BytesKind
+		case reflect.Ptr:
+			return MessageKind
+		}
+	case startGroup:
+		return GroupKind
+	}
+	return InvalidKind
+}
+
+// oneofWrappers is implemented by messages with oneof fields, it returns the
+// wrapper types of every field of the oneofs.
+type oneofWrappers interface {
+	XXX_OneofWrappers() []interface{}
+}
+
+var oneofWrappersType = reflect.TypeOf((*oneofWrappers)(nil)).Elem()
+
+// oneofWrappersOf returns the oneof wrapper types of the message type t. The
+// oneofs of messages without XXX_OneofWrappers are ignored.
+func oneofWrappersOf(t reflect.Type) []interface{} {
+	pt := reflect.PtrTo(t)
+	if !pt.Implements(oneofWrappersType) {
+		return []interface{}{}
+	}
+	return reflect.Zero(pt).Interface().(oneofWrappers).XXX_OneofWrappers()
+}
+
+func baseKindOf(t reflect.Type) reflect.Kind {
+	return baseTypeOf(t).Kind()
+}
+
+func baseTypeOf(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
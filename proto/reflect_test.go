@@ -0,0 +1,185 @@
+package proto_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+)
+
+type reflectMessage struct {
+	A int32               `protobuf:"zigzag32,1,opt"`
+	B Option[string]      `protobuf:"bytes,2,opt"`
+	C *uint64             `protobuf:"fixed64,3,opt"`
+	D []float32           `protobuf:"fixed32,4,rep"`
+	E []byte              `protobuf:"bytes,5,opt"`
+	F *reflectMessage     `protobuf:"bytes,6,opt"`
+	G []*submessage       `protobuf:"bytes,7,rep"`
+	H map[string]*message `protobuf:"bytes,8,rep" protobuf_key:"bytes,1,opt" protobuf_val:"bytes,9,opt"`
+	I *groupData          `protobuf:"group,9,opt"`
+	J bool                `protobuf:"varint,10,req"`
+	O isOneofValue        `protobuf_oneof:"o"`
+}
+
+func (*reflectMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*oneofInt)(nil),
+		(*oneofString)(nil),
+		(*oneofMessageValue)(nil),
+	}
+}
+
+func TestFields(t *testing.T) {
+	type field struct {
+		Name        string
+		Number      int
+		Kind        Kind
+		Cardinality Cardinality
+		Oneof       string
+		Type        reflect.Type
+	}
+	want := []field{
+		{"A", 1, Sint32Kind, Optional, "", reflect.TypeOf(int32(0))},
+		{"B", 2, StringKind, Optional, "", reflect.TypeOf("")},
+		{"C", 3, Fixed64Kind, Optional, "", reflect.TypeOf(uint64(0))},
+		{"D", 4, FloatKind, Repeated, "", reflect.TypeOf([]float32(nil))},
+		{"E", 5, BytesKind, Optional, "", reflect.TypeOf([]byte(nil))},
+		{"F", 6, MessageKind, Optional, "", reflect.TypeOf((*reflectMessage)(nil))},
+		{"G", 7, MessageKind, Repeated, "", reflect.TypeOf([]*submessage(nil))},
+		{"H", 8, MapKind, Repeated, "", reflect.TypeOf(map[string]*message(nil))},
+		{"I", 9, GroupKind, Optional, "", reflect.TypeOf((*groupData)(nil))},
+		{"J", 10, BoolKind, Required, "", reflect.TypeOf(false)},
+		{"I", 2, Sint64Kind, Optional, "o", reflect.TypeOf(int64(0))},
+		{"S", 3, StringKind, Optional, "o", reflect.TypeOf("")},
+		{"M", 4, MessageKind, Optional, "o", reflect.TypeOf((*message)(nil))},
+	}
+
+	fields := Fields(&reflectMessage{})
+	var got []field
+	for _, f := range fields {
+		got = append(got, field{f.Name, f.Number, f.Kind, f.Cardinality, f.Oneof, f.Type})
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, StringKind, fields[7].MapKey)
+	assert.Equal(t, MessageKind, fields[7].MapValue)
+	assert.Equal(t, "sint32", fields[0].Kind.String())
+	assert.Equal(t, "repeated", fields[3].Cardinality.String())
+}
+
+func TestGetSetHasClear(t *testing.T) {
+	m := &reflectMessage{}
+	for _, f := range Fields(m) {
+		assert.False(t, Has(m, f.Number), f.Name)
+	}
+	assert.Equal(t, int32(0), Get(m, 1))
+	assert.Equal(t, "", Get(m, 2))
+	assert.Equal(t, uint64(0), Get(m, 3))
+	assert.Nil(t, Get(m, 100))
+	assert.False(t, Has(m, 100))
+
+	assert.NoError(t, Set(m, 1, int32(-1)))
+	assert.NoError(t, Set(m, 2, "hello"))
+	assert.NoError(t, Set(m, 3, uint64(42)))
+	assert.NoError(t, Set(m, 4, []float32{1, 2}))
+	assert.NoError(t, Set(m, 6, &reflectMessage{A: 1}))
+	assert.Equal(t, int32(-1), m.A)
+	assert.Equal(t, Some("hello"), m.B)
+	assert.Equal(t, uint64(42), *m.C)
+	assert.Equal(t, []float32{1, 2}, m.D)
+	assert.Equal(t, &reflectMessage{A: 1}, m.F)
+	for _, n := range []int{1, 2, 3, 4, 6} {
+		assert.True(t, Has(m, n))
+	}
+	assert.Equal(t, "hello", Get(m, 2))
+	assert.Equal(t, uint64(42), Get(m, 3))
+
+	// the presence of pointers and options doesn't depend on the value
+	assert.NoError(t, Set(m, 3, uint64(0)))
+	assert.True(t, Has(m, 3), "pointer to zero is set")
+	assert.NoError(t, Set(m, 2, ""))
+	assert.True(t, Has(m, 2), "Some(\"\") is set")
+
+	// oneof
+	o := &oneofMessage{}
+	assert.NoError(t, Set(o, 2, int64(-5)))
+	assert.Equal(t, &oneofInt{I: -5}, o.O)
+	assert.True(t, Has(o, 2))
+	assert.False(t, Has(o, 3))
+	assert.Equal(t, "", Get(o, 3))
+	Clear(o, 3) // not selected
+	assert.Equal(t, &oneofInt{I: -5}, o.O)
+	assert.NoError(t, Set(o, 3, "x"))
+	assert.Equal(t, &oneofString{S: "x"}, o.O)
+	assert.Equal(t, "x", Get(o, 3))
+	Clear(o, 3)
+	assert.Nil(t, o.O)
+
+	Clear(m, 2)
+	Clear(m, 3)
+	assert.NoError(t, Set(m, 4, nil))
+	assert.Equal(t, None[string](), m.B)
+	assert.Nil(t, m.C)
+	assert.Nil(t, m.D)
+
+	err := Set(m, 1, "not an int")
+	assert.Error(t, err)
+	err = Set(m, 100, int32(1))
+	assert.True(t, errors.Is(err, ErrUnknownField))
+}
+
+func TestWalk(t *testing.T) {
+	m := &reflectMessage{
+		A: 1,
+		F: &reflectMessage{
+			B: Some("secret"),
+			G: []*submessage{{X: "secret"}, nil, {Y: "y"}},
+		},
+		H: map[string]*message{"k": {S: &submessage{X: "secret"}}},
+		O: &oneofMessageValue{M: &message{A: 2}},
+	}
+
+	var visited []string
+	err := Walk(m, func(path []Field, msg interface{}, f Field) error {
+		var names []string
+		for _, p := range path {
+			names = append(names, p.Name)
+		}
+		visited = append(visited, strings.Join(append(names, f.Name), "."))
+
+		// scrub every string field
+		if f.Kind == StringKind {
+			return Set(msg, f.Number, "")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"A",
+		"F", "F.B", "F.G", "F.G.X", "F.G.Y",
+		"H", "H.S", "H.S.X",
+		"M", "M.A",
+	}, visited)
+	assert.Equal(t, Some(""), m.F.B)
+	assert.Equal(t, "", m.F.G[0].X)
+	assert.Equal(t, "", m.H["k"].S.X)
+
+	// SkipField doesn't descend and other errors stop the walk
+	visited = nil
+	errStop := errors.New("stop")
+	err = Walk(m, func(path []Field, msg interface{}, f Field) error {
+		visited = append(visited, f.Name)
+		switch f.Name {
+		case "F":
+			return SkipField
+		case "H":
+			return errStop
+		}
+		return nil
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, []string{"A", "F", "H"}, visited)
+}
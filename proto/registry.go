@@ -0,0 +1,59 @@
+package proto
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// The registry maps the full names of messages to their types. It is
+// filled by the files generated with the register=true option of
+// protoc-gen-golite, or by calls to RegisterType.
+var registry struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// RegisterType registers the full proto name of the message type of v, a
+// pointer to struct, like
+//
+//	proto.RegisterType("google.protobuf.Timestamp", (*timestamppb.Timestamp)(nil))
+//
+// It panics if the name is registered with another type.
+func RegisterType(name string, v interface{}) {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("proto.RegisterType(%q, %T): not a pointer to struct", name, v))
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if old, ok := registry.types[name]; ok {
+		if old != t {
+			panic(fmt.Errorf("proto.RegisterType(%q, %T): already registered as %s", name, v, old))
+		}
+		return
+	}
+	if registry.types == nil {
+		registry.types = make(map[string]reflect.Type)
+		registry.names = make(map[reflect.Type]string)
+	}
+	registry.types[name] = t
+	registry.names[t] = name
+}
+
+// TypeByName returns the type registered for a full proto name, nil if the
+// name is not registered.
+func TypeByName(name string) reflect.Type {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.types[name]
+}
+
+// NameOf returns the full proto name registered for the type of v, "" if
+// the type is not registered.
+func NameOf(v interface{}) string {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.names[reflect.TypeOf(v)]
+}
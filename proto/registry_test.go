@@ -0,0 +1,20 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterType(t *testing.T) {
+	RegisterType("test.Message", (*message)(nil))
+	RegisterType("test.Message", (*message)(nil))
+	assert.Equal(t, reflect.TypeOf(&message{}), TypeByName("test.Message"))
+	assert.Equal(t, "test.Message", NameOf(&message{}))
+	assert.Nil(t, TypeByName("test.Nope"))
+	assert.Equal(t, "", NameOf(message{}))
+
+	assert.Panics(t, func() { RegisterType("test.Message", (*struct{ A int32 })(nil)) })
+	assert.Panics(t, func() { RegisterType("test.Int", new(int)) })
+}
@@ -1,5 +1,7 @@
 // Code generated by gen/gen_decode.go. DO NOT EDIT.
 
+//go:build !purego
+
 package proto
 
 import (
@@ -136,6 +138,30 @@ func encodeFixed64Required(b []byte, p unsafe.Pointer, f *structField) []byte {
 	return b
 }
 
+var sfixed32RequiredCodec = codec{size: sizeOfSfixed32Required, encode: encodeSfixed32Required, decode: decodeSfixed32}
+
+func sizeOfSfixed32Required(p unsafe.Pointer, f *structField) int {
+	return 4 + f.tagsize
+}
+func encodeSfixed32Required(b []byte, p unsafe.Pointer, f *structField) []byte {
+	v := *(*int32)(p)
+	b = appendVarint(b, f.wiretag)
+	b = encodeLE32(b, uint32(v))
+	return b
+}
+
+var sfixed64RequiredCodec = codec{size: sizeOfSfixed64Required, encode: encodeSfixed64Required, decode: decodeSfixed64}
+
+func sizeOfSfixed64Required(p unsafe.Pointer, f *structField) int {
+	return 8 + f.tagsize
+}
+func encodeSfixed64Required(b []byte, p unsafe.Pointer, f *structField) []byte {
+	v := *(*int64)(p)
+	b = appendVarint(b, f.wiretag)
+	b = encodeLE64(b, uint64(v))
+	return b
+}
+
 var zigzag32RequiredCodec = codec{size: sizeOfZigzag32Required, encode: encodeZigzag32Required, decode: decodeZigzag32}
 
 func sizeOfZigzag32Required(p unsafe.Pointer, f *structField) int {
@@ -0,0 +1,297 @@
+package proto
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// knownTypesPath is the import path of the packages of the well-known types,
+// they are referenced with their google.protobuf names instead of being
+// declared.
+const knownTypesPath = "github.com/RomiChan/protobuf/proto/types/known/"
+
+var knownTypesFiles = map[string]string{
+	"anypb":       "google/protobuf/any.proto",
+	"durationpb":  "google/protobuf/duration.proto",
+	"emptypb":     "google/protobuf/empty.proto",
+	"fieldmaskpb": "google/protobuf/field_mask.proto",
+	"structpb":    "google/protobuf/struct.proto",
+	"timestamppb": "google/protobuf/timestamp.proto",
+	"wrapperspb":  "google/protobuf/wrappers.proto",
+}
+
+// SchemaOf returns a .proto file declaring the messages types, given as
+// values or pointers of message structs, and the messages their fields
+// reference. The fields are read from the struct tags like the codecs do.
+//
+// The proto package and go_package option are those of the Go package of
+// the first type. A struct named Outer_Inner is declared as the message
+// Inner nested in Outer when Outer is declared too. Option and pointer
+// fields are optional, Lazy fields lazy, zigzag fields sint32 and sint64 and
+// enums int32. The file is proto3 unless the messages have required or group
+// fields.
+func SchemaOf(types ...interface{}) (string, error) {
+	if len(types) == 0 {
+		return "", fmt.Errorf("proto.SchemaOf: no types")
+	}
+	s := &schema{
+		names:   make(map[reflect.Type]string),
+		byName:  make(map[string]reflect.Type),
+		groups:  make(map[reflect.Type]bool),
+		imports: make(map[string]bool),
+	}
+	var roots []reflect.Type
+	for _, v := range types {
+		t := reflect.TypeOf(v)
+		if t != nil {
+			t = baseTypeOf(t)
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return "", fmt.Errorf("proto.SchemaOf: %T is not a message", v)
+		}
+		roots = append(roots, t)
+	}
+	for _, t := range roots {
+		if err := s.collect(t); err != nil {
+			return "", fmt.Errorf("proto.SchemaOf: %w", err)
+		}
+	}
+	return s.file(roots[0].PkgPath()), nil
+}
+
+type schema struct {
+	order   []reflect.Type          // declared messages, in discovery order
+	names   map[reflect.Type]string // Go name of the declared messages
+	byName  map[string]reflect.Type
+	groups  map[reflect.Type]bool // messages encoded as groups
+	imports map[string]bool
+	proto2  bool
+}
+
+// collect adds the message t and the messages of its fields.
+func (s *schema) collect(t reflect.Type) error {
+	if _, ok := s.names[t]; ok || isKnownType(t) {
+		return nil
+	}
+	if other, ok := s.byName[t.Name()]; ok {
+		return fmt.Errorf("%s and %s have the same name", other, t)
+	}
+	s.names[t] = t.Name()
+	s.byName[t.Name()] = t
+	s.order = append(s.order, t)
+	for _, f := range messageInfo(t).descs {
+		if f.Cardinality == Required {
+			s.proto2 = true
+		}
+		if f.Kind == GroupKind {
+			s.proto2 = true
+			s.groups[messageTypeOf(f)] = true
+		}
+		if mt := messageTypeOf(f); mt != nil {
+			if err := s.collect(mt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// messageTypeOf returns the struct type of the message field f, or of the
+// values of the map field f, nil for the other fields.
+func messageTypeOf(f Field) reflect.Type {
+	if f.Kind != MessageKind && f.Kind != GroupKind && f.MapValue != MessageKind {
+		return nil
+	}
+	t := f.Type
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return baseTypeOf(t)
+}
+
+func isKnownType(t reflect.Type) bool {
+	return strings.HasPrefix(t.PkgPath(), knownTypesPath)
+}
+
+// parentOf returns the message t is nested in, nil for top-level messages.
+func (s *schema) parentOf(t reflect.Type) reflect.Type {
+	name := t.Name()
+	for i := strings.LastIndexByte(name, '_'); i > 0; i = strings.LastIndexByte(name[:i], '_') {
+		if p, ok := s.byName[name[:i]]; ok && p != t {
+			return p
+		}
+	}
+	return nil
+}
+
+// localName returns the name of t in its parent.
+func (s *schema) localName(t reflect.Type) string {
+	if p := s.parentOf(t); p != nil {
+		return strings.TrimPrefix(t.Name(), p.Name()+"_")
+	}
+	return t.Name()
+}
+
+// fullName returns the name referencing t from anywhere in the file.
+func (s *schema) fullName(t reflect.Type) string {
+	if isKnownType(t) {
+		s.imports[knownTypesFiles[path.Base(t.PkgPath())]] = true
+		return "google.protobuf." + t.Name()
+	}
+	if p := s.parentOf(t); p != nil {
+		return s.fullName(p) + "." + s.localName(t)
+	}
+	return t.Name()
+}
+
+func (s *schema) file(pkgPath string) string {
+	var body strings.Builder
+	for _, t := range s.order {
+		if s.parentOf(t) == nil && !s.groups[t] {
+			body.WriteByte('\n')
+			s.message(&body, t, "")
+		}
+	}
+
+	var b strings.Builder
+	if s.proto2 {
+		b.WriteString("syntax = \"proto2\";\n")
+	} else {
+		b.WriteString("syntax = \"proto3\";\n")
+	}
+	if pkg := protoPackageOf(pkgPath); pkg != "" {
+		fmt.Fprintf(&b, "\npackage %s;\n", pkg)
+	}
+	if len(s.imports) > 0 {
+		imports := make([]string, 0, len(s.imports))
+		for imp := range s.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		b.WriteByte('\n')
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "import %q;\n", imp)
+		}
+	}
+	if pkgPath != "" {
+		fmt.Fprintf(&b, "\noption go_package = %q;\n", pkgPath)
+	}
+	b.WriteString(body.String())
+	return b.String()
+}
+
+// protoPackageOf returns the proto package for the Go package pkgPath: its
+// last element, as an identifier.
+func protoPackageOf(pkgPath string) string {
+	if pkgPath == "" || pkgPath == "main" {
+		return ""
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, path.Base(pkgPath))
+}
+
+func (s *schema) message(b *strings.Builder, t reflect.Type, indent string) {
+	fmt.Fprintf(b, "%smessage %s {\n", indent, s.localName(t))
+	s.fields(b, t, indent+"  ")
+	var nested []reflect.Type
+	for _, n := range s.order {
+		if s.parentOf(n) == t && !s.groups[n] {
+			nested = append(nested, n)
+		}
+	}
+	for _, n := range nested {
+		b.WriteByte('\n')
+		s.message(b, n, indent+"  ")
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func (s *schema) fields(b *strings.Builder, t reflect.Type, indent string) {
+	descs := messageInfo(t).descs
+	for i := 0; i < len(descs); i++ {
+		f := descs[i]
+		if f.Oneof == "" {
+			s.field(b, f, indent, false)
+			continue
+		}
+		fmt.Fprintf(b, "%soneof %s {\n", indent, f.Oneof)
+		for ; i < len(descs) && descs[i].Oneof == f.Oneof; i++ {
+			s.field(b, descs[i], indent+"  ", true)
+		}
+		i--
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+func (s *schema) field(b *strings.Builder, f Field, indent string, oneof bool) {
+	var label string
+	switch {
+	case oneof:
+	case f.Kind == MapKind:
+	case f.Cardinality == Repeated:
+		label = "repeated "
+	case f.Cardinality == Required:
+		label = "required "
+	case s.proto2 || (f.option || f.pointer) && f.Kind != MessageKind:
+		label = "optional "
+	}
+	var opts []string
+	if f.option && f.Kind == MessageKind {
+		opts = append(opts, "lazy = true")
+	}
+	if f.Redact {
+		opts = append(opts, "debug_redact = true")
+	}
+	var options string
+	if len(opts) > 0 {
+		options = " [" + strings.Join(opts, ", ") + "]"
+	}
+
+	name := snakeCase(f.Name)
+	switch f.Kind {
+	case MapKind:
+		val := f.MapValue.String()
+		if f.MapValue == MessageKind {
+			val = s.fullName(messageTypeOf(f))
+		}
+		fmt.Fprintf(b, "%smap<%s, %s> %s = %d%s;\n", indent, f.MapKey, val, name, f.Number, options)
+	case GroupKind:
+		mt := messageTypeOf(f)
+		fmt.Fprintf(b, "%s%sgroup %s = %d%s {\n", indent, label, s.localName(mt), f.Number, options)
+		s.fields(b, mt, indent+"  ")
+		fmt.Fprintf(b, "%s}\n", indent)
+	case MessageKind:
+		fmt.Fprintf(b, "%s%s%s %s = %d%s;\n", indent, label, s.fullName(messageTypeOf(f)), name, f.Number, options)
+	default:
+		fmt.Fprintf(b, "%s%s%s %s = %d%s;\n", indent, label, f.Kind, name, f.Number, options)
+	}
+}
+
+// snakeCase converts the Go name of a field to a proto field name:
+// OptionalInt32 becomes optional_int32 and HTTPServer http_server.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 && name[i-1] != '_' {
+				prev := name[i-1]
+				lowerPrev := prev >= 'a' && prev <= 'z' || prev >= '0' && prev <= '9'
+				nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+				if lowerPrev || prev >= 'A' && prev <= 'Z' && nextLower {
+					b.WriteByte('_')
+				}
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package proto_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RomiChan/protobuf/dynamic"
+	"github.com/RomiChan/protobuf/internal/conformance"
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func TestSchemaOf(t *testing.T) {
+	s, err := SchemaOf(&testproto.User{})
+	require.NoError(t, err)
+	assert.Equal(t, `syntax = "proto3";
+
+package testproto;
+
+option go_package = "github.com/RomiChan/protobuf/proto/internal/testproto";
+
+message User {
+  string name = 1;
+  string email = 2;
+  int32 age = 3;
+  optional double score = 4;
+  int32 role = 5;
+  repeated string tags = 6;
+  Address address = 7;
+  repeated Address previous = 8;
+  map<string, Address> others = 9;
+  oneof contact {
+    string phone = 10;
+    Address office = 11;
+  }
+}
+
+message Address {
+  string city = 1;
+  uint32 zip = 2;
+}
+`, s)
+
+	_, err = SchemaOf(42)
+	assert.Error(t, err)
+}
+
+// TestSchemaOfParse checks that the schemas of the conformance messages
+// parse and declare the fields of the structs.
+func TestSchemaOfParse(t *testing.T) {
+	for name, m := range map[string]interface{}{
+		"conformance.TestAllTypesProto2": &conformance.TestAllTypesProto2{},
+		"conformance.TestAllTypesProto3": &conformance.TestAllTypesProto3{},
+	} {
+		s, err := SchemaOf(m)
+		require.NoError(t, err)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.proto"), []byte(s), 0o644))
+		r, err := dynamic.ParseFiles([]string{dir}, "schema.proto")
+		require.NoError(t, err, s)
+
+		schema := r.Schema(name)
+		require.NotNil(t, schema, name)
+		fields := Fields(m)
+		require.Len(t, schema.Fields, len(fields))
+		for i, f := range fields {
+			got := schema.Fields[i]
+			assert.Equal(t, f.Number, got.Number, f.Name)
+			assert.Equal(t, f.Kind, got.Kind, f.Name)
+			assert.Equal(t, f.Cardinality, got.Cardinality, f.Name)
+			assert.Equal(t, f.Oneof, got.Oneof, f.Name)
+			assert.Equal(t, f.MapKey, got.MapKey, f.Name)
+			assert.Equal(t, f.MapValue, got.MapValue, f.Name)
+		}
+	}
+}
@@ -1,11 +1,6 @@
 package proto
 
-import (
-	"math/bits"
-	"unsafe"
-)
-
-type sizeFunc = func(unsafe.Pointer, *structField) int
+import "math/bits"
 
 func sizeOfVarint(v uint64) int {
 	// This computes 1 + (bits.Len64(v)-1)/7.
@@ -1,3 +1,5 @@
+//go:build !purego
+
 package proto
 
 import (
@@ -10,19 +12,21 @@ import (
 
 var sliceMap sync.Map // map[*codec]*codec for slice
 
-func sliceCodecOf(t reflect.Type, c *codec, w *walker) *codec {
+// sliceCodecOf returns the codec of repeated fields with elements encoded by c.
+// Repeated fields of scalar numeric types can be packed, in which case the
+// decoder also accepts the packed encoding.
+func sliceCodecOf(t reflect.Type, c *codec, packable bool) *codec {
 	if loaded, ok := sliceMap.Load(c); ok {
 		return loaded.(*codec)
 	}
-	if w.codecs[t] != nil {
-		return w.codecs[t]
-	}
 	s := new(codec)
-	w.codecs[t] = s
 
 	s.size = sliceSizeFuncOf(t, c)
 	s.encode = sliceEncodeFuncOf(t, c)
 	s.decode = sliceDecodeFuncOf(t, c)
+	if packable {
+		s.decodePacked = slicePackedDecodeFuncOf(s.decode)
+	}
 
 	actualCodec, _ := sliceMap.LoadOrStore(c, s)
 	return actualCodec.(*codec)
@@ -74,6 +78,23 @@ func sliceDecodeFuncOf(t reflect.Type, c *codec) decodeFunc {
 	}
 }
 
+func slicePackedDecodeFuncOf(decode decodeFunc) decodeFunc {
+	return func(b []byte, p unsafe.Pointer) (int, error) {
+		v, n, err := decodeVarlen(b)
+		if err != nil {
+			return n, err
+		}
+		for len(v) > 0 {
+			l, err := decode(v, p)
+			if err != nil {
+				return n, err
+			}
+			v = v[l:]
+		}
+		return n, nil
+	}
+}
+
 func alignedSize(t reflect.Type) uintptr {
 	a := t.Align()
 	s := t.Size()
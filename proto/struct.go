@@ -1,16 +1,22 @@
+//go:build !purego
+
 package proto
 
 import (
 	"fmt"
 	"io"
-	"strconv"
-	"strings"
 	"unsafe"
 )
 
 type structInfo struct {
 	fields     []*structField
 	fieldIndex map[fieldNumber]*structField
+
+	// descriptors of the fields for the reflection API
+	descs []Field
+
+	// the Extensions field, nil if the message has no extension ranges
+	extensions *extensionRanges
 }
 
 type structField struct {
@@ -40,6 +46,9 @@ func (info *structInfo) size(p unsafe.Pointer) int {
 	for _, f := range info.fields {
 		n += f.codec.size(f.pointer(p), f)
 	}
+	if info.extensions != nil {
+		n += info.extensions.pointer(p).size()
+	}
 	return n
 }
 
@@ -50,12 +59,16 @@ func (info *structInfo) encode(b []byte, p unsafe.Pointer) []byte {
 	for _, f := range info.fields {
 		b = f.codec.encode(b, f.pointer(p), f)
 	}
+	if info.extensions != nil {
+		b = info.extensions.pointer(p).encode(b)
+	}
 	return b
 }
 
 func (info *structInfo) decode(b []byte, p unsafe.Pointer) (int, error) {
 	offset := 0
 	for offset < len(b) {
+		start := offset
 		fieldNumber, wireType, n, err := decodeTag(b[offset:])
 		offset += n
 		if err != nil {
@@ -82,6 +95,8 @@ func (info *structInfo) decode(b []byte, p unsafe.Pointer) (int, error) {
 				skip = 4
 			case fixed64:
 				skip = 8
+			case startGroup:
+				skip, err = decodeGroup(b[offset:], fieldNumber)
 			default:
 				err = ErrWireTypeUnknown
 			}
@@ -90,14 +105,21 @@ func (info *structInfo) decode(b []byte, p unsafe.Pointer) (int, error) {
 			} else {
 				offset, err = len(b), io.ErrUnexpectedEOF
 			}
+			if err == nil && info.extensions != nil && info.extensions.contains(fieldNumber) {
+				err = info.extensions.pointer(p).appendRaw(fieldNumber, b[start:offset])
+			}
 			if err != nil {
 				return offset, fieldError(fieldNumber, wireType, err)
 			}
 			continue
 		}
 
+		decode := f.codec.decode
 		if wireType != f.wireType() {
-			return offset, fieldError(fieldNumber, wireType, fmt.Errorf("expected wire type %d", f.wireType()))
+			if wireType != varlen || f.codec.decodePacked == nil {
+				return offset, fieldError(fieldNumber, wireType, fmt.Errorf("expected wire type %d", f.wireType()))
+			}
+			decode = f.codec.decodePacked
 		}
 
 		// `data` will only contain the section of the input buffer where
@@ -134,105 +156,30 @@ func (info *structInfo) decode(b []byte, p unsafe.Pointer) (int, error) {
 			}
 			data = b[offset : offset+8]
 
+		case startGroup:
+			n, err := decodeGroup(b[offset:], fieldNumber)
+			if err != nil {
+				return offset + n, fieldError(fieldNumber, wireType, err)
+			}
+			data = b[offset : offset+n]
+
 		default:
 			return offset, fieldError(fieldNumber, wireType, ErrWireTypeUnknown)
 		}
 
-		n, err = f.codec.decode(data, f.pointer(p))
+		if wireType == startGroup {
+			// the end group tag is as long as the start group tag
+			data = data[:len(data)-f.tagsize]
+		}
+		n, err = decode(data, f.pointer(p))
 		offset += n
 		if err != nil {
 			return offset, fieldError(fieldNumber, wireType, err)
 		}
-	}
-
-	return offset, nil
-}
-
-type structTag struct {
-	// version     int
-	wireType    wireType
-	fieldNumber fieldNumber
-	repeated    bool
-	zigzag      bool
-}
-
-func parseStructTag(tag string) (structTag, error) {
-	t := structTag{
-		// version:    2,
-	}
-
-	for i, f := range splitFields(tag) {
-		switch i {
-		case 0:
-			switch f {
-			case "varint":
-				t.wireType = varint
-			case "bytes":
-				t.wireType = varlen
-			case "fixed32":
-				t.wireType = fixed32
-			case "fixed64":
-				t.wireType = fixed64
-			case "zigzag32":
-				t.wireType = varint
-				t.zigzag = true
-			case "zigzag64":
-				t.wireType = varint
-				t.zigzag = true
-			default:
-				return t, fmt.Errorf("unsupported wire type in struct tag %q: %s", tag, f)
-			}
-
-		case 1:
-			n, err := strconv.Atoi(f)
-			if err != nil {
-				return t, fmt.Errorf("unsupported field number in struct tag %q: %w", tag, err)
-			}
-			t.fieldNumber = fieldNumber(n)
-
-		case 2:
-			switch f {
-			case "opt":
-				// not sure what this is for
-			case "rep":
-				t.repeated = true
-			default:
-				return t, fmt.Errorf("unsupported field option in struct tag %q: %s", tag, f)
-			}
-
-		default:
-			/*
-				name, value := splitNameValue(f)
-				switch name {
-				case "name":
-					t.name = value
-				case "enum":
-					t.enum = value
-				case "json":
-					t.json = value
-				case "proto3":
-					t.version = 3
-				default:
-					t.extensions[name] = value
-				}
-			*/
+		if wireType == startGroup {
+			offset += f.tagsize
 		}
 	}
 
-	return t, nil
-}
-
-func splitFields(s string) []string {
-	return strings.Split(s, ",")
-}
-
-/*
-func splitNameValue(s string) (name, value string) {
-	i := strings.IndexByte(s, '=')
-	if i < 0 {
-		return strings.TrimSpace(s), ""
-	} else {
-		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
-	}
+	return offset, nil
 }
-*/
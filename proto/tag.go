@@ -0,0 +1,110 @@
+package proto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type structTag struct {
+	// version     int
+	wireType    wireType
+	fieldNumber fieldNumber
+	repeated    bool
+	required    bool
+	zigzag      bool
+	redact      bool
+	presence    bool
+}
+
+func parseStructTag(tag string) (structTag, error) {
+	t := structTag{
+		// version:    2,
+	}
+
+	for i, f := range splitFields(tag) {
+		switch i {
+		case 0:
+			switch f {
+			case "varint":
+				t.wireType = varint
+			case "bytes":
+				t.wireType = varlen
+			case "fixed32":
+				t.wireType = fixed32
+			case "fixed64":
+				t.wireType = fixed64
+			case "group":
+				t.wireType = startGroup
+			case "zigzag32":
+				t.wireType = varint
+				t.zigzag = true
+			case "zigzag64":
+				t.wireType = varint
+				t.zigzag = true
+			default:
+				return t, fmt.Errorf("unsupported wire type in struct tag %q: %s", tag, f)
+			}
+
+		case 1:
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return t, fmt.Errorf("unsupported field number in struct tag %q: %w", tag, err)
+			}
+			t.fieldNumber = fieldNumber(n)
+
+		case 2:
+			switch f {
+			case "opt":
+				// not sure what this is for
+			case "req":
+				// required fields are encoded like optional ones
+				t.required = true
+			case "rep":
+				t.repeated = true
+			default:
+				return t, fmt.Errorf("unsupported field option in struct tag %q: %s", tag, f)
+			}
+
+		default:
+			switch f {
+			case "redact":
+				t.redact = true
+			case "presence":
+				t.presence = true
+			}
+			/*
+				name, value := splitNameValue(f)
+				switch name {
+				case "name":
+					t.name = value
+				case "enum":
+					t.enum = value
+				case "json":
+					t.json = value
+				case "proto3":
+					t.version = 3
+				default:
+					t.extensions[name] = value
+				}
+			*/
+		}
+	}
+
+	return t, nil
+}
+
+func splitFields(s string) []string {
+	return strings.Split(s, ",")
+}
+
+/*
+func splitNameValue(s string) (name, value string) {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return strings.TrimSpace(s), ""
+	} else {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+}
+*/
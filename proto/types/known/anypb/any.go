@@ -0,0 +1,91 @@
+// Package anypb contains the golite version of the well-known type
+// google.protobuf.Any, and the functions packing messages in it.
+//
+// The type URLs are resolved with the registry of the proto package: the
+// messages must be generated with the register=true option of
+// protoc-gen-golite, or registered with proto.RegisterType.
+package anypb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// URLPrefix is the prefix of the type URLs built by Pack.
+const URLPrefix = "type.googleapis.com/"
+
+// Pack marshals v into an Any, v must be a pointer to a registered message.
+func Pack(v interface{}) (*Any, error) {
+	name := proto.NameOf(v)
+	if name == "" {
+		return nil, fmt.Errorf("anypb: type %T is not registered", v)
+	}
+	b, err := proto.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Any{TypeUrl: URLPrefix + name, Value: b}, nil
+}
+
+// UnpackTo unmarshals the message of x into v, it returns an error if the
+// type of v is not the one of the type URL of x.
+func UnpackTo(x *Any, v interface{}) error {
+	if x == nil {
+		return fmt.Errorf("anypb: invalid nil Any")
+	}
+	if want := proto.NameOf(v); want == "" || want != x.MessageName() {
+		return fmt.Errorf("anypb: cannot unpack %s into %T", x.TypeUrl, v)
+	}
+	return unmarshal(x.Value, v)
+}
+
+// UnpackNew unmarshals the message of x into a new value of the type
+// registered for its type URL.
+func UnpackNew(x *Any) (interface{}, error) {
+	if x == nil {
+		return nil, fmt.Errorf("anypb: invalid nil Any")
+	}
+	t := proto.TypeByName(x.MessageName())
+	if t == nil {
+		return nil, fmt.Errorf("anypb: type %s is not registered", x.TypeUrl)
+	}
+	v := reflect.New(t.Elem()).Interface()
+	if err := unmarshal(x.Value, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// unmarshal resets v before unmarshalling b into it, as the message of an
+// Any is complete.
+func unmarshal(b []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return &proto.InvalidUnmarshalError{Type: rv.Type()}
+	}
+	rv = rv.Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	return proto.Unmarshal(b, v)
+}
+
+// MessageName returns the full name of the message in x, the part of its
+// type URL after the last '/'.
+func (x *Any) MessageName() string {
+	if x == nil {
+		return ""
+	}
+	url := x.TypeUrl
+	if i := strings.LastIndexByte(url, '/'); i >= 0 {
+		url = url[i+1:]
+	}
+	return url
+}
+
+// MessageIs reports whether x contains a message of the type of v.
+func (x *Any) MessageIs(v interface{}) bool {
+	name := proto.NameOf(v)
+	return name != "" && name == x.MessageName()
+}
@@ -0,0 +1,59 @@
+package anypb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	upstream "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	upstreamdurationpb "google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/types/known/durationpb"
+	"github.com/RomiChan/protobuf/proto/types/known/timestamppb"
+)
+
+type unregistered struct {
+	A int32 `protobuf:"varint,1,opt"`
+}
+
+func TestPack(t *testing.T) {
+	d := durationpb.FromDuration(3 * time.Second)
+	x, err := Pack(d)
+	assert.NoError(t, err)
+	assert.Equal(t, "type.googleapis.com/google.protobuf.Duration", x.TypeUrl)
+	assert.Equal(t, "google.protobuf.Duration", x.MessageName())
+	assert.True(t, x.MessageIs(&durationpb.Duration{}))
+	assert.False(t, x.MessageIs(&timestamppb.Timestamp{}))
+
+	got := &durationpb.Duration{Nanos: 1}
+	assert.NoError(t, UnpackTo(x, got))
+	assert.Equal(t, d, got)
+	assert.Error(t, UnpackTo(x, &timestamppb.Timestamp{}))
+	assert.Error(t, UnpackTo(x, (*durationpb.Duration)(nil)))
+
+	v, err := UnpackNew(x)
+	assert.NoError(t, err)
+	assert.Equal(t, d, v)
+
+	_, err = Pack(&unregistered{})
+	assert.Error(t, err)
+	_, err = UnpackNew(&Any{TypeUrl: "type.googleapis.com/foo.Bar"})
+	assert.Error(t, err)
+	_, err = UnpackNew(nil)
+	assert.Error(t, err)
+}
+
+func TestUpstream(t *testing.T) {
+	x, err := Pack(durationpb.FromDuration(time.Minute))
+	assert.NoError(t, err)
+	b, err := proto.Marshal(x)
+	assert.NoError(t, err)
+
+	u := &anypb.Any{}
+	assert.NoError(t, upstream.Unmarshal(b, u))
+	m, err := u.UnmarshalNew()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, m.(*upstreamdurationpb.Duration).AsDuration())
+}
@@ -0,0 +1,69 @@
+// Package durationpb contains the golite version of the well-known type
+// google.protobuf.Duration and the conversions from and to time.Duration.
+package durationpb
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// The range of valid durations, about ±10000 years.
+const (
+	maxValidSeconds = 315576000000
+	minValidSeconds = -maxValidSeconds
+)
+
+// FromDuration returns d as a Duration.
+func FromDuration(d time.Duration) *Duration {
+	nanos := d.Nanoseconds()
+	secs := nanos / 1e9
+	nanos -= secs * 1e9
+	return &Duration{Seconds: secs, Nanos: int32(nanos)}
+}
+
+// New is FromDuration, named like the constructor of the upstream package.
+func New(d time.Duration) *Duration {
+	return FromDuration(d)
+}
+
+// AsDuration returns x as a time.Duration, a nil Duration is 0. Durations
+// out of the range of time.Duration are clamped to its minimum or maximum.
+func (x *Duration) AsDuration() time.Duration {
+	secs, nanos := x.GetSeconds(), x.GetNanos()
+	d := time.Duration(secs) * time.Second
+	overflow := d/time.Second != time.Duration(secs)
+	d += time.Duration(nanos) * time.Nanosecond
+	overflow = overflow || (secs < 0 && nanos < 0 && d > 0)
+	overflow = overflow || (secs > 0 && nanos > 0 && d < 0)
+	if overflow {
+		switch {
+		case secs < 0:
+			return time.Duration(math.MinInt64)
+		case secs > 0:
+			return time.Duration(math.MaxInt64)
+		}
+	}
+	return d
+}
+
+// IsValid reports whether x is a non-nil valid duration.
+func (x *Duration) IsValid() bool {
+	return x.CheckValid() == nil
+}
+
+// CheckValid returns an error if x is nil, out of the range of ±10000 years,
+// has nanos out of (-1e9, 1e9) or seconds and nanos of different signs.
+func (x *Duration) CheckValid() error {
+	switch {
+	case x == nil:
+		return fmt.Errorf("durationpb: invalid nil Duration")
+	case x.Seconds < minValidSeconds || x.Seconds > maxValidSeconds:
+		return fmt.Errorf("durationpb: %v exceeds the range of ±10000 years", x)
+	case x.Nanos <= -1e9 || x.Nanos >= 1e9:
+		return fmt.Errorf("durationpb: %v has out-of-range nanos", x)
+	case (x.Seconds > 0 && x.Nanos < 0) || (x.Seconds < 0 && x.Nanos > 0):
+		return fmt.Errorf("durationpb: %v has seconds and nanos with different signs", x)
+	}
+	return nil
+}
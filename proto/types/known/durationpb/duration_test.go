@@ -0,0 +1,29 @@
+package durationpb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, 1500 * time.Millisecond, -1500 * time.Millisecond, math.MaxInt64, math.MinInt64} {
+		x := FromDuration(d)
+		assert.True(t, x.IsValid())
+		assert.Equal(t, d, x.AsDuration())
+	}
+	assert.Equal(t, &Duration{Seconds: -1, Nanos: -5e8}, FromDuration(-1500*time.Millisecond))
+	assert.Equal(t, time.Duration(math.MaxInt64), (&Duration{Seconds: maxValidSeconds}).AsDuration())
+	assert.Equal(t, time.Duration(math.MinInt64), (&Duration{Seconds: minValidSeconds}).AsDuration())
+
+	for _, x := range []*Duration{
+		nil,
+		{Seconds: maxValidSeconds + 1},
+		{Nanos: 1e9},
+		{Seconds: 1, Nanos: -1},
+	} {
+		assert.Error(t, x.CheckValid())
+	}
+}
@@ -0,0 +1,3 @@
+// Package emptypb contains the golite version of the well-known type
+// google.protobuf.Empty.
+package emptypb
@@ -0,0 +1,27 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: google/protobuf/empty.proto
+
+package emptypb
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+// A generic empty message that you can re-use to avoid defining duplicated
+// empty messages in your APIs. A typical example is to use it as the request
+// or the response type of an API method. For instance:
+//
+//	service Foo {
+//	  rpc Bar(google.protobuf.Empty) returns (google.protobuf.Empty);
+//	}
+type Empty struct {
+	_ [0]func()
+}
+
+func (x *Empty) String() string {
+	return proto.Format(x)
+}
+
+func init() {
+	proto.RegisterType("google.protobuf.Empty", (*Empty)(nil))
+}
@@ -0,0 +1,48 @@
+// Package fieldmaskpb contains the golite version of the well-known type
+// google.protobuf.FieldMask.
+package fieldmaskpb
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// New returns a FieldMask of paths.
+func New(paths ...string) *FieldMask {
+	return &FieldMask{Paths: append([]string(nil), paths...)}
+}
+
+// Append appends paths to x.
+func (x *FieldMask) Append(paths ...string) {
+	x.Paths = append(x.Paths, paths...)
+}
+
+// Normalize sorts the paths of x and removes the paths covered by another
+// one, like "a.b" by "a".
+func (x *FieldMask) Normalize() {
+	paths := append([]string(nil), x.Paths...)
+	// sort "a" and "a.b" before "a_b" to find covered paths next to the
+	// paths covering them
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.ReplaceAll(paths[i], ".", "\x00") < strings.ReplaceAll(paths[j], ".", "\x00")
+	})
+	out := paths[:0]
+	for _, p := range paths {
+		if len(out) > 0 {
+			last := out[len(out)-1]
+			if p == last || strings.HasPrefix(p, last+".") {
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	x.Paths = out
+}
+
+// IsValid reports whether the paths of x name fields of the message m, see
+// proto.CheckPaths.
+func (x *FieldMask) IsValid(m interface{}) bool {
+	return proto.CheckPaths(m, x.GetPaths()) == nil
+}
@@ -0,0 +1,19 @@
+package fieldmaskpb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	x := New("b", "a.b", "a_b", "a", "a.c.d")
+	x.Append("b", "c.d", "c")
+	x.Normalize()
+	assert.Equal(t, []string{"a", "a_b", "b", "c"}, x.Paths)
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, New("paths").IsValid(&FieldMask{}))
+	assert.False(t, New("paths", "path").IsValid(&FieldMask{}))
+}
@@ -0,0 +1,192 @@
+// Package structpb contains the golite versions of the well-known types
+// google.protobuf.Struct, Value and ListValue, and the conversions from and
+// to the Go values of encoding/json.
+//
+// The Go values are converted as follows:
+//
+//	nil                              ↔ NullValue
+//	bool                             ↔ BoolValue
+//	int, int32, int64                → NumberValue
+//	uint, uint32, uint64             → NumberValue
+//	float32, float64                 ↔ NumberValue (float64)
+//	json.Number                      → NumberValue
+//	string                           ↔ StringValue (valid UTF-8)
+//	[]byte                           → StringValue (base64)
+//	map[string]interface{}           ↔ StructValue
+//	[]interface{}                    ↔ ListValue
+package structpb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// NewStruct converts m to a Struct, see the package documentation for the
+// supported values.
+func NewStruct(m map[string]interface{}) (*Struct, error) {
+	x := &Struct{Fields: make(map[string]*Value, len(m))}
+	for k, v := range m {
+		if !utf8.ValidString(k) {
+			return nil, fmt.Errorf("structpb: invalid UTF-8 in key %q", k)
+		}
+		var err error
+		x.Fields[k], err = NewValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// AsMap converts x to a map[string]interface{}.
+func (x *Struct) AsMap() map[string]interface{} {
+	var fields map[string]*Value
+	if x != nil {
+		fields = x.Fields
+	}
+	m := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		m[k] = v.AsInterface()
+	}
+	return m
+}
+
+// NewList converts s to a ListValue, see the package documentation for the
+// supported values.
+func NewList(s []interface{}) (*ListValue, error) {
+	x := &ListValue{Values: make([]*Value, len(s))}
+	for i, v := range s {
+		var err error
+		x.Values[i], err = NewValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// AsSlice converts x to a []interface{}.
+func (x *ListValue) AsSlice() []interface{} {
+	var values []*Value
+	if x != nil {
+		values = x.Values
+	}
+	s := make([]interface{}, len(values))
+	for i, v := range values {
+		s[i] = v.AsInterface()
+	}
+	return s
+}
+
+// NewValue converts v to a Value, see the package documentation for the
+// supported values.
+func NewValue(v interface{}) (*Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return NewNullValue(), nil
+	case bool:
+		return NewBoolValue(v), nil
+	case int:
+		return NewNumberValue(float64(v)), nil
+	case int32:
+		return NewNumberValue(float64(v)), nil
+	case int64:
+		return NewNumberValue(float64(v)), nil
+	case uint:
+		return NewNumberValue(float64(v)), nil
+	case uint32:
+		return NewNumberValue(float64(v)), nil
+	case uint64:
+		return NewNumberValue(float64(v)), nil
+	case float32:
+		return NewNumberValue(float64(v)), nil
+	case float64:
+		return NewNumberValue(v), nil
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("structpb: invalid number %q: %w", v, err)
+		}
+		return NewNumberValue(n), nil
+	case string:
+		if !utf8.ValidString(v) {
+			return nil, fmt.Errorf("structpb: invalid UTF-8 in string %q", v)
+		}
+		return NewStringValue(v), nil
+	case []byte:
+		return NewStringValue(base64.StdEncoding.EncodeToString(v)), nil
+	case map[string]interface{}:
+		s, err := NewStruct(v)
+		if err != nil {
+			return nil, err
+		}
+		return NewStructValue(s), nil
+	case []interface{}:
+		l, err := NewList(v)
+		if err != nil {
+			return nil, err
+		}
+		return NewListValue(l), nil
+	}
+	return nil, fmt.Errorf("structpb: invalid type %T", v)
+}
+
+// NewNullValue returns a Value holding NullValue.
+func NewNullValue() *Value {
+	return &Value{Kind: &Value_NullValue{NullValue: NullValue_NULL_VALUE}}
+}
+
+// NewBoolValue returns a Value holding v.
+func NewBoolValue(v bool) *Value {
+	return &Value{Kind: &Value_BoolValue{BoolValue: v}}
+}
+
+// NewNumberValue returns a Value holding v.
+func NewNumberValue(v float64) *Value {
+	return &Value{Kind: &Value_NumberValue{NumberValue: v}}
+}
+
+// NewStringValue returns a Value holding v.
+func NewStringValue(v string) *Value {
+	return &Value{Kind: &Value_StringValue{StringValue: v}}
+}
+
+// NewStructValue returns a Value holding v.
+func NewStructValue(v *Struct) *Value {
+	return &Value{Kind: &Value_StructValue{StructValue: v}}
+}
+
+// NewListValue returns a Value holding v.
+func NewListValue(v *ListValue) *Value {
+	return &Value{Kind: &Value_ListValue{ListValue: v}}
+}
+
+// AsInterface converts x to a Go value, a Value without kind is nil.
+// NaN and infinite numbers are returned as the strings "NaN", "Infinity" and
+// "-Infinity" since encoding/json rejects them.
+func (x *Value) AsInterface() interface{} {
+	switch v := x.GetKind().(type) {
+	case *Value_NumberValue:
+		switch n := v.NumberValue; {
+		case math.IsNaN(n):
+			return "NaN"
+		case math.IsInf(n, 1):
+			return "Infinity"
+		case math.IsInf(n, -1):
+			return "-Infinity"
+		}
+		return v.NumberValue
+	case *Value_StringValue:
+		return v.StringValue
+	case *Value_BoolValue:
+		return v.BoolValue
+	case *Value_StructValue:
+		return v.StructValue.AsMap()
+	case *Value_ListValue:
+		return v.ListValue.AsSlice()
+	}
+	return nil
+}
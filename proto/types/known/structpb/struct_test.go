@@ -0,0 +1,66 @@
+package structpb
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	upstream "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+func TestStruct(t *testing.T) {
+	m := map[string]interface{}{
+		"null":   nil,
+		"bool":   true,
+		"number": 1.5,
+		"string": "s",
+		"struct": map[string]interface{}{"a": "b"},
+		"list":   []interface{}{1.0, "x", false},
+	}
+	x, err := NewStruct(m)
+	assert.NoError(t, err)
+	assert.Equal(t, m, x.AsMap())
+
+	b, err := proto.Marshal(x)
+	assert.NoError(t, err)
+	u := &structpb.Struct{}
+	assert.NoError(t, upstream.Unmarshal(b, u))
+	assert.Equal(t, m, u.AsMap())
+
+	y := &Struct{}
+	assert.NoError(t, proto.Unmarshal(b, y))
+	assert.Equal(t, m, y.AsMap())
+}
+
+func TestValue(t *testing.T) {
+	for _, tt := range []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{int32(-3), -3.0},
+		{uint64(3), 3.0},
+		{float32(0.5), 0.5},
+		{json.Number("12"), 12.0},
+		{[]byte("hi"), "aGk="},
+		{math.Inf(-1), "-Infinity"},
+	} {
+		v, err := NewValue(tt.in)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, v.AsInterface())
+	}
+	assert.Nil(t, (&Value{}).AsInterface())
+
+	for _, in := range []interface{}{
+		struct{}{},
+		"\xff",
+		map[string]interface{}{"\xff": 1},
+		[]interface{}{map[string]interface{}{"a": complex(1, 1)}},
+	} {
+		_, err := NewValue(in)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package timestamppb contains the golite version of the well-known type
+// google.protobuf.Timestamp and the conversions from and to time.Time.
+package timestamppb
+
+import (
+	"fmt"
+	"time"
+)
+
+// The range of valid timestamps, 0001-01-01T00:00:00Z to
+// 9999-12-31T23:59:59.999999999Z.
+const (
+	minValidSeconds = -62135596800
+	maxValidSeconds = 253402300800
+)
+
+// Now returns the current time as a Timestamp.
+func Now() *Timestamp {
+	return FromTime(time.Now())
+}
+
+// FromTime returns t as a Timestamp.
+func FromTime(t time.Time) *Timestamp {
+	return &Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// New is FromTime, named like the constructor of the upstream package.
+func New(t time.Time) *Timestamp {
+	return FromTime(t)
+}
+
+// AsTime returns x as a time.Time in UTC, a nil Timestamp is the Unix epoch.
+// The result is unspecified if x is invalid, see CheckValid.
+func (x *Timestamp) AsTime() time.Time {
+	return time.Unix(x.GetSeconds(), int64(x.GetNanos())).UTC()
+}
+
+// IsValid reports whether x is a non-nil valid timestamp.
+func (x *Timestamp) IsValid() bool {
+	return x.CheckValid() == nil
+}
+
+// CheckValid returns an error if x is nil, out of the range from
+// 0001-01-01 to 9999-12-31 or has nanos out of [0, 1e9).
+func (x *Timestamp) CheckValid() error {
+	switch {
+	case x == nil:
+		return fmt.Errorf("timestamppb: invalid nil Timestamp")
+	case x.Seconds < minValidSeconds:
+		return fmt.Errorf("timestamppb: %v before 0001-01-01", x)
+	case x.Seconds >= maxValidSeconds:
+		return fmt.Errorf("timestamppb: %v after 9999-12-31", x)
+	case x.Nanos < 0 || x.Nanos >= 1e9:
+		return fmt.Errorf("timestamppb: %v has out-of-range nanos", x)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package timestamppb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	upstream "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+func TestTimestamp(t *testing.T) {
+	now := time.Unix(1650000000, 123456789).UTC()
+	x := FromTime(now)
+	assert.Equal(t, &Timestamp{Seconds: 1650000000, Nanos: 123456789}, x)
+	assert.Equal(t, now, x.AsTime())
+	assert.True(t, x.IsValid())
+	assert.Equal(t, time.Unix(0, 0).UTC(), (*Timestamp)(nil).AsTime())
+
+	b, err := proto.Marshal(x)
+	assert.NoError(t, err)
+	u := &timestamppb.Timestamp{}
+	assert.NoError(t, upstream.Unmarshal(b, u))
+	assert.Equal(t, now, u.AsTime())
+
+	for _, x := range []*Timestamp{
+		nil,
+		{Seconds: minValidSeconds - 1},
+		{Seconds: maxValidSeconds},
+		{Nanos: -1},
+		{Nanos: 1e9},
+	} {
+		assert.Error(t, x.CheckValid())
+	}
+}
@@ -0,0 +1,30 @@
+// Package wrapperspb contains the golite versions of the well-known wrapper
+// types, like google.protobuf.Int64Value.
+package wrapperspb
+
+// Double returns a DoubleValue holding v.
+func Double(v float64) *DoubleValue { return &DoubleValue{Value: v} }
+
+// Float returns a FloatValue holding v.
+func Float(v float32) *FloatValue { return &FloatValue{Value: v} }
+
+// Int64 returns an Int64Value holding v.
+func Int64(v int64) *Int64Value { return &Int64Value{Value: v} }
+
+// UInt64 returns a UInt64Value holding v.
+func UInt64(v uint64) *UInt64Value { return &UInt64Value{Value: v} }
+
+// Int32 returns an Int32Value holding v.
+func Int32(v int32) *Int32Value { return &Int32Value{Value: v} }
+
+// UInt32 returns a UInt32Value holding v.
+func UInt32(v uint32) *UInt32Value { return &UInt32Value{Value: v} }
+
+// Bool returns a BoolValue holding v.
+func Bool(v bool) *BoolValue { return &BoolValue{Value: v} }
+
+// String returns a StringValue holding v.
+func String(v string) *StringValue { return &StringValue{Value: v} }
+
+// Bytes returns a BytesValue holding v.
+func Bytes(v []byte) *BytesValue { return &BytesValue{Value: v} }
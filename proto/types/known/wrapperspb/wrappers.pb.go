@@ -0,0 +1,199 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: google/protobuf/wrappers.proto
+
+package wrapperspb
+
+import (
+	proto "github.com/RomiChan/protobuf/proto"
+)
+
+// Wrapper message for `double`.
+//
+// The JSON representation for `DoubleValue` is JSON number.
+type DoubleValue struct {
+	// The double value.
+	Value float64 `protobuf:"fixed64,1,opt"`
+	_     [0]func()
+}
+
+func (x *DoubleValue) String() string {
+	return proto.Format(x)
+}
+
+func (x *DoubleValue) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `float`.
+//
+// The JSON representation for `FloatValue` is JSON number.
+type FloatValue struct {
+	// The float value.
+	Value float32 `protobuf:"fixed32,1,opt"`
+	_     [0]func()
+}
+
+func (x *FloatValue) String() string {
+	return proto.Format(x)
+}
+
+func (x *FloatValue) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `int64`.
+//
+// The JSON representation for `Int64Value` is JSON string.
+type Int64Value struct {
+	// The int64 value.
+	Value int64 `protobuf:"varint,1,opt"`
+	_     [0]func()
+}
+
+func (x *Int64Value) String() string {
+	return proto.Format(x)
+}
+
+func (x *Int64Value) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `uint64`.
+//
+// The JSON representation for `UInt64Value` is JSON string.
+type UInt64Value struct {
+	// The uint64 value.
+	Value uint64 `protobuf:"varint,1,opt"`
+	_     [0]func()
+}
+
+func (x *UInt64Value) String() string {
+	return proto.Format(x)
+}
+
+func (x *UInt64Value) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `int32`.
+//
+// The JSON representation for `Int32Value` is JSON number.
+type Int32Value struct {
+	// The int32 value.
+	Value int32 `protobuf:"varint,1,opt"`
+	_     [0]func()
+}
+
+func (x *Int32Value) String() string {
+	return proto.Format(x)
+}
+
+func (x *Int32Value) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `uint32`.
+//
+// The JSON representation for `UInt32Value` is JSON number.
+type UInt32Value struct {
+	// The uint32 value.
+	Value uint32 `protobuf:"varint,1,opt"`
+	_     [0]func()
+}
+
+func (x *UInt32Value) String() string {
+	return proto.Format(x)
+}
+
+func (x *UInt32Value) GetValue() uint32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Wrapper message for `bool`.
+//
+// The JSON representation for `BoolValue` is JSON `true` and `false`.
+type BoolValue struct {
+	// The bool value.
+	Value bool `protobuf:"varint,1,opt"`
+	_     [0]func()
+}
+
+func (x *BoolValue) String() string {
+	return proto.Format(x)
+}
+
+func (x *BoolValue) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+// Wrapper message for `string`.
+//
+// The JSON representation for `StringValue` is JSON string.
+type StringValue struct {
+	// The string value.
+	Value string `protobuf:"bytes,1,opt"`
+	_     [0]func()
+}
+
+func (x *StringValue) String() string {
+	return proto.Format(x)
+}
+
+func (x *StringValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Wrapper message for `bytes`.
+//
+// The JSON representation for `BytesValue` is JSON string.
+type BytesValue struct {
+	// The bytes value.
+	Value []byte `protobuf:"bytes,1,opt"`
+}
+
+func (x *BytesValue) String() string {
+	return proto.Format(x)
+}
+
+func (x *BytesValue) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType("google.protobuf.DoubleValue", (*DoubleValue)(nil))
+	proto.RegisterType("google.protobuf.FloatValue", (*FloatValue)(nil))
+	proto.RegisterType("google.protobuf.Int64Value", (*Int64Value)(nil))
+	proto.RegisterType("google.protobuf.UInt64Value", (*UInt64Value)(nil))
+	proto.RegisterType("google.protobuf.Int32Value", (*Int32Value)(nil))
+	proto.RegisterType("google.protobuf.UInt32Value", (*UInt32Value)(nil))
+	proto.RegisterType("google.protobuf.BoolValue", (*BoolValue)(nil))
+	proto.RegisterType("google.protobuf.StringValue", (*StringValue)(nil))
+	proto.RegisterType("google.protobuf.BytesValue", (*BytesValue)(nil))
+}
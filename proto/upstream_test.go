@@ -0,0 +1,113 @@
+package proto_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	upstreampb "google.golang.org/protobuf/proto"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto/upstream"
+)
+
+func TestProto2Upstream(t *testing.T) {
+	v := &testproto.Proto2{
+		Int32Val:    Int32(-1),
+		Fixed32Val:  Uint32(math.MaxUint32),
+		Fixed64Val:  Uint64(math.MaxUint64),
+		Sint32Val:   Int32(math.MinInt32),
+		Sint64Val:   Int64(math.MinInt64),
+		Sfixed32Val: Int32(-2),
+		Sfixed64Val: Int64(math.MinInt64),
+	}
+	want := &upstream.Proto2{
+		Int32Val:    upstreampb.Int32(-1),
+		Fixed32Val:  upstreampb.Uint32(math.MaxUint32),
+		Fixed64Val:  upstreampb.Uint64(math.MaxUint64),
+		Sint32Val:   upstreampb.Int32(math.MinInt32),
+		Sint64Val:   upstreampb.Int64(math.MinInt64),
+		Sfixed32Val: upstreampb.Int32(-2),
+		Sfixed64Val: upstreampb.Int64(math.MinInt64),
+	}
+	assertUpstreamRoundTrip(t, v, new(testproto.Proto2), want, new(upstream.Proto2))
+}
+
+func TestRepeatedUpstream(t *testing.T) {
+	v := &testproto.Repeated{
+		BoolVal:        []bool{true, false},
+		Int32Val:       []int32{-1, 0, 1},
+		Uint32Val:      []uint32{math.MaxUint32},
+		Int64Val:       []int64{math.MinInt64},
+		Uint64Val:      []uint64{math.MaxUint64},
+		FloatVal:       []float32{1.5, -2},
+		DoubleVal:      []float64{math.Pi},
+		StringVal:      []string{"", "A"},
+		BytesVal:       [][]byte{{}, {1, 2}},
+		Fixed32Val:     []uint32{0, 1, math.MaxUint32},
+		Fixed64Val:     []uint64{0, math.MaxUint64},
+		Sint32Val:      []int32{math.MinInt32, -1},
+		Sint64Val:      []int64{math.MinInt64, math.MaxInt64},
+		Sfixed32Val:    []int32{-2, 2},
+		Sfixed64Val:    []int64{-3, 3},
+		PackedInt32:    []int32{-1, 300},
+		PackedUint64:   []uint64{1 << 63},
+		PackedFloat:    []float32{0.25},
+		PackedDouble:   []float64{-0.5, 1e100},
+		PackedFixed32:  []uint32{7, 8, 9},
+		PackedFixed64:  []uint64{10},
+		PackedSint32:   []int32{-64, 64},
+		PackedSint64:   []int64{-1},
+		PackedSfixed32: []int32{math.MinInt32},
+		PackedSfixed64: []int64{math.MaxInt64, -4},
+		PackedBool:     []bool{false, true, true},
+		FixedMap:       map[uint32]int64{1: -1, math.MaxUint32: math.MinInt64},
+	}
+	want := &upstream.Repeated{
+		BoolVal:        v.BoolVal,
+		Int32Val:       v.Int32Val,
+		Uint32Val:      v.Uint32Val,
+		Int64Val:       v.Int64Val,
+		Uint64Val:      v.Uint64Val,
+		FloatVal:       v.FloatVal,
+		DoubleVal:      v.DoubleVal,
+		StringVal:      v.StringVal,
+		BytesVal:       v.BytesVal,
+		Fixed32Val:     v.Fixed32Val,
+		Fixed64Val:     v.Fixed64Val,
+		Sint32Val:      v.Sint32Val,
+		Sint64Val:      v.Sint64Val,
+		Sfixed32Val:    v.Sfixed32Val,
+		Sfixed64Val:    v.Sfixed64Val,
+		PackedInt32:    v.PackedInt32,
+		PackedUint64:   v.PackedUint64,
+		PackedFloat:    v.PackedFloat,
+		PackedDouble:   v.PackedDouble,
+		PackedFixed32:  v.PackedFixed32,
+		PackedFixed64:  v.PackedFixed64,
+		PackedSint32:   v.PackedSint32,
+		PackedSint64:   v.PackedSint64,
+		PackedSfixed32: v.PackedSfixed32,
+		PackedSfixed64: v.PackedSfixed64,
+		PackedBool:     v.PackedBool,
+		FixedMap:       v.FixedMap,
+	}
+	assertUpstreamRoundTrip(t, v, new(testproto.Repeated), want, new(upstream.Repeated))
+}
+
+// assertUpstreamRoundTrip checks that v and want, the same message built with
+// golite and upstream generated types, decode from each other's encoding.
+func assertUpstreamRoundTrip(t *testing.T, v, vOut interface{}, want, wantOut upstreampb.Message) {
+	t.Helper()
+
+	b, err := Marshal(v)
+	assert.NoError(t, err)
+	assert.NoError(t, upstreampb.Unmarshal(b, wantOut))
+	assert.True(t, upstreampb.Equal(want, wantOut), "upstream decoded %v", wantOut)
+
+	b, err = upstreampb.Marshal(want)
+	assert.NoError(t, err)
+	assert.NoError(t, Unmarshal(b, vOut))
+	assert.Equal(t, v, vOut)
+}
@@ -0,0 +1,86 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a constraint not satisfied by a field of a message.
+type Violation struct {
+	// Field is the path of the field from the validated message, with the
+	// proto names of the fields, like "owner.tags[1]".
+	Field   string
+	Message string
+}
+
+// ValidationError is returned by the Validate methods generated from
+// buf.validate constraints, it lists all the violations of a message.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("validation error:")
+	for i, v := range e.Violations {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(" ")
+		b.WriteString(v.Field)
+		b.WriteString(": ")
+		b.WriteString(v.Message)
+	}
+	return b.String()
+}
+
+// Validator collects the violations in the generated Validate methods.
+type Validator struct {
+	violations []Violation
+}
+
+// Addf adds a violation of field.
+func (v *Validator) Addf(field, format string, a ...interface{}) {
+	v.violations = append(v.violations, Violation{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// Nested validates the message m of field if it has a Validate method,
+// the paths of its violations are prefixed by field.
+func (v *Validator) Nested(field string, m interface{}) {
+	vm, ok := m.(interface{ Validate() error })
+	if !ok {
+		return
+	}
+	err := vm.Validate()
+	if err == nil {
+		return
+	}
+	e, ok := err.(*ValidationError)
+	if !ok {
+		v.violations = append(v.violations, Violation{Field: field, Message: err.Error()})
+		return
+	}
+	for _, violation := range e.Violations {
+		violation.Field = field + "." + violation.Field
+		v.violations = append(v.violations, violation)
+	}
+}
+
+// NestedElem validates the element m of the repeated or map field, at the
+// index or map key key.
+func (v *Validator) NestedElem(field string, key interface{}, m interface{}) {
+	if s, ok := key.(string); ok {
+		v.Nested(fmt.Sprintf("%s[%q]", field, s), m)
+	} else {
+		v.Nested(fmt.Sprintf("%s[%v]", field, key), m)
+	}
+}
+
+// Err returns a *ValidationError of the collected violations, nil if there
+// are none.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
@@ -0,0 +1,77 @@
+package proto_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/proto/internal/testproto"
+)
+
+func validUser() *testproto.User {
+	return &testproto.User{
+		Name:    "golite",
+		Email:   "golite@example.com",
+		Age:     20,
+		Score:   Some(0.5),
+		Role:    testproto.Role_ROLE_ADMIN,
+		Tags:    []string{"a"},
+		Address: &testproto.Address{City: "Paris", Zip: 75001},
+		Contact: &testproto.User_Phone{Phone: "12345678901"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var nilUser *testproto.User
+	assert.NoError(t, nilUser.Validate())
+	assert.NoError(t, validUser().Validate())
+
+	m := validUser()
+	m.Name = ""
+	m.Email = "golite"
+	m.Age = 150
+	m.Score = Some(0.0)
+	m.Role = 3
+	m.Tags = nil
+	m.Address = nil
+	m.Previous = []*testproto.Address{{City: "Lyon", Zip: 69001}, {Zip: 1}}
+	m.Others = map[string]*testproto.Address{"home": {City: "Nice"}}
+	m.Contact = &testproto.User_Phone{Phone: "123"}
+
+	err := m.Validate()
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, []Violation{
+		{Field: "name", Message: "value length must be at least 1 character(s)"},
+		{Field: "email", Message: `value does not match regex pattern "^[^@]+@[^@]+$"`},
+		{Field: "age", Message: "value must be less than 150"},
+		{Field: "score", Message: "value must be greater than 0"},
+		{Field: "role", Message: "value must be one of the defined enum values"},
+		{Field: "tags", Message: "value must contain at least 1 item(s)"},
+		{Field: "address", Message: "value is required"},
+		{Field: "previous[1].city", Message: "value is required"},
+		{Field: "previous[1].zip", Message: "value must be greater than or equal to 10000"},
+		{Field: `others["home"].zip`, Message: "value must be greater than or equal to 10000"},
+		{Field: "phone", Message: "value length must be 11 character(s)"},
+	}, verr.Violations)
+
+	m = validUser()
+	m.Contact = &testproto.User_Office{Office: &testproto.Address{City: "Paris"}}
+	assert.EqualError(t, m.Validate(), "validation error: office.zip: value must be greater than or equal to 10000")
+}
+
+func TestValidateInfinity(t *testing.T) {
+	m := &testproto.Reading{Temperature: 20, Ratio: math.MaxFloat64, Missing: math.NaN()}
+	assert.NoError(t, m.Validate())
+
+	m = &testproto.Reading{Temperature: float32(math.Inf(-1)), Ratio: math.NaN()}
+	var verr *ValidationError
+	assert.ErrorAs(t, m.Validate(), &verr)
+	assert.Equal(t, []Violation{
+		{Field: "temperature", Message: "value must be greater than -Inf"},
+		{Field: "ratio", Message: "value must be less than or equal to +Inf"},
+		{Field: "missing", Message: "value must equal NaN"},
+	}, verr.Violations)
+}
@@ -1,29 +1,32 @@
+//go:build !purego
+
 package proto
 
 import (
 	"reflect"
+	"sync"
 	"unsafe"
 )
 
-var (
-	optionBoolType    = reflect.TypeOf((*Option[bool])(nil)).Elem()
-	optionInt32Type   = reflect.TypeOf((*Option[int32])(nil)).Elem()
-	optionInt64Type   = reflect.TypeOf((*Option[int64])(nil)).Elem()
-	optionUInt32Type  = reflect.TypeOf((*Option[uint32])(nil)).Elem()
-	optionUInt64Type  = reflect.TypeOf((*Option[uint64])(nil)).Elem()
-	optionFloat32Type = reflect.TypeOf((*Option[float32])(nil)).Elem()
-	optionFloat64Type = reflect.TypeOf((*Option[float64])(nil)).Elem()
-	optionStringType  = reflect.TypeOf((*Option[string])(nil)).Elem()
-)
-
 type walker struct {
-	codecs map[reflect.Type]*codec
-	infos  map[reflect.Type]*structInfo
+	codecs   map[reflect.Type]*codec
+	groups   map[reflect.Type]*codec
+	infos    map[reflect.Type]*structInfo
+	pointers map[pointerKey]*codec
 }
 
 type walkerConfig struct {
+	wireType wireType
 	zigzag   bool
 	required bool
+	presence bool // a non-nil pointer to the zero value is encoded
+}
+
+// pointerKey identifies the codec of a pointer to a scalar, which depends on
+// the configuration of the field as well as on the type.
+type pointerKey struct {
+	t    reflect.Type
+	conf walkerConfig
 }
 
 func (w *walker) codec(t reflect.Type, conf *walkerConfig) *codec {
@@ -40,15 +43,27 @@ func (w *walker) codec(t reflect.Type, conf *walkerConfig) *codec {
 		if conf.zigzag {
 			return &zigzag32Codec
 		}
+		if conf.wireType == fixed32 {
+			return &sfixed32Codec
+		}
 		return &int32Codec
 	case reflect.Int64:
 		if conf.zigzag {
 			return &zigzag64Codec
 		}
+		if conf.wireType == fixed64 {
+			return &sfixed64Codec
+		}
 		return &int64Codec
 	case reflect.Uint32:
+		if conf.wireType == fixed32 {
+			return &fixed32Codec
+		}
 		return &uint32Codec
 	case reflect.Uint64:
+		if conf.wireType == fixed64 {
+			return &fixed64Codec
+		}
 		return &uint64Codec
 	case reflect.Float32:
 		return &float32Codec
@@ -120,15 +135,101 @@ func (w *walker) structCodec(t reflect.Type) *codec {
 	return actualCodec.(*codec)
 }
 
-func baseKindOf(t reflect.Type) reflect.Kind {
-	return baseTypeOf(t).Kind()
+var groupCodecCache sync.Map // map[unsafe.Pointer]*codec
+
+// groupCodec returns the codec of a message encoded with the deprecated group
+// encoding, where the fields are delimited by start and end group tags.
+func (w *walker) groupCodec(t reflect.Type) *codec {
+	if c, ok := groupCodecCache.Load(pointer(t)); ok {
+		return c.(*codec)
+	}
+	if c, ok := w.groups[t]; ok {
+		return c
+	}
+	c := new(codec)
+	w.groups[t] = c
+	elem := t.Elem()
+	info := w.structInfo(elem)
+	c.size = func(p unsafe.Pointer, f *structField) int {
+		p = deref(p)
+		if p != nil {
+			return info.size(p) + 2*f.tagsize
+		}
+		return 0
+	}
+	c.encode = func(b []byte, p unsafe.Pointer, f *structField) []byte {
+		p = deref(p)
+		if p != nil {
+			b = appendVarint(b, f.wiretag)
+			b = info.encode(b, p)
+			b = appendVarint(b, f.wiretag&^7|uint64(endGroup))
+		}
+		return b
+	}
+	c.decode = func(b []byte, p unsafe.Pointer) (int, error) {
+		// b only holds the fields of the group, the end group tag is
+		// consumed by the caller.
+		v := (*unsafe.Pointer)(p)
+		if *v == nil {
+			*v = unsafe.Pointer(reflect.New(elem).Pointer())
+		}
+		return info.decode(b, *v)
+	}
+	actualCodec, _ := groupCodecCache.LoadOrStore(pointer(t), c)
+	return actualCodec.(*codec)
 }
 
-func baseTypeOf(t reflect.Type) reflect.Type {
-	for t.Kind() == reflect.Ptr {
-		t = t.Elem()
+// oneofField returns the field for the wrapper type wt of the oneof field f.
+// The wrapper is a pointer to a struct with a single field, which is encoded
+// as long as the wrapper is set, even if it holds the zero value.
+func (w *walker) oneofField(f reflect.StructField, wt reflect.Type) *structField {
+	inner := wt.Elem().Field(0)
+	t, err := parseStructTag(inner.Tag.Get("protobuf"))
+	if err != nil {
+		panic(err)
+	}
+	field := &structField{
+		offset:  f.Offset,
+		wiretag: uint64(t.fieldNumber)<<3 | uint64(t.wireType),
+	}
+	field.tagsize = sizeOfVarint(field.wiretag)
+	c := w.codec(inner.Type, &walkerConfig{
+		wireType: t.wireType,
+		zigzag:   t.zigzag,
+		required: true,
+	})
+	field.codec = oneofCodecOf(f.Type, wt, inner.Offset, c)
+	return field
+}
+
+func oneofCodecOf(ifaceType, wt reflect.Type, offset uintptr, c *codec) *codec {
+	// the itab of wt for the oneof interface identifies which wrapper is set
+	v := reflect.New(ifaceType).Elem()
+	v.Set(reflect.Zero(wt))
+	itab := (*iface)(unsafe.Pointer(v.UnsafeAddr())).typ
+	elem := wt.Elem()
+
+	return &codec{
+		size: func(p unsafe.Pointer, f *structField) int {
+			if i := (*iface)(p); i.typ == itab && i.ptr != nil {
+				return c.size(unsafe.Pointer(uintptr(i.ptr)+offset), f)
+			}
+			return 0
+		},
+		encode: func(b []byte, p unsafe.Pointer, f *structField) []byte {
+			if i := (*iface)(p); i.typ == itab && i.ptr != nil {
+				return c.encode(b, unsafe.Pointer(uintptr(i.ptr)+offset), f)
+			}
+			return b
+		},
+		decode: func(b []byte, p unsafe.Pointer) (int, error) {
+			i := (*iface)(p)
+			if i.typ != itab || i.ptr == nil {
+				*i = iface{typ: itab, ptr: unsafe.Pointer(reflect.New(elem).Pointer())}
+			}
+			return c.decode(b, unsafe.Pointer(uintptr(i.ptr)+offset))
+		},
 	}
-	return t
 }
 
 func (w *walker) structInfo(t reflect.Type) *structInfo {
@@ -143,12 +244,30 @@ func (w *walker) structInfo(t reflect.Type) *structInfo {
 	w.infos[t] = info
 	numField := t.NumField()
 	fields := make([]*structField, 0, numField)
+	var wrappers []interface{}
 	for i := 0; i < numField; i++ {
 		f := t.Field(i)
 		if f.PkgPath != "" {
 			continue // unexported
 		}
 
+		if _, ok := f.Tag.Lookup("protobuf_extensions"); ok && f.Type == extensionsType {
+			info.extensions = extensionRangesOf(f)
+			continue
+		}
+
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok && f.Type.Kind() == reflect.Interface {
+			if wrappers == nil {
+				wrappers = oneofWrappersOf(t)
+			}
+			for _, wrapper := range wrappers {
+				if wt := reflect.TypeOf(wrapper); wt.Implements(f.Type) {
+					fields = append(fields, w.oneofField(f, wt))
+				}
+			}
+			continue
+		}
+
 		tag, ok := f.Tag.Lookup("protobuf")
 		if !ok {
 			continue // no tag
@@ -163,59 +282,53 @@ func (w *walker) structInfo(t reflect.Type) *structInfo {
 			panic(err)
 		}
 		field.wiretag = uint64(t.fieldNumber)<<3 | uint64(t.wireType)
-		switch t.wireType {
-		case fixed32:
-			switch f.Type {
-			case optionFloat32Type:
-				field.codec = &float32OptionCodec
-			case optionUInt32Type:
-				field.codec = &fixed32OptionCodec
-			}
-			switch baseKindOf(f.Type) {
-			case reflect.Uint32:
-				field.codec = &fixed32Codec
-			case reflect.Float32:
-				field.codec = &float32Codec
-			}
-		case fixed64:
-			switch f.Type {
-			case optionUInt64Type:
-				field.codec = &fixed64OptionCodec
-			case optionFloat64Type:
-				field.codec = &float64OptionCodec
-			}
-			switch baseKindOf(f.Type) {
-			case reflect.Uint64:
-				field.codec = &fixed64Codec
-			case reflect.Float64:
-				field.codec = &float64Codec
-			}
+		if pt := reflect.PtrTo(f.Type); pt.Implements(lazyFieldType) {
+			lazy := reflect.Zero(pt).Interface().(lazyField)
+			field.codec = lazy.codec(w.codec(lazy.elemType(), &walkerConfig{wireType: t.wireType}))
 		}
-		if field.codec == nil {
-			switch f.Type {
-			case optionBoolType:
-				field.codec = &boolOptionCodec
-			case optionInt32Type:
+		switch f.Type {
+		case optionBoolType:
+			field.codec = &boolOptionCodec
+		case optionInt32Type:
+			switch {
+			case t.zigzag:
+				field.codec = &zigzag32OptionCodec
+			case t.wireType == fixed32:
+				field.codec = &sfixed32OptionCodec
+			default:
 				field.codec = &int32OptionCodec
-				if t.zigzag {
-					field.codec = &zigzag32OptionCodec
-				}
-			case optionInt64Type:
+			}
+		case optionInt64Type:
+			switch {
+			case t.zigzag:
+				field.codec = &zigzag64OptionCodec
+			case t.wireType == fixed64:
+				field.codec = &sfixed64OptionCodec
+			default:
 				field.codec = &int64OptionCodec
-				if t.zigzag {
-					field.codec = &zigzag64OptionCodec
-				}
-			case optionUInt32Type:
-				field.codec = &uint32OptionCodec
-			case optionUInt64Type:
-				field.codec = &uint64OptionCodec
-			case optionStringType:
-				field.codec = &stringOptionCodec
 			}
+		case optionUInt32Type:
+			field.codec = &uint32OptionCodec
+			if t.wireType == fixed32 {
+				field.codec = &fixed32OptionCodec
+			}
+		case optionUInt64Type:
+			field.codec = &uint64OptionCodec
+			if t.wireType == fixed64 {
+				field.codec = &fixed64OptionCodec
+			}
+		case optionFloat32Type:
+			field.codec = &float32OptionCodec
+		case optionFloat64Type:
+			field.codec = &float64OptionCodec
+		case optionStringType:
+			field.codec = &stringOptionCodec
 		}
 		if field.codec == nil {
 			conf := &walkerConfig{
-				zigzag: t.zigzag,
+				wireType: t.wireType,
+				zigzag:   t.zigzag,
+				presence: t.presence,
 				// required: t.required,
 			}
 			switch baseKindOf(f.Type) {
@@ -229,23 +342,30 @@ func (w *walker) structInfo(t reflect.Type) *structInfo {
 				} else {
 					conf.required = true
 					field.codec = w.codec(elem, conf)
-					field.codec = sliceCodecOf(f.Type, field.codec, w)
+					packable := t.wireType == varint || t.wireType == fixed32 || t.wireType == fixed64
+					field.codec = sliceCodecOf(f.Type, field.codec, packable)
 				}
 
 			case reflect.Map:
 				conf.required = true // map key and val should be encoded always
 				key, val := f.Type.Key(), f.Type.Elem()
-				m := &mapField{wiretag: field.wiretag}
+				m := &mapField{
+					wiretag: field.wiretag,
+					keyTag:  f.Tag.Get("protobuf_key"),
+					valTag:  f.Tag.Get("protobuf_val"),
+				}
 
-				t, _ := parseStructTag(f.Tag.Get("protobuf_key"))
+				t, _ := parseStructTag(m.keyTag)
 				keyField := &structField{wiretag: uint64(t.fieldNumber)<<3 | uint64(t.wireType)}
 				keyField.tagsize = sizeOfVarint(keyField.wiretag)
+				conf.wireType = t.wireType
 				conf.zigzag = t.zigzag
 				keyField.codec = w.codec(key, conf)
 
-				t, _ = parseStructTag(f.Tag.Get("protobuf_val"))
+				t, _ = parseStructTag(m.valTag)
 				valFiled := &structField{wiretag: uint64(t.fieldNumber)<<3 | uint64(t.wireType)}
 				valFiled.tagsize = sizeOfVarint(valFiled.wiretag)
+				conf.wireType = t.wireType
 				conf.zigzag = t.zigzag
 				valFiled.codec = w.codec(val, conf)
 
@@ -270,6 +390,7 @@ func (w *walker) structInfo(t reflect.Type) *structInfo {
 	for _, f := range info.fields {
 		info.fieldIndex[f.fieldNumber()] = f
 	}
+	info.descs = fieldsOf(t)
 
 	structInfoCache.Store(pointer(t), info)
 	return info
@@ -284,12 +405,23 @@ func deref(p unsafe.Pointer) unsafe.Pointer {
 func (w *walker) pointer(t reflect.Type, conf *walkerConfig) *codec {
 	switch t.Elem().Kind() {
 	case reflect.Struct:
+		if conf.wireType == startGroup {
+			return w.groupCodec(t)
+		}
 		return w.structCodec(t)
 	}
-	// common value
+	// common value, cached by type and configuration as the codec depends on
+	// the wire type. With the presence option, a non-nil pointer is encoded
+	// even if it points to the zero value.
+	key := pointerKey{t, *conf}
+	if c, ok := w.pointers[key]; ok {
+		return c
+	}
 	p := new(codec)
-	w.codecs[t] = p
-	c := w.codec(t.Elem(), conf)
+	w.pointers[key] = p
+	elemConf := *conf
+	elemConf.required = conf.required || conf.presence
+	c := w.codec(t.Elem(), &elemConf)
 	p.size = pointerSizeFuncOf(t, c)
 	p.encode = pointerEncodeFuncOf(t, c)
 	p.decode = pointerDecodeFuncOf(t, c)
@@ -308,15 +440,27 @@ func (w *walker) required(t reflect.Type, conf *walkerConfig) *codec {
 		if conf.zigzag {
 			return &zigzag32RequiredCodec
 		}
+		if conf.wireType == fixed32 {
+			return &sfixed32RequiredCodec
+		}
 		return &int32RequiredCodec
 	case reflect.Int64:
 		if conf.zigzag {
 			return &zigzag64RequiredCodec
 		}
+		if conf.wireType == fixed64 {
+			return &sfixed64RequiredCodec
+		}
 		return &int64RequiredCodec
 	case reflect.Uint32:
+		if conf.wireType == fixed32 {
+			return &fixed32RequiredCodec
+		}
 		return &uint32RequiredCodec
 	case reflect.Uint64:
+		if conf.wireType == fixed64 {
+			return &fixed64RequiredCodec
+		}
 		return &uint64RequiredCodec
 	case reflect.Float32:
 		return &float32RequiredCodec
@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/RomiChan/protobuf/proto"
+)
+
+// ContentType is the content type of binary protobuf messages in Connect.
+const ContentType = "application/proto"
+
+// TwirpContentType is the content type of binary protobuf messages in Twirp,
+// the handlers accept it as well.
+const TwirpContentType = "application/protobuf"
+
+// DefaultMaxBodySize is the size limit of the requests of the handlers
+// returned by NewHandler, unless MaxBodySize is given.
+const DefaultMaxBodySize = 4 << 20
+
+// HandlerOption configures the handlers returned by NewHandler.
+type HandlerOption func(*handler)
+
+// MaxBodySize limits the size of the requests to n bytes, larger requests
+// fail with ResourceExhausted before they are read.
+func MaxBodySize(n int64) HandlerOption {
+	return func(h *handler) { h.maxBodySize = n }
+}
+
+// NewHandler returns an http.Handler serving the methods of desc implemented
+// by srv, and the path prefix to mount it on, "/package.Service/".
+//
+//	mux.Handle(rpc.NewHandler(&pb.Greeter_ServiceDesc, srv))
+func NewHandler(desc *ServiceDesc, srv interface{}, opts ...HandlerOption) (string, http.Handler) {
+	ht := reflect.TypeOf(desc.HandlerType).Elem()
+	if st := reflect.TypeOf(srv); !st.Implements(ht) {
+		panic(fmt.Errorf("rpc: %s does not implement %s", st, ht))
+	}
+	h := &handler{
+		srv:         srv,
+		methods:     make(map[string]*MethodDesc, len(desc.Methods)),
+		maxBodySize: DefaultMaxBodySize,
+	}
+	for i := range desc.Methods {
+		m := &desc.Methods[i]
+		h.methods[MethodPath(desc.ServiceName, m.MethodName)] = m
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return "/" + desc.ServiceName + "/", h
+}
+
+type handler struct {
+	srv         interface{}
+	methods     map[string]*MethodDesc
+	maxBodySize int64
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m, ok := h.methods[r.URL.Path]
+	if !ok {
+		writeError(w, Errorf(Unimplemented, "method %s not found", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != ContentType && contentType != TwirpContentType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		// MaxBytesReader returns the bytes up to the limit, then fails
+		if int64(len(body)) >= h.maxBodySize {
+			writeError(w, Errorf(ResourceExhausted, "request larger than %d bytes", h.maxBodySize))
+			return
+		}
+		writeError(w, Errorf(Unknown, "read request: %v", err))
+		return
+	}
+	dec := func(v interface{}) error {
+		if err := proto.Unmarshal(body, v); err != nil {
+			return Errorf(InvalidArgument, "decode request: %v", err)
+		}
+		return nil
+	}
+	res, err := m.Handler(h.srv, r.Context(), dec)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	b, err := proto.Marshal(res)
+	if err != nil {
+		writeError(w, Errorf(Internal, "encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(b)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		// the message of other errors may hold internal details, only their
+		// code is sent
+		code := CodeOf(err)
+		e = &Error{Code: code, Message: strings.ReplaceAll(string(code), "_", " ")}
+	}
+	b, _ := json.Marshal(e)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(e.Code))
+	w.Write(b)
+}
+
+// HTTPClient is an Invoker calling the methods served by NewHandler, or by
+// a Connect or Twirp server. Twirp servers need ContentType set to
+// TwirpContentType.
+type HTTPClient struct {
+	// BaseURL is prepended to the method paths, like "http://localhost:8080"
+	// or "https://example.com/twirp".
+	BaseURL string
+	// Client is used to send the requests, http.DefaultClient if nil.
+	Client *http.Client
+	// ContentType is the content type of the requests, ContentType if
+	// empty.
+	ContentType string
+}
+
+// errorBody is the JSON body of the errors, Twirp sends the message as msg.
+type errorBody struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	Msg     string `json:"msg"`
+}
+
+func (c *HTTPClient) Invoke(ctx context.Context, method string, req, res interface{}) error {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.BaseURL, "/")+method, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	contentType := c.ContentType
+	if contentType == "" {
+		contentType = ContentType
+	}
+	r.Header.Set("Content-Type", contentType)
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var body errorBody
+		if json.Unmarshal(b, &body) != nil || body.Code == "" {
+			return &Error{Code: codeOfStatus(resp.StatusCode), Message: resp.Status}
+		}
+		if body.Message == "" {
+			body.Message = body.Msg
+		}
+		return &Error{Code: body.Code, Message: body.Message}
+	}
+	return proto.Unmarshal(b, res)
+}
+
+func httpStatus(c Code) int {
+	switch c {
+	case Canceled:
+		return 499
+	case InvalidArgument, FailedPrecondition, OutOfRange:
+		return http.StatusBadRequest
+	case DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case NotFound:
+		return http.StatusNotFound
+	case AlreadyExists, Aborted:
+		return http.StatusConflict
+	case PermissionDenied:
+		return http.StatusForbidden
+	case ResourceExhausted:
+		return http.StatusTooManyRequests
+	case Unimplemented:
+		return http.StatusNotImplemented
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	case Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+func codeOfStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidArgument
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusNotFound, http.StatusNotImplemented:
+		return Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return Unavailable
+	}
+	return Unknown
+}
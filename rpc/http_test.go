@@ -0,0 +1,189 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RomiChan/protobuf/proto"
+	"github.com/RomiChan/protobuf/rpc"
+	"github.com/RomiChan/protobuf/rpc/internal/echo"
+)
+
+type echoServer struct {
+	echo.UnimplementedEchoServer
+}
+
+func (echoServer) Say(ctx context.Context, req *echo.SayRequest) (*echo.SayResponse, error) {
+	switch {
+	case req.Repeat < 0:
+		return nil, rpc.Errorf(rpc.InvalidArgument, "negative repeat %d", req.Repeat)
+	case req.Repeat > 1000:
+		return nil, fmt.Errorf("say: %w", rpc.Errorf(rpc.ResourceExhausted, "too many"))
+	case req.Repeat > 100:
+		return nil, errors.New("too many")
+	}
+	res := &echo.SayResponse{}
+	for i := int32(0); i < req.Repeat; i++ {
+		res.Messages = append(res.Messages, req.Message)
+	}
+	return res, nil
+}
+
+func newServer(t *testing.T, srv echo.EchoServer) echo.EchoClient {
+	mux := http.NewServeMux()
+	mux.Handle(rpc.NewHandler(&echo.Echo_ServiceDesc, srv))
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return echo.NewEchoClient(&rpc.HTTPClient{BaseURL: s.URL, Client: s.Client()})
+}
+
+func TestHTTP(t *testing.T) {
+	client := newServer(t, echoServer{})
+	ctx := context.Background()
+
+	res, err := client.Say(ctx, &echo.SayRequest{Message: "hi", Repeat: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hi", "hi"}, res.Messages)
+
+	res, err = client.Say(ctx, &echo.SayRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Messages)
+
+	_, err = client.Say(ctx, &echo.SayRequest{Repeat: -1})
+	assert.Equal(t, &rpc.Error{Code: rpc.InvalidArgument, Message: "negative repeat -1"}, err)
+
+	// the messages of other errors are not sent
+	_, err = client.Say(ctx, &echo.SayRequest{Repeat: 101})
+	assert.Equal(t, &rpc.Error{Code: rpc.Unknown, Message: "unknown"}, err)
+
+	_, err = client.Say(ctx, &echo.SayRequest{Repeat: 1001})
+	assert.Equal(t, &rpc.Error{Code: rpc.ResourceExhausted, Message: "too many"}, err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.Say(ctx, &echo.SayRequest{})
+	assert.Equal(t, rpc.Canceled, rpc.CodeOf(err))
+}
+
+func TestHTTPUnimplemented(t *testing.T) {
+	client := newServer(t, echo.UnimplementedEchoServer{})
+	_, err := client.Say(context.Background(), &echo.SayRequest{})
+	assert.Equal(t, rpc.Unimplemented, rpc.CodeOf(err))
+}
+
+func TestHTTPProtocol(t *testing.T) {
+	_, h := rpc.NewHandler(&echo.Echo_ServiceDesc, echoServer{})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	body, _ := proto.Marshal(&echo.SayRequest{Message: "a", Repeat: 1})
+	post := func(path, contentType string, body []byte) *http.Response {
+		t.Helper()
+		res, err := s.Client().Post(s.URL+path, contentType, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	// Twirp content type
+	res := post("/echo.Echo/Say", rpc.TwirpContentType, body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, rpc.TwirpContentType, res.Header.Get("Content-Type"))
+	b, _ := io.ReadAll(res.Body)
+	m := &echo.SayResponse{}
+	assert.NoError(t, proto.Unmarshal(b, m))
+	assert.Equal(t, []string{"a"}, m.Messages)
+
+	res = post("/echo.Echo/Say", "application/json", []byte("{}"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+
+	res = post("/echo.Echo/Nope", rpc.ContentType, body)
+	assert.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	b, _ = io.ReadAll(res.Body)
+	assert.JSONEq(t, `{"code":"unimplemented","message":"method /echo.Echo/Nope not found"}`, string(b))
+
+	res = post("/echo.Echo/Say", rpc.ContentType, []byte{0x0a, 0x05})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, err := s.Client().Get(s.URL + "/echo.Echo/Say")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	assert.Panics(t, func() { rpc.NewHandler(&echo.Echo_ServiceDesc, struct{}{}) })
+}
+
+func TestHTTPMaxBodySize(t *testing.T) {
+	_, h := rpc.NewHandler(&echo.Echo_ServiceDesc, echoServer{}, rpc.MaxBodySize(8))
+	s := httptest.NewServer(h)
+	defer s.Close()
+	client := echo.NewEchoClient(&rpc.HTTPClient{BaseURL: s.URL, Client: s.Client()})
+	ctx := context.Background()
+
+	res, err := client.Say(ctx, &echo.SayRequest{Message: "abc", Repeat: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, res.Messages)
+
+	_, err = client.Say(ctx, &echo.SayRequest{Message: "too long"})
+	assert.Equal(t, rpc.ResourceExhausted, rpc.CodeOf(err))
+}
+
+// TestHTTPTwirp calls a stand-in for a Twirp server, which only accepts its
+// content type and sends the error messages as msg.
+func TestHTTPTwirp(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != rpc.TwirpContentType {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"code":"bad_route","msg":"unexpected Content-Type"}`)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		req := &echo.SayRequest{}
+		if err := proto.Unmarshal(b, req); err != nil || req.Repeat < 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"code":"invalid_argument","msg":"negative repeat","meta":{"argument":"repeat"}}`)
+			return
+		}
+		b, _ = proto.Marshal(&echo.SayResponse{Messages: []string{req.Message}})
+		w.Header().Set("Content-Type", rpc.TwirpContentType)
+		w.Write(b)
+	}))
+	defer s.Close()
+	ctx := context.Background()
+
+	client := echo.NewEchoClient(&rpc.HTTPClient{BaseURL: s.URL, Client: s.Client(), ContentType: rpc.TwirpContentType})
+	res, err := client.Say(ctx, &echo.SayRequest{Message: "hi", Repeat: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hi"}, res.Messages)
+
+	_, err = client.Say(ctx, &echo.SayRequest{Repeat: -1})
+	assert.Equal(t, &rpc.Error{Code: rpc.InvalidArgument, Message: "negative repeat"}, err)
+
+	// the Connect content type is rejected by Twirp
+	client = echo.NewEchoClient(&rpc.HTTPClient{BaseURL: s.URL, Client: s.Client()})
+	_, err = client.Say(ctx, &echo.SayRequest{})
+	assert.Equal(t, &rpc.Error{Code: "bad_route", Message: "unexpected Content-Type"}, err)
+}
+
+func TestInvokerFunc(t *testing.T) {
+	var method string
+	client := echo.NewEchoClient(rpc.InvokerFunc(func(ctx context.Context, m string, req, res interface{}) error {
+		method = m
+		res.(*echo.SayResponse).Messages = []string{req.(*echo.SayRequest).Message}
+		return nil
+	}))
+	res, err := client.Say(context.Background(), &echo.SayRequest{Message: "local"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"local"}, res.Messages)
+	assert.Equal(t, echo.Echo_Say_FullMethodName, method)
+}
@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-golite. DO NOT EDIT.
+// source: echo.proto
+
+package echo
+
+import (
+	context "context"
+	proto "github.com/RomiChan/protobuf/proto"
+	rpc "github.com/RomiChan/protobuf/rpc"
+)
+
+type SayRequest struct {
+	Message string `protobuf:"bytes,1,opt"`
+	Repeat  int32  `protobuf:"varint,2,opt"`
+	_       [0]func()
+}
+
+func (x *SayRequest) String() string {
+	return proto.Format(x)
+}
+
+func (x *SayRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SayRequest) GetRepeat() int32 {
+	if x != nil {
+		return x.Repeat
+	}
+	return 0
+}
+
+type SayResponse struct {
+	Messages []string `protobuf:"bytes,1,rep"`
+}
+
+func (x *SayResponse) String() string {
+	return proto.Format(x)
+}
+
+func (x *SayResponse) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Streaming method echo.Echo.Watch is not supported by golite, skipped.
+
+const (
+	Echo_Say_FullMethodName = "/echo.Echo/Say"
+)
+
+// EchoClient is the client API for Echo service.
+type EchoClient interface {
+	// Say repeats the message of the request.
+	Say(ctx context.Context, in *SayRequest) (*SayResponse, error)
+}
+
+type echoClient struct {
+	inv rpc.Invoker
+}
+
+// NewEchoClient returns a EchoClient calling the methods with inv.
+func NewEchoClient(inv rpc.Invoker) EchoClient {
+	return &echoClient{inv}
+}
+
+func (c *echoClient) Say(ctx context.Context, in *SayRequest) (*SayResponse, error) {
+	out := new(SayResponse)
+	if err := c.inv.Invoke(ctx, Echo_Say_FullMethodName, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EchoServer is the server API for Echo service.
+type EchoServer interface {
+	// Say repeats the message of the request.
+	Say(context.Context, *SayRequest) (*SayResponse, error)
+}
+
+// UnimplementedEchoServer can be embedded to have forward compatible implementations.
+type UnimplementedEchoServer struct{}
+
+func (UnimplementedEchoServer) Say(context.Context, *SayRequest) (*SayResponse, error) {
+	return nil, rpc.Errorf(rpc.Unimplemented, "method Say not implemented")
+}
+
+func _Echo_Say_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(EchoServer).Say(ctx, in)
+}
+
+// Echo_ServiceDesc is the rpc.ServiceDesc of Echo service.
+var Echo_ServiceDesc = rpc.ServiceDesc{
+	ServiceName: "echo.Echo",
+	HandlerType: (*EchoServer)(nil),
+	Methods: []rpc.MethodDesc{
+		{
+			MethodName: "Say",
+			Handler:    _Echo_Say_Handler,
+		},
+	},
+}
@@ -0,0 +1,114 @@
+// Package rpc is the runtime of the services generated by protoc-gen-golite.
+//
+// A generated service has a server interface, a client built on an Invoker
+// and a ServiceDesc describing its methods. Invoker and ServiceDesc don't
+// depend on a transport, NewHandler and HTTPClient bind them to net/http with
+// the unary protocol of Connect and Twirp: requests are POSTed to
+// /package.Service/Method with the binary message as body. HTTPClient sends
+// the content type of Connect unless told to use the one of Twirp.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Invoker invokes unary RPCs, it is implemented by transports.
+type Invoker interface {
+	// Invoke calls method with the request message req and decodes the
+	// response into res.
+	Invoke(ctx context.Context, method string, req, res interface{}) error
+}
+
+// InvokerFunc is an adapter to use a function as an Invoker.
+type InvokerFunc func(ctx context.Context, method string, req, res interface{}) error
+
+func (f InvokerFunc) Invoke(ctx context.Context, method string, req, res interface{}) error {
+	return f(ctx, method, req, res)
+}
+
+// ServiceDesc describes a service for the servers.
+type ServiceDesc struct {
+	// ServiceName is the full name of the service, like "package.Service".
+	ServiceName string
+	// HandlerType is a pointer to the server interface of the service, it is
+	// used to check the implementation given to the servers.
+	HandlerType interface{}
+	Methods     []MethodDesc
+}
+
+// MethodDesc describes a unary method of a service.
+type MethodDesc struct {
+	MethodName string
+	// Handler decodes the request with dec and calls the method of srv.
+	Handler func(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error)
+}
+
+// MethodPath returns the path of a method, "/package.Service/Method".
+func MethodPath(service, method string) string {
+	return "/" + service + "/" + method
+}
+
+// Code is the status code of an RPC error, with the names used by Connect
+// and Twirp.
+type Code string
+
+const (
+	Canceled           Code = "canceled"
+	Unknown            Code = "unknown"
+	InvalidArgument    Code = "invalid_argument"
+	DeadlineExceeded   Code = "deadline_exceeded"
+	NotFound           Code = "not_found"
+	AlreadyExists      Code = "already_exists"
+	PermissionDenied   Code = "permission_denied"
+	ResourceExhausted  Code = "resource_exhausted"
+	FailedPrecondition Code = "failed_precondition"
+	Aborted            Code = "aborted"
+	OutOfRange         Code = "out_of_range"
+	Unimplemented      Code = "unimplemented"
+	Internal           Code = "internal"
+	Unavailable        Code = "unavailable"
+	DataLoss           Code = "data_loss"
+	Unauthenticated    Code = "unauthenticated"
+)
+
+// Error is an error with a status code, it is returned by clients for
+// failed calls. Servers send the *Error in the chain of the errors returned
+// by methods; for other errors, only the code is sent, Unknown unless they
+// are context errors, so that their messages don't leak to clients. The
+// errors are sent as JSON with the message under "message", clients also
+// read the "msg" of Twirp.
+type Error struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Errorf returns an *Error with the code c and a formatted message.
+func Errorf(c Code, format string, a ...interface{}) *Error {
+	return &Error{Code: c, Message: fmt.Sprintf(format, a...)}
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return "rpc error: " + string(e.Code)
+	}
+	return "rpc error: " + string(e.Code) + ": " + e.Message
+}
+
+// CodeOf returns the code of err: the code of an *Error in its chain,
+// Canceled or DeadlineExceeded for context errors and Unknown otherwise.
+func CodeOf(err error) Code {
+	var e *Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &e):
+		return e.Code
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	}
+	return Unknown
+}